@@ -0,0 +1,72 @@
+// Command arena plays two AI strategies against each other and prints how
+// they did, e.g.
+//
+//	go run ./cmd/arena -game tictactoe -a optimal -b medium -n 1000
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"os"
+	"os/signal"
+	"sort"
+	"time"
+
+	"github.com/Manishk5507/gaMerZ/backend/internal/arena"
+)
+
+func main() {
+	var cfg arena.Config
+	flag.StringVar(&cfg.Game, "game", "tictactoe", "game type")
+	flag.StringVar(&cfg.A, "a", "", "first strategy")
+	flag.StringVar(&cfg.B, "b", "", "second strategy")
+	flag.IntVar(&cfg.Games, "n", 100, "number of games")
+	flag.IntVar(&cfg.Workers, "workers", 0, "parallel games (default: number of CPUs)")
+	asJSON := flag.Bool("json", false, "print the report as JSON")
+	verbose := flag.Bool("v", false, "keep the games' move logs")
+	list := flag.Bool("list", false, "list the strategies of every game and exit")
+	flag.Parse()
+
+	if *list {
+		all := arena.Strategies()
+		var names []string
+		for name := range all { names = append(names, name) }
+		sort.Strings(names)
+		for _, name := range names { fmt.Printf("%-12s %v\n", name, all[name]) }
+		return
+	}
+	cfg.Verbose = *verbose
+	if !*verbose { log.SetOutput(io.Discard) }
+	rand.Seed(time.Now().UnixNano())
+
+	// Ctrl-C stops the run and still prints the games played so far
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	rep, err := arena.Run(ctx, cfg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "arena:", err)
+		os.Exit(2)
+	}
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(rep)
+		return
+	}
+	played := rep.AWins + rep.BWins + rep.Draws
+	pct := func(n int) float64 { return 100 * float64(n) / float64(max1(played)) }
+	fmt.Printf("%s: %s vs %s, %d games on %d workers in %.0fms", rep.Game, rep.A, rep.B, played, rep.Workers, rep.ElapsedMs)
+	if rep.Incomplete { fmt.Printf(" (interrupted)") }
+	fmt.Println()
+	fmt.Printf("  %-10s wins %5d (%5.1f%%)  %.3fms/move\n", rep.A, rep.AWins, pct(rep.AWins), rep.AMoveMs)
+	fmt.Printf("  %-10s wins %5d (%5.1f%%)  %.3fms/move\n", rep.B, rep.BWins, pct(rep.BWins), rep.BMoveMs)
+	fmt.Printf("  %-10s      %5d (%5.1f%%)\n", "draws", rep.Draws, pct(rep.Draws))
+	fmt.Printf("  %.1f moves and %.2fms per game\n", rep.AvgMoves, rep.AvgGameMs)
+}
+
+func max1(n int) int { if n < 1 { return 1 }; return n }
@@ -0,0 +1,212 @@
+package achievements
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Manishk5507/gaMerZ/backend/internal/events"
+)
+
+// Cond compares one event stat against Value. Op is one of eq, ne, lt, lte,
+// gt, gte; ordering ops only apply to numbers.
+type Cond struct {
+	Stat  string `json:"stat"`
+	Op    string `json:"op"`
+	Value any    `json:"value"`
+}
+
+// Rule declares an achievement. An event counts towards it when the game type
+// matches, the player's outcome is one of Outcomes (any if empty) and every
+// Where condition holds. The badge unlocks once Goal events have counted.
+type Rule struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Game        string   `json:"game,omitempty"` // empty matches every game
+	Outcomes    []string `json:"outcomes,omitempty"`
+	Where       []Cond   `json:"where,omitempty"`
+	Goal        int      `json:"goal"`
+}
+
+// Status is a user's progress on one rule.
+type Status struct {
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Goal        int        `json:"goal"`
+	Progress    int        `json:"progress"`
+	Unlocked    bool       `json:"unlocked"`
+	UnlockedAt  *time.Time `json:"unlockedAt,omitempty"`
+}
+
+type progress struct {
+	count      int
+	unlockedAt *time.Time
+	games      map[string]bool // event keys already counted
+}
+
+// Engine evaluates rules against game events and keeps per user progress.
+type Engine struct {
+	mu    sync.Mutex
+	rules []Rule
+	users map[string]map[string]*progress // user -> rule id -> progress
+}
+
+// NewEngine validates rules and returns an engine for them.
+func NewEngine(rules []Rule) (*Engine, error) {
+	seen := map[string]bool{}
+	for _, r := range rules {
+		if r.ID == "" || seen[r.ID] {
+			return nil, fmt.Errorf("achievement rule id %q empty or duplicated", r.ID)
+		}
+		seen[r.ID] = true
+		if r.Goal < 1 {
+			return nil, fmt.Errorf("achievement %s: goal must be >= 1", r.ID)
+		}
+		for _, c := range r.Where {
+			if !validOp(c.Op) {
+				return nil, fmt.Errorf("achievement %s: unknown op %q", r.ID, c.Op)
+			}
+		}
+	}
+	return &Engine{rules: rules, users: map[string]map[string]*progress{}}, nil
+}
+
+// Handle counts e towards every matching rule for each human participant.
+// Replaying the same result never counts twice, and games played with undo
+// or analysis (the "assisted" stat) never count.
+func (en *Engine) Handle(e events.GameFinished) {
+	if e.Stats["assisted"] == true {
+		return
+	}
+	en.mu.Lock()
+	defer en.mu.Unlock()
+	for _, p := range e.Players {
+		if p.User == "" {
+			continue
+		}
+		for _, r := range en.rules {
+			if !r.matches(e, p) {
+				continue
+			}
+			pr := en.progress(p.User, r.ID)
+			if pr.unlockedAt != nil || pr.games[e.Key()] {
+				continue
+			}
+			pr.games[e.Key()] = true
+			pr.count++
+			if pr.count >= r.Goal {
+				at := e.At
+				pr.unlockedAt = &at
+				log.Printf("[ACH] %s unlocked %q", p.User, r.Name)
+			}
+		}
+	}
+}
+
+func (en *Engine) progress(user, rule string) *progress {
+	byRule, ok := en.users[user]
+	if !ok {
+		byRule = map[string]*progress{}
+		en.users[user] = byRule
+	}
+	pr, ok := byRule[rule]
+	if !ok {
+		pr = &progress{games: map[string]bool{}}
+		byRule[rule] = pr
+	}
+	return pr
+}
+
+// Statuses lists every rule with the user's progress, in rule order.
+func (en *Engine) Statuses(user string) []Status {
+	en.mu.Lock()
+	defer en.mu.Unlock()
+	out := make([]Status, 0, len(en.rules))
+	for _, r := range en.rules {
+		s := Status{ID: r.ID, Name: r.Name, Description: r.Description, Goal: r.Goal}
+		if pr, ok := en.users[user][r.ID]; ok {
+			s.Progress = pr.count
+			s.Unlocked = pr.unlockedAt != nil
+			s.UnlockedAt = pr.unlockedAt
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+func (r Rule) matches(e events.GameFinished, p events.PlayerResult) bool {
+	if r.Game != "" && r.Game != e.GameType {
+		return false
+	}
+	if len(r.Outcomes) > 0 {
+		ok := false
+		for _, o := range r.Outcomes {
+			if o == p.Outcome {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+	for _, c := range r.Where {
+		v, ok := e.Stats[c.Stat]
+		if !ok || !c.holds(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func validOp(op string) bool {
+	switch op {
+	case "eq", "ne", "lt", "lte", "gt", "gte":
+		return true
+	}
+	return false
+}
+
+func (c Cond) holds(v any) bool {
+	a, aNum := number(v)
+	b, bNum := number(c.Value)
+	if aNum && bNum {
+		switch c.Op {
+		case "eq":
+			return a == b
+		case "ne":
+			return a != b
+		case "lt":
+			return a < b
+		case "lte":
+			return a <= b
+		case "gt":
+			return a > b
+		case "gte":
+			return a >= b
+		}
+		return false
+	}
+	switch c.Op {
+	case "eq":
+		return v == c.Value
+	case "ne":
+		return v != c.Value
+	}
+	return false
+}
+
+func number(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
@@ -0,0 +1,77 @@
+package achievements
+
+import (
+	"testing"
+
+	"github.com/Manishk5507/gaMerZ/backend/internal/events"
+)
+
+func finished(game, id, outcome string, stats map[string]any) events.GameFinished {
+	return events.GameFinished{GameType: game, GameID: id, Stats: stats,
+		Players: []events.PlayerResult{{User: "ann", Seat: "player", Outcome: outcome}}}
+}
+
+func status(t *testing.T, en *Engine, id string) Status {
+	for _, s := range en.Statuses("ann") {
+		if s.ID == id {
+			return s
+		}
+	}
+	t.Fatalf("no status %s", id)
+	return Status{}
+}
+
+func TestOneShotAchievementIsIdempotent(t *testing.T) {
+	en, err := NewEngine(Default)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := finished("numberguess", "g1", events.Win, map[string]any{"difficulty": "insane", "tries": 9})
+	en.Handle(e)
+	en.Handle(e)
+	s := status(t, en, "numberguess-insane")
+	if !s.Unlocked || s.Progress != 1 {
+		t.Fatalf("expected unlocked once, got %+v", s)
+	}
+	en.Handle(finished("numberguess", "g2", events.Win, map[string]any{"difficulty": "insane", "tries": 10}))
+	if s := status(t, en, "numberguess-insane"); s.Progress != 1 {
+		t.Fatalf("unlocked badge should not keep counting: %+v", s)
+	}
+}
+
+func TestIncrementalProgress(t *testing.T) {
+	en, _ := NewEngine(Default)
+	optimal := map[string]any{"vsAI": true, "difficulty": "optimal"}
+	en.Handle(finished("tictactoe", "a", events.Draw, optimal))
+	en.Handle(finished("tictactoe", "b", events.Loss, optimal))
+	en.Handle(finished("tictactoe", "c", events.Draw, map[string]any{"vsAI": true, "difficulty": "easy"}))
+	s := status(t, en, "ttt-survivor")
+	if s.Progress != 1 || s.Unlocked {
+		t.Fatalf("only the optimal draw should count: %+v", s)
+	}
+	for i := 0; i < 9; i++ {
+		en.Handle(finished("tictactoe", string(rune('d'+i)), events.Draw, optimal))
+	}
+	if s := status(t, en, "ttt-survivor"); !s.Unlocked || s.Progress != 10 {
+		t.Fatalf("expected unlocked after 10, got %+v", s)
+	}
+}
+
+func TestAssistedGamesDoNotCount(t *testing.T) {
+	en, _ := NewEngine(Default)
+	en.Handle(finished("tictactoe", "a", events.Win, map[string]any{"vsAI": true, "difficulty": "optimal", "assisted": true}))
+	for _, id := range []string{"ttt-survivor", "first-win"} {
+		if s := status(t, en, id); s.Progress != 0 {
+			t.Fatalf("a game played with undo counted towards %s: %+v", id, s)
+		}
+	}
+}
+
+func TestInvalidRulesRejected(t *testing.T) {
+	if _, err := NewEngine([]Rule{{ID: "x", Goal: 1, Where: []Cond{{Stat: "a", Op: "~"}}}}); err == nil {
+		t.Fatal("expected unknown op error")
+	}
+	if _, err := NewEngine([]Rule{{ID: "x", Goal: 1}, {ID: "x", Goal: 1}}); err == nil {
+		t.Fatal("expected duplicate id error")
+	}
+}
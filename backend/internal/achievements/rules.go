@@ -0,0 +1,40 @@
+package achievements
+
+import "github.com/Manishk5507/gaMerZ/backend/internal/events"
+
+// Default is the built-in achievement catalogue. Stats referenced here are
+// the ones published by the HTTP layer for each game type.
+var Default = []Rule{
+	{
+		ID: "first-win", Name: "First Blood", Description: "Win any game.",
+		Outcomes: []string{events.Win}, Goal: 1,
+	},
+	{
+		ID: "ttt-survivor", Name: "Survivor", Description: "Finish 10 TicTacToe games against the optimal AI without losing.",
+		Game: "tictactoe", Outcomes: []string{events.Win, events.Draw},
+		Where: []Cond{{Stat: "vsAI", Op: "eq", Value: true}, {Stat: "difficulty", Op: "eq", Value: "optimal"}},
+		Goal:  10,
+	},
+	{
+		ID: "numberguess-insane", Name: "Mind Reader", Description: "Guess the insane number in under 10 tries.",
+		Game: "numberguess", Outcomes: []string{events.Win},
+		Where: []Cond{{Stat: "difficulty", Op: "eq", Value: "insane"}, {Stat: "tries", Op: "lt", Value: 10}},
+		Goal:  1,
+	},
+	{
+		ID: "hangman-flawless", Name: "Flawless", Description: "Win Hangman with zero wrong guesses.",
+		Game: "hangman", Outcomes: []string{events.Win},
+		Where: []Cond{{Stat: "wrong", Op: "eq", Value: 0}},
+		Goal:  1,
+	},
+	{
+		ID: "rps-sweep", Name: "Clean Sweep", Description: "Win Rock Paper Scissors 3-0.",
+		Game: "rps", Outcomes: []string{events.Win},
+		Where: []Cond{{Stat: "winnerScore", Op: "gte", Value: 3}, {Stat: "loserScore", Op: "eq", Value: 0}},
+		Goal:  1,
+	},
+	{
+		ID: "hangman-regular", Name: "Wordsmith", Description: "Win 25 Hangman games.",
+		Game: "hangman", Outcomes: []string{events.Win}, Goal: 25,
+	},
+}
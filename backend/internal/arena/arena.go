@@ -0,0 +1,172 @@
+// Package arena plays AI strategies against each other to compare them:
+// N games of one game type between two strategies, run in parallel by a
+// worker pool, with the openers alternating so neither side keeps the
+// first-move advantage.
+package arena
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// MaxGames caps a single run.
+const MaxGames = 10000
+
+var (
+	ErrUnknownGame     = errors.New("unknown game type")
+	ErrUnknownStrategy = errors.New("unknown strategy")
+	ErrBadGameCount    = fmt.Errorf("games must be between 1 and %d", MaxGames)
+)
+
+// Config selects what to play. A and B name strategies of Game (see
+// Strategies); A opens the even numbered games and B the odd ones.
+type Config struct {
+	Game    string `json:"game"`
+	A       string `json:"a"`
+	B       string `json:"b"`
+	Games   int    `json:"games"`
+	Workers int    `json:"workers"` // defaults to the number of CPUs
+	Verbose bool   `json:"-"`       // keep the games' move logs
+}
+
+// Report sums up a run from A's side.
+type Report struct {
+	Config
+	AWins      int     `json:"aWins"`
+	BWins      int     `json:"bWins"`
+	Draws      int     `json:"draws"`
+	AvgMoves   float64 `json:"avgMoves"`   // per game, both sides together
+	AvgGameMs  float64 `json:"avgGameMs"`  // wall time per game
+	AMoveMs    float64 `json:"aMoveMs"`    // average thinking time per move
+	BMoveMs    float64 `json:"bMoveMs"`
+	ElapsedMs  float64 `json:"elapsedMs"`  // whole run
+	Incomplete bool    `json:"incomplete"` // cancelled before every game was played
+}
+
+// result is one finished game. Winner is 0 when the opener won, 1 when the
+// other side won and -1 for a draw; Moves and Think are per seat. Aborted
+// games were cut short by cancellation and are not counted.
+type result struct {
+	Winner  int
+	Moves   [2]int
+	Think   [2]time.Duration
+	Aborted bool
+}
+
+// gameType plays one whole game; s[0] opens. play checks ctx between moves
+// and returns an aborted result once it is cancelled; quiet games do not log
+// their moves.
+type gameType struct {
+	strategies []string
+	play       func(ctx context.Context, s [2]string, quiet bool) result
+}
+
+var gameTypes = map[string]gameType{}
+
+func register(game string, strategies []string, play func(ctx context.Context, s [2]string, quiet bool) result) {
+	gameTypes[game] = gameType{strategies: strategies, play: play}
+}
+
+// Strategies lists the strategies of every game type.
+func Strategies() map[string][]string {
+	out := map[string][]string{}
+	for name, gt := range gameTypes { out[name] = gt.strategies }
+	return out
+}
+
+func (gt gameType) has(s string) bool {
+	for _, v := range gt.strategies {
+		if v == s { return true }
+	}
+	return false
+}
+
+// Run plays cfg.Games games and reports the totals. Cancelling ctx stops
+// handing out new games and abandons the ones being played; the report then
+// covers the finished ones.
+func Run(ctx context.Context, cfg Config) (*Report, error) {
+	gt, ok := gameTypes[cfg.Game]
+	if !ok { return nil, ErrUnknownGame }
+	for _, s := range []string{cfg.A, cfg.B} {
+		if !gt.has(s) { return nil, fmt.Errorf("%w %q for %s (have %v)", ErrUnknownStrategy, s, cfg.Game, gt.strategies) }
+	}
+	if cfg.Games < 1 || cfg.Games > MaxGames { return nil, ErrBadGameCount }
+	if cfg.Workers <= 0 { cfg.Workers = runtime.NumCPU() }
+	if cfg.Workers > cfg.Games { cfg.Workers = cfg.Games }
+
+	start := time.Now()
+	jobs := make(chan int)
+	type played struct {
+		aOpened bool
+		res     result
+		took    time.Duration
+	}
+	results := make(chan played, cfg.Workers)
+	var wg sync.WaitGroup
+	for w := 0; w < cfg.Workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				seats := [2]string{cfg.A, cfg.B}
+				aOpened := i%2 == 0
+				if !aOpened { seats = [2]string{cfg.B, cfg.A} }
+				t := time.Now()
+				res := gt.play(ctx, seats, !cfg.Verbose)
+				if res.Aborted { continue }
+				results <- played{aOpened, res, time.Since(t)}
+			}
+		}()
+	}
+	go func() {
+		defer close(jobs)
+		for i := 0; i < cfg.Games; i++ {
+			select {
+			case jobs <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	go func() { wg.Wait(); close(results) }()
+
+	rep := &Report{Config: cfg}
+	var moves [2]int // A, B
+	var think [2]time.Duration
+	var gameTime time.Duration
+	n := 0
+	for p := range results {
+		n++
+		gameTime += p.took
+		a := 0 // A's seat in this game
+		if !p.aOpened { a = 1 }
+		switch p.res.Winner {
+		case -1:
+			rep.Draws++
+		case a:
+			rep.AWins++
+		default:
+			rep.BWins++
+		}
+		moves[0] += p.res.Moves[a]
+		moves[1] += p.res.Moves[1-a]
+		think[0] += p.res.Think[a]
+		think[1] += p.res.Think[1-a]
+	}
+	rep.Incomplete = n < cfg.Games
+	rep.ElapsedMs = ms(time.Since(start))
+	if n > 0 {
+		rep.AvgMoves = float64(moves[0]+moves[1]) / float64(n)
+		rep.AvgGameMs = ms(gameTime) / float64(n)
+	}
+	if moves[0] > 0 { rep.AMoveMs = ms(think[0]) / float64(moves[0]) }
+	if moves[1] > 0 { rep.BMoveMs = ms(think[1]) / float64(moves[1]) }
+	return rep, nil
+}
+
+func ms(d time.Duration) float64 { return float64(d.Microseconds()) / 1000 }
+
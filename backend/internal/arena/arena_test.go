@@ -0,0 +1,86 @@
+package arena
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	log.SetOutput(io.Discard) // the games log every move
+	os.Exit(m.Run())
+}
+
+func TestOptimalNeverLosesAndAlternatesOpeners(t *testing.T) {
+	rep, err := Run(context.Background(), Config{Game: "tictactoe", A: "optimal", B: "random", Games: 60, Workers: 4})
+	if err != nil { t.Fatal(err) }
+	if rep.BWins != 0 || rep.AWins+rep.Draws != 60 || rep.AWins < 30 {
+		t.Fatalf("optimal should beat random most games and never lose: %+v", rep)
+	}
+	if rep.AvgMoves < 5 || rep.AvgMoves > 9 { t.Fatalf("tictactoe games take 5 to 9 moves, got %v", rep.AvgMoves) }
+	self, _ := Run(context.Background(), Config{Game: "tictactoe", A: "optimal", B: "optimal", Games: 10})
+	if self.Draws != 10 { t.Fatalf("perfect play should always draw: %+v", self) }
+}
+
+func TestStrategiesSeparate(t *testing.T) {
+	rep, err := Run(context.Background(), Config{Game: "rps", A: "frequency", B: "rock", Games: 20})
+	if err != nil { t.Fatal(err) }
+	if rep.AWins < 18 { t.Fatalf("countering the most frequent move should beat rock: %+v", rep) }
+	c4, err := Run(context.Background(), Config{Game: "connectfour", A: "medium", B: "random", Games: 10})
+	if err != nil { t.Fatal(err) }
+	if c4.AWins < 9 { t.Fatalf("search should beat random in connect four: %+v", c4) }
+	ult, err := Run(context.Background(), Config{Game: "ultimate", A: "easy", B: "random", Games: 6})
+	if err != nil { t.Fatal(err) }
+	if ult.AWins < 5 { t.Fatalf("MCTS should beat random in ultimate: %+v", ult) }
+}
+
+func TestRunValidatesAndCancels(t *testing.T) {
+	if _, err := Run(context.Background(), Config{Game: "chess", A: "random", B: "random", Games: 1}); err != ErrUnknownGame {
+		t.Fatalf("expected ErrUnknownGame, got %v", err)
+	}
+	if _, err := Run(context.Background(), Config{Game: "tictactoe", A: "genius", B: "random", Games: 1}); !errors.Is(err, ErrUnknownStrategy) {
+		t.Fatalf("expected ErrUnknownStrategy, got %v", err)
+	}
+	if _, err := Run(context.Background(), Config{Game: "tictactoe", A: "random", B: "random", Games: 0}); err != ErrBadGameCount {
+		t.Fatalf("expected ErrBadGameCount, got %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	rep, err := Run(ctx, Config{Game: "tictactoe", A: "random", B: "random", Games: MaxGames, Workers: 2})
+	if err != nil { t.Fatal(err) }
+	if !rep.Incomplete || rep.AWins+rep.BWins+rep.Draws >= MaxGames { t.Fatalf("a cancelled run should stop early: %+v", rep) }
+}
+
+func TestRPSRoundCap(t *testing.T) {
+	// identical deterministic strategies tie every round; the cap ends each game as a draw
+	for _, s := range []string{"rock", "cycle"} {
+		rep, err := Run(context.Background(), Config{Game: "rps", A: s, B: s, Games: 4})
+		if err != nil { t.Fatal(err) }
+		if rep.Draws != 4 || rep.AvgMoves != 2*rpsMaxRounds { t.Fatalf("%s vs %s should draw at the round cap: %+v", s, s, rep) }
+	}
+}
+
+func TestRunAbandonsGamesInPlay(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, play := range []func(context.Context, [2]string, bool) result{playTicTacToe, playConnectFour, playUltimate, playRPS} {
+		if res := play(ctx, [2]string{"random", "random"}, true); !res.Aborted { t.Fatalf("a cancelled game should stop: %+v", res) }
+	}
+}
+
+func TestRunDoesNotLogMoves(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(io.Discard)
+	for _, game := range []string{"tictactoe", "connectfour", "ultimate"} {
+		if _, err := Run(context.Background(), Config{Game: game, A: "easy", B: "random", Games: 2}); err != nil { t.Fatal(err) }
+	}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if !strings.Contains(line, "game created") { t.Fatalf("unexpected log line %q", line) }
+	}
+}
@@ -0,0 +1,154 @@
+package arena
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/Manishk5507/gaMerZ/backend/internal/games"
+)
+
+// The strategies of each game type. Most reuse the difficulty levels the
+// games already offer against humans; "random" is the baseline.
+func init() {
+	register("tictactoe", []string{"random", "heuristic", "beginner", "easy", "medium", "hard", "optimal"}, playTicTacToe)
+	register("connectfour", []string{"random", "easy", "medium", "hard"}, playConnectFour)
+	register("ultimate", []string{"random", "easy", "medium", "hard"}, playUltimate)
+	register("rps", []string{"random", "rock", "cycle", "frequency"}, playRPS)
+}
+
+// aborted is what a game cancelled half way returns.
+var aborted = result{Winner: -1, Aborted: true}
+
+// seatResult maps a winning mark to the seat that played it; seats[0] is the opener's mark.
+func seatResult(winner string, seats [2]string) int {
+	switch winner {
+	case seats[0]:
+		return 0
+	case seats[1]:
+		return 1
+	}
+	return -1
+}
+
+func playTicTacToe(ctx context.Context, s [2]string, quiet bool) result {
+	g := games.NewTicTacToe(false, "")
+	g.Quiet = quiet
+	var res result
+	for g.Winner == "" {
+		if ctx.Err() != nil { return aborted }
+		seat := len(g.Moves) % 2
+		t := time.Now()
+		pos := -1
+		if s[seat] == "random" {
+			var empty []int
+			for i, c := range g.Board { if c == "" { empty = append(empty, i) } }
+			pos = empty[rand.Intn(len(empty))]
+		} else {
+			pos = g.SuggestMove(s[seat])
+		}
+		res.Think[seat] += time.Since(t)
+		res.Moves[seat]++
+		g.MakeMove(pos)
+	}
+	res.Winner = seatResult(g.Winner, [2]string{"X", "O"})
+	return res
+}
+
+func playConnectFour(ctx context.Context, s [2]string, quiet bool) result {
+	g := games.NewConnectFour(false, "")
+	g.Quiet = quiet
+	var res result
+	for g.Winner == "" {
+		if ctx.Err() != nil { return aborted }
+		seat := len(g.Moves) % 2
+		t := time.Now()
+		col := -1
+		if s[seat] == "random" {
+			var open []int
+			for c := range g.Board[0] { if g.Board[0][c] == "" { open = append(open, c) } }
+			col = open[rand.Intn(len(open))]
+		} else {
+			col = g.SuggestColumn(s[seat])
+		}
+		res.Think[seat] += time.Since(t)
+		res.Moves[seat]++
+		g.Drop(col)
+	}
+	res.Winner = seatResult(g.Winner, [2]string{"R", "Y"})
+	return res
+}
+
+func playUltimate(ctx context.Context, s [2]string, quiet bool) result {
+	g := games.NewUltimate(false, "")
+	g.Quiet = quiet
+	var res result
+	for g.Winner == "" {
+		if ctx.Err() != nil { return aborted }
+		seat := len(g.Moves) % 2
+		t := time.Now()
+		var m [2]int
+		if s[seat] == "random" {
+			legal := g.Legal()
+			m = legal[rand.Intn(len(legal))]
+		} else {
+			m = g.SuggestMove(s[seat])
+		}
+		res.Think[seat] += time.Since(t)
+		res.Moves[seat]++
+		g.Play(m[0], m[1])
+	}
+	res.Winner = seatResult(g.Winner, [2]string{"X", "O"})
+	return res
+}
+
+// rpsCounter is the move that beats m.
+var rpsCounter = map[string]string{"rock": "paper", "paper": "scissors", "scissors": "rock"}
+
+var rpsChoices = []string{"rock", "paper", "scissors"}
+
+// rpsPick chooses a move for strategy given the moves both seats made so
+// far; "frequency" counters the opponent's most common move.
+func rpsPick(strategy string, mine, theirs []string) string {
+	switch strategy {
+	case "rock":
+		return "rock"
+	case "cycle":
+		return rpsChoices[len(mine)%3]
+	case "frequency":
+		counts := map[string]int{}
+		best, top := "", 0
+		for _, m := range theirs {
+			counts[m]++
+			if counts[m] > top { best, top = m, counts[m] }
+		}
+		if best != "" { return rpsCounter[best] }
+	}
+	return rpsChoices[rand.Intn(3)]
+}
+
+// rpsMaxRounds ends a match that is not decided by then as a draw: two
+// deterministic strategies can tie every round forever.
+const rpsMaxRounds = 100
+
+// playRPS plays a versus match; seat 0 is p1. Moves count rounds per seat.
+func playRPS(ctx context.Context, s [2]string, _ bool) result {
+	g := games.NewRPSVersus(0)
+	var res result
+	var hist [2][]string
+	for !g.Finished && g.Rounds < rpsMaxRounds {
+		if ctx.Err() != nil { return aborted }
+		var picks [2]string
+		for seat := 0; seat < 2; seat++ {
+			t := time.Now()
+			picks[seat] = rpsPick(s[seat], hist[seat], hist[1-seat])
+			res.Think[seat] += time.Since(t)
+			res.Moves[seat]++
+		}
+		g.PlaySeat("p1", picks[0])
+		g.PlaySeat("p2", picks[1])
+		hist[0], hist[1] = append(hist[0], picks[0]), append(hist[1], picks[1])
+	}
+	res.Winner = seatResult(g.Winner, [2]string{"p1", "p2"}) // -1 when the cap was reached
+	return res
+}
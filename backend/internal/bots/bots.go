@@ -0,0 +1,136 @@
+// Package bots keeps the accounts of user-written bots. Every bot belongs to
+// a human owner and acts as the user "bot:<name>", authenticating with an API
+// token that is shown once at creation and only stored as a hash.
+package bots
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Prefix marks bot user ids; humans cannot claim ids starting with it.
+const Prefix = "bot:"
+
+// MaxPerOwner caps how many bots one user may run.
+const MaxPerOwner = 5
+
+var (
+	ErrBadName   = errors.New("bot names are 3-24 lowercase letters, digits, '-' or '_'")
+	ErrNameTaken = errors.New("bot name is taken or belonged to a revoked bot")
+	ErrTooMany   = errors.New("too many bots for this owner")
+	ErrNotFound  = errors.New("no such bot")
+	ErrBadOwner  = errors.New("bots must be owned by a human user")
+)
+
+var validName = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{2,23}$`)
+
+// IsBot reports whether user is a bot id.
+func IsBot(user string) bool { return strings.HasPrefix(user, Prefix) }
+
+// Bot is the public view of a bot account.
+type Bot struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Owner     string    `json:"owner"`
+	Created   time.Time `json:"created"`
+	LastSeen  time.Time `json:"lastSeen"`  // last authenticated request
+	AutoMoves int       `json:"autoMoves"` // moves the server played after a timeout
+}
+
+// Registry holds the bots and their token hashes.
+type Registry struct {
+	mu     sync.Mutex
+	bots    map[string]*Bot     // id -> bot
+	tokens  map[[32]byte]string // token hash -> id
+	revoked map[string]bool     // ids never handed out again: their games still seat them
+	now     func() time.Time
+}
+
+func NewRegistry() *Registry {
+	return &Registry{bots: map[string]*Bot{}, tokens: map[[32]byte]string{}, revoked: map[string]bool{}, now: time.Now}
+}
+
+// Create registers a bot called name for owner and returns it with its API
+// token. The token cannot be recovered later.
+func (r *Registry) Create(owner, name string) (Bot, string, error) {
+	if owner == "" || IsBot(owner) { return Bot{}, "", ErrBadOwner }
+	if !validName.MatchString(name) { return Bot{}, "", ErrBadName }
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	id := Prefix + name
+	if _, ok := r.bots[id]; ok || r.revoked[id] { return Bot{}, "", ErrNameTaken }
+	n := 0
+	for _, b := range r.bots {
+		if b.Owner == owner { n++ }
+	}
+	if n >= MaxPerOwner { return Bot{}, "", ErrTooMany }
+	raw := make([]byte, 24)
+	if _, err := rand.Read(raw); err != nil { return Bot{}, "", err }
+	token := "gzb_" + hex.EncodeToString(raw)
+	b := &Bot{ID: id, Name: name, Owner: owner, Created: r.now()}
+	r.bots[id] = b
+	r.tokens[sha256.Sum256([]byte(token))] = id
+	return *b, token, nil
+}
+
+// Authenticate returns the bot owning token and marks it as seen.
+func (r *Registry) Authenticate(token string) (Bot, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	id, ok := r.tokens[sha256.Sum256([]byte(token))]
+	if !ok { return Bot{}, false }
+	b := r.bots[id]
+	b.LastSeen = r.now()
+	return *b, true
+}
+
+// Online reports whether bot id made an authenticated request within d.
+func (r *Registry) Online(id string, d time.Duration) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	b, ok := r.bots[id]
+	return ok && !b.LastSeen.IsZero() && r.now().Sub(b.LastSeen) <= d
+}
+
+// AutoMoved counts a move the server played for bot id.
+func (r *Registry) AutoMoved(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if b, ok := r.bots[id]; ok { b.AutoMoves++ }
+}
+
+// List returns owner's bots by name, or every bot when owner is empty.
+func (r *Registry) List(owner string) []Bot {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := []Bot{}
+	for _, b := range r.bots {
+		if owner == "" || b.Owner == owner { out = append(out, *b) }
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// Revoke deletes owner's bot called name; its token stops working at once.
+// Games it sits in keep the seat, so the server moves for it from then on,
+// and the name is retired so nobody can take those seats over by creating
+// the bot again.
+func (r *Registry) Revoke(owner, name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	b, ok := r.bots[Prefix+name]
+	if !ok || b.Owner != owner { return ErrNotFound }
+	delete(r.bots, b.ID)
+	r.revoked[b.ID] = true
+	for h, id := range r.tokens {
+		if id == b.ID { delete(r.tokens, h) }
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package bots
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCreateValidatesNamesAndOwners(t *testing.T) {
+	r := NewRegistry()
+	for _, tc := range []struct {
+		owner, name string
+		want        error
+	}{
+		{"ann", "ab", ErrBadName},
+		{"ann", "Has Space", ErrBadName},
+		{"", "minimax", ErrBadOwner},
+		{"bot:other", "minimax", ErrBadOwner},
+		{"ann", "minimax", nil},
+		{"bob", "minimax", ErrNameTaken},
+	} {
+		if _, _, err := r.Create(tc.owner, tc.name); err != tc.want {
+			t.Errorf("%s/%s: got %v want %v", tc.owner, tc.name, err, tc.want)
+		}
+	}
+	for i := 0; i < MaxPerOwner-1; i++ {
+		if _, _, err := r.Create("ann", "bot-"+string(rune('a'+i))+"xx"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, _, err := r.Create("ann", "onetoomany"); err != ErrTooMany {
+		t.Fatalf("expected per owner cap, got %v", err)
+	}
+}
+
+func TestTokensAuthenticateUntilRevoked(t *testing.T) {
+	now := time.Unix(0, 0)
+	r := NewRegistry()
+	r.now = func() time.Time { return now }
+	b, token, err := r.Create("ann", "minimax")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.ID != "bot:minimax" || !IsBot(b.ID) {
+		t.Fatalf("unexpected id %q", b.ID)
+	}
+	if r.Online(b.ID, time.Minute) {
+		t.Fatal("a bot that never connected is not online")
+	}
+	if _, ok := r.Authenticate("gzb_wrong"); ok {
+		t.Fatal("wrong token accepted")
+	}
+	if got, ok := r.Authenticate(token); !ok || got.ID != b.ID {
+		t.Fatalf("token rejected: %+v %v", got, ok)
+	}
+	now = now.Add(30 * time.Second)
+	if !r.Online(b.ID, time.Minute) || r.Online(b.ID, 10*time.Second) {
+		t.Fatal("online should follow the last authenticated request")
+	}
+	r.AutoMoved(b.ID)
+	if l := r.List("ann"); len(l) != 1 || l[0].AutoMoves != 1 {
+		t.Fatalf("unexpected list %+v", l)
+	}
+	if err := r.Revoke("bob", "minimax"); err != ErrNotFound {
+		t.Fatalf("only the owner may revoke, got %v", err)
+	}
+	if err := r.Revoke("ann", "minimax"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := r.Authenticate(token); ok {
+		t.Fatal("revoked token still works")
+	}
+	for _, owner := range []string{"ann", "bob"} {
+		if _, _, err := r.Create(owner, "minimax"); err != ErrNameTaken {
+			t.Fatalf("a revoked name must not be handed out again, got %v", err)
+		}
+	}
+}
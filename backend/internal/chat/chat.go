@@ -0,0 +1,167 @@
+package chat
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	ErrEmpty       = errors.New("message is empty")
+	ErrTooLong     = errors.New("message is too long")
+	ErrInvalidText = errors.New("message contains invalid characters")
+	ErrProfanity   = errors.New("message contains blocked words")
+	ErrRateLimited = errors.New("slow down, too many messages")
+	ErrMuted       = errors.New("you are muted in this room")
+)
+
+// Config tunes validation, retention and rate limiting for every channel.
+type Config struct {
+	MaxLength  int // max runes per message
+	History    int // messages retained per channel
+	RateBurst  int // messages allowed per RateWindow per user
+	RateWindow time.Duration
+	Filter     *Filter // nil disables the profanity filter
+}
+
+// DefaultConfig is used when the server is not configured otherwise.
+var DefaultConfig = Config{MaxLength: 500, History: 100, RateBurst: 5, RateWindow: 10 * time.Second, Filter: NewFilter(DefaultBlocklist, Mask)}
+
+// Message is one chat line. System messages announce moderation actions.
+type Message struct {
+	ID     int64     `json:"id"`
+	User   string    `json:"user"`
+	Text   string    `json:"text"`
+	At     time.Time `json:"at"`
+	System bool      `json:"system,omitempty"`
+}
+
+type channel struct {
+	history []Message
+	sent    map[string][]time.Time // user -> recent send times
+	muted   map[string]bool
+}
+
+// Service holds one chat channel per room.
+type Service struct {
+	mu       sync.Mutex
+	cfg      Config
+	channels map[string]*channel
+	nextID   int64
+	now      func() time.Time
+}
+
+func NewService(cfg Config) *Service {
+	return &Service{cfg: cfg, channels: map[string]*channel{}, now: time.Now}
+}
+
+func (s *Service) channel(room string) *channel {
+	c, ok := s.channels[room]
+	if !ok {
+		c = &channel{sent: map[string][]time.Time{}, muted: map[string]bool{}}
+		s.channels[room] = c
+	}
+	return c
+}
+
+// Validate normalises text and checks it against the length, content and
+// profanity rules, returning the text that should be stored.
+func (s *Service) Validate(text string) (string, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "", ErrEmpty
+	}
+	if !utf8.ValidString(text) {
+		return "", ErrInvalidText
+	}
+	if utf8.RuneCountInString(text) > s.cfg.MaxLength {
+		return "", ErrTooLong
+	}
+	for _, r := range text {
+		// control characters, zero width spaces and bidi overrides are used to spoof or hide text
+		if unicode.IsControl(r) || r == '\u200b' || r == '\u202e' {
+			return "", ErrInvalidText
+		}
+	}
+	if s.cfg.Filter != nil {
+		return s.cfg.Filter.Apply(text)
+	}
+	return text, nil
+}
+
+// Post validates and stores a message from user in room.
+func (s *Service) Post(room, user, text string) (Message, error) {
+	text, err := s.Validate(text)
+	if err != nil {
+		return Message{}, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c := s.channel(room)
+	if c.muted[user] {
+		return Message{}, ErrMuted
+	}
+	now := s.now()
+	recent := c.sent[user][:0]
+	for _, t := range c.sent[user] {
+		if now.Sub(t) < s.cfg.RateWindow {
+			recent = append(recent, t)
+		}
+	}
+	if len(recent) >= s.cfg.RateBurst {
+		c.sent[user] = recent
+		return Message{}, ErrRateLimited
+	}
+	c.sent[user] = append(recent, now)
+	return s.append(c, Message{User: user, Text: text, At: now}), nil
+}
+
+// Announce stores a system message, e.g. "bob was muted".
+func (s *Service) Announce(room, text string) Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.append(s.channel(room), Message{Text: text, At: s.now(), System: true})
+}
+
+func (s *Service) append(c *channel, m Message) Message {
+	s.nextID++
+	m.ID = s.nextID
+	c.history = append(c.history, m)
+	if over := len(c.history) - s.cfg.History; over > 0 {
+		c.history = append([]Message{}, c.history[over:]...)
+	}
+	return m
+}
+
+// History returns the retained messages of room, oldest first.
+func (s *Service) History(room string) []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.channels[room]
+	if !ok {
+		return []Message{}
+	}
+	return append([]Message{}, c.history...)
+}
+
+// SetMuted mutes or unmutes user in room.
+func (s *Service) SetMuted(room, user string, muted bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c := s.channel(room)
+	if muted {
+		c.muted[user] = true
+	} else {
+		delete(c.muted, user)
+	}
+}
+
+// Close drops everything kept for room.
+func (s *Service) Close(room string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.channels, room)
+}
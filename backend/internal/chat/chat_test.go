@@ -0,0 +1,66 @@
+package chat
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFilterMasksVariants(t *testing.T) {
+	f := NewFilter([]string{"shit"}, Mask)
+	got, err := f.Apply("oh $h1iit, shitake is fine")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "oh ******, shitake is fine" {
+		t.Fatalf("unexpected mask result %q", got)
+	}
+	if _, err := NewFilter([]string{"shit"}, Reject).Apply("SHIT"); err != ErrProfanity {
+		t.Fatalf("expected rejection, got %v", err)
+	}
+}
+
+func TestValidation(t *testing.T) {
+	s := NewService(Config{MaxLength: 10, History: 10, RateBurst: 10, RateWindow: time.Second})
+	for text, want := range map[string]error{
+		"   ":           ErrEmpty,
+		"hello world!!": ErrTooLong,
+		"hi\x07":        ErrInvalidText,
+		"a\u202eb":      ErrInvalidText,
+		"  hey  ":       nil,
+	} {
+		if _, err := s.Post("r", "u", text); err != want {
+			t.Errorf("%q: got %v want %v", text, err, want)
+		}
+	}
+	if h := s.History("r"); len(h) != 1 || h[0].Text != "hey" {
+		t.Fatalf("expected trimmed message stored, got %+v", h)
+	}
+}
+
+func TestRateLimitMuteAndRetention(t *testing.T) {
+	now := time.Unix(0, 0)
+	s := NewService(Config{MaxLength: 100, History: 3, RateBurst: 2, RateWindow: 10 * time.Second})
+	s.now = func() time.Time { return now }
+	s.Post("r", "ann", "1")
+	s.Post("r", "ann", "2")
+	if _, err := s.Post("r", "ann", "3"); err != ErrRateLimited {
+		t.Fatalf("expected rate limit, got %v", err)
+	}
+	now = now.Add(11 * time.Second)
+	if _, err := s.Post("r", "ann", "4"); err != nil {
+		t.Fatalf("window should have passed: %v", err)
+	}
+	s.SetMuted("r", "ann", true)
+	if _, err := s.Post("r", "ann", "5"); err != ErrMuted {
+		t.Fatalf("expected muted, got %v", err)
+	}
+	s.Announce("r", "ann was muted")
+	var texts []string
+	for _, m := range s.History("r") {
+		texts = append(texts, m.Text)
+	}
+	if strings.Join(texts, ",") != "2,4,ann was muted" {
+		t.Fatalf("unexpected retained history %v", texts)
+	}
+}
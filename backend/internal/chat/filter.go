@@ -0,0 +1,99 @@
+package chat
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Mode decides what the filter does with a blocked word.
+type Mode int
+
+const (
+	Mask   Mode = iota // replace the word with asterisks
+	Reject             // refuse the whole message
+)
+
+// DefaultBlocklist is a deliberately small starter list; deployments pass
+// their own through configuration.
+var DefaultBlocklist = []string{"fuck", "shit", "bitch", "bastard", "asshole", "dick", "cunt", "slut", "whore"}
+
+// leet maps common look-alike characters back to letters so "sh1t" and
+// "$hit" are caught.
+var leet = map[rune]rune{'0': 'o', '1': 'i', '3': 'e', '4': 'a', '5': 's', '7': 't', '@': 'a', '$': 's'}
+
+// Filter matches whole words against a blocklist, ignoring case, look-alike
+// characters and stretched letters ("shiiit").
+type Filter struct {
+	words map[string]bool
+	mode  Mode
+}
+
+func NewFilter(words []string, mode Mode) *Filter {
+	f := &Filter{words: map[string]bool{}, mode: mode}
+	for _, w := range words {
+		w = strings.TrimSpace(w)
+		if w != "" {
+			f.words[squeeze(normalize(w))] = true
+		}
+	}
+	return f
+}
+
+func isWordRune(r rune) bool {
+	_, l := leet[r]
+	return l || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func normalize(w string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(w) {
+		if m, ok := leet[r]; ok {
+			r = m
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// squeeze collapses runs of the same letter.
+func squeeze(w string) string {
+	var b strings.Builder
+	var last rune = -1
+	for _, r := range w {
+		if r != last {
+			b.WriteRune(r)
+		}
+		last = r
+	}
+	return b.String()
+}
+
+func (f *Filter) blocked(word string) bool {
+	n := normalize(word)
+	return f.words[n] || f.words[squeeze(n)]
+}
+
+// Apply returns text with blocked words masked, or ErrProfanity in Reject mode.
+func (f *Filter) Apply(text string) (string, error) {
+	rs := []rune(text)
+	for i := 0; i < len(rs); {
+		if !isWordRune(rs[i]) {
+			i++
+			continue
+		}
+		j := i
+		for j < len(rs) && isWordRune(rs[j]) {
+			j++
+		}
+		if f.blocked(string(rs[i:j])) {
+			if f.mode == Reject {
+				return "", ErrProfanity
+			}
+			for k := i; k < j; k++ {
+				rs[k] = '*'
+			}
+		}
+		i = j
+	}
+	return string(rs), nil
+}
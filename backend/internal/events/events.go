@@ -0,0 +1,76 @@
+package events
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Outcome values for a single participant of a finished game.
+const (
+	Win  = "win"
+	Loss = "loss"
+	Draw = "draw"
+)
+
+// PlayerResult is how one seat ended a game. User is empty for the AI.
+type PlayerResult struct {
+	User    string `json:"user"`
+	Seat    string `json:"seat"`
+	Outcome string `json:"outcome"`
+}
+
+// GameFinished is published exactly once per round when a game reaches a
+// final state; Round increases each time the same game id is reset. Stats
+// carries game specific facts (difficulty, tries, score...) used by consumers
+// such as the achievement engine.
+type GameFinished struct {
+	GameType string         `json:"gameType"`
+	GameID   string         `json:"gameId"`
+	Round    int            `json:"round"`
+	Players  []PlayerResult `json:"players"`
+	Rated    bool           `json:"rated"`
+	Stats    map[string]any `json:"stats,omitempty"`
+	At       time.Time      `json:"at"`
+}
+
+// Key identifies this particular result, distinguishing rounds of a reset game.
+func (e GameFinished) Key() string { return fmt.Sprintf("%s#%d", e.GameID, e.Round) }
+
+// Player returns the result entry for the given user, if present.
+func (e GameFinished) Player(user string) (PlayerResult, bool) {
+	for _, p := range e.Players {
+		if p.User == user {
+			return p, true
+		}
+	}
+	return PlayerResult{}, false
+}
+
+// Bus is a tiny synchronous fan-out for game events.
+type Bus struct {
+	mu       sync.RWMutex
+	handlers []func(GameFinished)
+}
+
+func NewBus() *Bus { return &Bus{} }
+
+// Subscribe registers h to receive every published event.
+func (b *Bus) Subscribe(h func(GameFinished)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers = append(b.handlers, h)
+}
+
+// Publish delivers e to all subscribers in registration order.
+func (b *Bus) Publish(e GameFinished) {
+	if e.At.IsZero() {
+		e.At = time.Now()
+	}
+	b.mu.RLock()
+	hs := append([]func(GameFinished){}, b.handlers...)
+	b.mu.RUnlock()
+	for _, h := range hs {
+		h(e)
+	}
+}
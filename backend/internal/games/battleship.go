@@ -0,0 +1,380 @@
+package games
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+)
+
+const bsSize = 10
+
+// BattleshipFleet lists the ships every player places, by name and length.
+var BattleshipFleet = []ShipClass{{"carrier", 5}, {"battleship", 4}, {"cruiser", 3}, {"submarine", 3}, {"destroyer", 2}}
+
+type ShipClass struct {
+	Name string `json:"name"`
+	Size int    `json:"size"`
+}
+
+// Ship is a placed ship; it runs Size cells right from (Row, Col), or down
+// when Vertical.
+type Ship struct {
+	Name     string `json:"name"`
+	Size     int    `json:"size"`
+	Row      int    `json:"row"`
+	Col      int    `json:"col"`
+	Vertical bool   `json:"vertical"`
+	Hits     int    `json:"hits"`
+}
+
+func (s Ship) cells() [][2]int {
+	out := make([][2]int, s.Size)
+	for i := range out {
+		if s.Vertical {
+			out[i] = [2]int{s.Row + i, s.Col}
+		} else {
+			out[i] = [2]int{s.Row, s.Col + i}
+		}
+	}
+	return out
+}
+
+func (s Ship) Sunk() bool { return s.Hits >= s.Size }
+
+// BattleshipShot is one resolved shot; Sunk names the ship it sank.
+type BattleshipShot struct {
+	By   string `json:"by"`
+	Row  int    `json:"row"`
+	Col  int    `json:"col"`
+	Hit  bool   `json:"hit"`
+	Sunk string `json:"sunk,omitempty"`
+}
+
+// Battleship is played by seats "p1" (shoots first) and "p2" on 10x10
+// waters. Both place their fleet during the "placing" phase, then take turns
+// firing one shot each until a fleet is sunk. With VsAI the AI holds p2.
+//
+// The fleets are hidden information, so they are unexported: serve View,
+// which projects the game for one seat (or spectators), never the game itself.
+type Battleship struct {
+	Phase      string           `json:"phase"` // placing, playing or over
+	Turn       string           `json:"turn"`
+	Winner     string           `json:"winner"`
+	VsAI       bool             `json:"vsAI"`
+	Difficulty string           `json:"difficulty"` // easy, medium or hard (AI targeting)
+	Shots      []BattleshipShot `json:"shots"`
+	Series     *Series          `json:"series,omitempty"`
+
+	fleets map[string][]Ship
+	rng    *rand.Rand
+}
+
+// BattleshipView is what one seat may see: its own fleet, the shots each side
+// received and the ships sunk so far. Spectators get no fleet at all; both
+// fleets are revealed once the game is over.
+type BattleshipView struct {
+	Size       int                   `json:"size"`
+	Phase      string                `json:"phase"`
+	Turn       string                `json:"turn"`
+	Winner     string                `json:"winner"`
+	VsAI       bool                  `json:"vsAI"`
+	Difficulty string                `json:"difficulty"`
+	Seat       string                `json:"seat,omitempty"`  // the viewer's seat, "" for spectators
+	Fleet      []Ship                `json:"fleet,omitempty"` // the viewer's ships
+	Ready      map[string]bool       `json:"ready"`           // seat -> fleet placed
+	Waters     map[string][][]string `json:"waters"`          // seat -> shots it received: "", miss, hit or sunk per [row][col]
+	Sunk       map[string][]Ship     `json:"sunk"`            // seat -> its sunk ships
+	Revealed   map[string][]Ship     `json:"revealed,omitempty"`
+	LastShot   *BattleshipShot       `json:"lastShot,omitempty"`
+	ShotCount  int                   `json:"shotCount"`
+	Series     *Series               `json:"series,omitempty"`
+	Spectators int                   `json:"spectators"`
+}
+
+// ShipPlacement puts the fleet ship called Name at (Row, Col).
+type ShipPlacement struct {
+	Name     string `json:"name"`
+	Row      int    `json:"row"`
+	Col      int    `json:"col"`
+	Vertical bool   `json:"vertical"`
+}
+
+var (
+	ErrBadBattleshipLevel = errors.New("difficulty must be easy, medium or hard")
+	ErrBadSeat            = errors.New(`seat must be "p1" or "p2"`)
+	ErrAISeat             = errors.New("the AI places its own fleet")
+	ErrBadPlacement       = errors.New("invalid fleet placement")
+	ErrNotPlacing         = errors.New("ships can only be placed before the first shot")
+	ErrNotPlaying         = errors.New("game is not in progress")
+	ErrNotYourTurn        = errors.New("not your turn")
+	ErrOffBoard           = errors.New("shot is off the board")
+	ErrAlreadyShot        = errors.New("cell was already shot")
+)
+
+var bsLevels = map[string]bool{"easy": true, "medium": true, "hard": true}
+
+// NewBattleship starts a game in the placing phase; against the AI its fleet
+// is placed right away. An empty difficulty means hard.
+func NewBattleship(vsAI bool, difficulty string) (*Battleship, error) {
+	if difficulty == "" { difficulty = "hard" }
+	if !bsLevels[difficulty] { return nil, ErrBadBattleshipLevel }
+	g := &Battleship{VsAI: vsAI, Difficulty: difficulty, rng: rand.New(rand.NewSource(rand.Int63()))}
+	g.Reset()
+	log.Printf("[BATTLESHIP] New game vsAI=%v difficulty=%s", vsAI, difficulty)
+	return g, nil
+}
+
+// Reset clears both fleets and all shots, keeping mode and difficulty.
+func (g *Battleship) Reset() {
+	g.Phase, g.Turn, g.Winner = "placing", "p1", ""
+	g.Shots = []BattleshipShot{}
+	g.fleets = map[string][]Ship{}
+	if g.VsAI { g.placeRandom("p2") }
+}
+
+func bsOther(seat string) string { if seat == "p1" { return "p2" }; return "p1" }
+
+func bsSeat(seat string) bool { return seat == "p1" || seat == "p2" }
+
+// Place sets seat's fleet, which must hold every ship of BattleshipFleet once,
+// inside the board and without overlaps (ships may touch). A fleet can be
+// moved until both are placed; then the shooting starts. Against the AI only
+// p1 is placed here.
+func (g *Battleship) Place(seat string, placements []ShipPlacement) error {
+	if g.VsAI && seat == "p2" { return ErrAISeat }
+	return g.place(seat, placements)
+}
+
+func (g *Battleship) place(seat string, placements []ShipPlacement) error {
+	if !bsSeat(seat) { return ErrBadSeat }
+	if g.Phase != "placing" { return ErrNotPlacing }
+	if len(placements) != len(BattleshipFleet) { return fmt.Errorf("%w: place all %d ships", ErrBadPlacement, len(BattleshipFleet)) }
+	var taken [bsSize][bsSize]bool
+	fleet := make([]Ship, 0, len(placements))
+	for _, class := range BattleshipFleet {
+		var p *ShipPlacement
+		for i := range placements {
+			if placements[i].Name == class.Name { p = &placements[i] }
+		}
+		if p == nil { return fmt.Errorf("%w: missing the %s", ErrBadPlacement, class.Name) }
+		s := Ship{Name: class.Name, Size: class.Size, Row: p.Row, Col: p.Col, Vertical: p.Vertical}
+		for _, c := range s.cells() {
+			if c[0] < 0 || c[0] >= bsSize || c[1] < 0 || c[1] >= bsSize { return fmt.Errorf("%w: the %s is off the board", ErrBadPlacement, s.Name) }
+			if taken[c[0]][c[1]] { return fmt.Errorf("%w: the %s overlaps another ship", ErrBadPlacement, s.Name) }
+			taken[c[0]][c[1]] = true
+		}
+		fleet = append(fleet, s)
+	}
+	g.fleets[seat] = fleet
+	if g.fleets[bsOther(seat)] != nil { g.Phase = "playing" }
+	return nil
+}
+
+// PlaceRandom places seat's fleet at random.
+func (g *Battleship) PlaceRandom(seat string) error {
+	if g.VsAI && seat == "p2" { return ErrAISeat }
+	return g.placeRandom(seat)
+}
+
+func (g *Battleship) placeRandom(seat string) error {
+	for {
+		var ps []ShipPlacement
+		for _, class := range BattleshipFleet {
+			v := g.rng.Intn(2) == 0
+			row, col := g.rng.Intn(bsSize), g.rng.Intn(bsSize-class.Size+1)
+			if v { row, col = col, row }
+			ps = append(ps, ShipPlacement{Name: class.Name, Row: row, Col: col, Vertical: v})
+		}
+		if err := g.place(seat, ps); !errors.Is(err, ErrBadPlacement) { return err }
+	}
+}
+
+// Fire shoots at (row, col) of the other seat's waters for seat. Against the
+// AI its answer is fired straight away.
+func (g *Battleship) Fire(seat string, row, col int) (BattleshipShot, error) {
+	shot, err := g.fire(seat, row, col)
+	if err != nil { return shot, err }
+	if g.VsAI && g.Phase == "playing" && g.Turn == "p2" {
+		r, c := g.aiTarget()
+		g.fire("p2", r, c)
+	}
+	if g.Phase == "over" { log.Printf("[BATTLESHIP] Game over, winner %s after %d shots", g.Winner, len(g.Shots)) }
+	return shot, nil
+}
+
+func (g *Battleship) fire(seat string, row, col int) (BattleshipShot, error) {
+	if !bsSeat(seat) { return BattleshipShot{}, ErrBadSeat }
+	if g.Phase != "playing" { return BattleshipShot{}, ErrNotPlaying }
+	if seat != g.Turn { return BattleshipShot{}, ErrNotYourTurn }
+	if row < 0 || row >= bsSize || col < 0 || col >= bsSize { return BattleshipShot{}, ErrOffBoard }
+	target := bsOther(seat)
+	if g.waters(target)[row][col] != "" { return BattleshipShot{}, ErrAlreadyShot }
+	shot := BattleshipShot{By: seat, Row: row, Col: col}
+	fleet := g.fleets[target]
+	for i := range fleet {
+		for _, c := range fleet[i].cells() {
+			if c != [2]int{row, col} { continue }
+			shot.Hit = true
+			fleet[i].Hits++
+			if fleet[i].Sunk() { shot.Sunk = fleet[i].Name }
+		}
+	}
+	g.Shots = append(g.Shots, shot)
+	if g.fleetSunk(target) {
+		g.Phase, g.Winner = "over", seat
+	} else {
+		g.Turn = target
+	}
+	return shot, nil
+}
+
+func (g *Battleship) fleetSunk(seat string) bool {
+	for _, s := range g.fleets[seat] {
+		if !s.Sunk() { return false }
+	}
+	return true
+}
+
+// waters returns the shots seat received; cells of sunk ships read "sunk".
+func (g *Battleship) waters(seat string) [][]string {
+	w := make([][]string, bsSize)
+	for r := range w { w[r] = make([]string, bsSize) }
+	for _, s := range g.Shots {
+		if s.By == seat { continue }
+		w[s.Row][s.Col] = "miss"
+		if s.Hit { w[s.Row][s.Col] = "hit" }
+	}
+	for _, s := range g.fleets[seat] {
+		if !s.Sunk() { continue }
+		for _, c := range s.cells() { w[c[0]][c[1]] = "sunk" }
+	}
+	return w
+}
+
+// Ready reports whether seat has placed its fleet.
+func (g *Battleship) Ready(seat string) bool { return g.fleets[seat] != nil }
+
+// View projects the game for seat; any other seat value gets the spectator
+// view.
+func (g *Battleship) View(seat string) *BattleshipView {
+	v := &BattleshipView{Size: bsSize, Phase: g.Phase, Turn: g.Turn, Winner: g.Winner, VsAI: g.VsAI, Difficulty: g.Difficulty,
+		Ready: map[string]bool{}, Waters: map[string][][]string{}, Sunk: map[string][]Ship{}, ShotCount: len(g.Shots), Series: g.Series}
+	if bsSeat(seat) {
+		v.Seat = seat
+		v.Fleet = append([]Ship{}, g.fleets[seat]...)
+	}
+	for _, s := range []string{"p1", "p2"} {
+		v.Ready[s] = g.Ready(s)
+		v.Waters[s] = g.waters(s)
+		v.Sunk[s] = []Ship{}
+		for _, ship := range g.fleets[s] {
+			if ship.Sunk() { v.Sunk[s] = append(v.Sunk[s], ship) }
+		}
+	}
+	if g.Phase == "over" {
+		v.Revealed = map[string][]Ship{"p1": append([]Ship{}, g.fleets["p1"]...), "p2": append([]Ship{}, g.fleets["p2"]...)}
+	}
+	if n := len(g.Shots); n > 0 {
+		last := g.Shots[n-1]
+		v.LastShot = &last
+	}
+	return v
+}
+
+// Legal lists the cells the side to move can still shoot at.
+func (g *Battleship) Legal() [][2]int {
+	if g.Phase != "playing" { return nil }
+	var out [][2]int
+	w := g.waters(bsOther(g.Turn))
+	for r := range w {
+		for c := range w[r] {
+			if w[r][c] == "" { out = append(out, [2]int{r, c}) }
+		}
+	}
+	return out
+}
+
+// aiTarget picks the AI's next shot at p1 from what a player could know: the
+// shot results and which ships are sunk. Every way the ships still afloat
+// could lie is counted per cell (probability density); placements through
+// unresolved hits count far more, which turns the hunt into a chase. Hard
+// fires at the densest cell, medium draws cells weighted by density and easy
+// shoots at random.
+func (g *Battleship) aiTarget() (int, int) {
+	free := g.Legal()
+	if g.Difficulty == "easy" {
+		c := free[g.rng.Intn(len(free))]
+		return c[0], c[1]
+	}
+	density := BattleshipDensity(g.waters("p1"), g.afloat("p1"))
+	best, total := 0, 0
+	for _, c := range free {
+		d := density[c[0]][c[1]]
+		total += d
+		if d > best { best = d }
+	}
+	if total == 0 {
+		c := free[g.rng.Intn(len(free))]
+		return c[0], c[1]
+	}
+	if g.Difficulty == "medium" {
+		pick := g.rng.Intn(total)
+		for _, c := range free {
+			if pick -= density[c[0]][c[1]]; pick < 0 { return c[0], c[1] }
+		}
+	}
+	var top [][2]int
+	for _, c := range free {
+		if density[c[0]][c[1]] == best { top = append(top, c) }
+	}
+	c := top[g.rng.Intn(len(top))]
+	return c[0], c[1]
+}
+
+// afloat lists the sizes of seat's ships not sunk yet.
+func (g *Battleship) afloat(seat string) []int {
+	var sizes []int
+	for _, s := range g.fleets[seat] {
+		if !s.Sunk() { sizes = append(sizes, s.Size) }
+	}
+	return sizes
+}
+
+// bsHitWeight is how much more a placement through unresolved hits counts,
+// per hit it covers.
+const bsHitWeight = 50
+
+// BattleshipDensity counts, for every cell that was not shot yet, the
+// placements of the ships of the given sizes that cover it and fit the known
+// waters ("miss" and "sunk" cells are blocked).
+func BattleshipDensity(waters [][]string, sizes []int) [bsSize][bsSize]int {
+	var d [bsSize][bsSize]int
+	for _, size := range sizes {
+		for _, vertical := range []bool{false, true} {
+			for r := 0; r < bsSize; r++ {
+				for c := 0; c < bsSize; c++ {
+					s := Ship{Size: size, Row: r, Col: c, Vertical: vertical}
+					cells := s.cells()
+					last := cells[size-1]
+					if last[0] >= bsSize || last[1] >= bsSize { continue }
+					hits, blocked := 0, false
+					for _, cell := range cells {
+						switch waters[cell[0]][cell[1]] {
+						case "miss", "sunk":
+							blocked = true
+						case "hit":
+							hits++
+						}
+					}
+					if blocked { continue }
+					weight := 1 + bsHitWeight*hits
+					for _, cell := range cells {
+						if waters[cell[0]][cell[1]] == "" { d[cell[0]][cell[1]] += weight }
+					}
+				}
+			}
+		}
+	}
+	return d
+}
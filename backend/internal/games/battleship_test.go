@@ -0,0 +1,186 @@
+package games
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// bsRows places ship i of the fleet on row 2*i, from column 0.
+func bsRows() []ShipPlacement {
+	var ps []ShipPlacement
+	for i, c := range BattleshipFleet {
+		ps = append(ps, ShipPlacement{Name: c.Name, Row: 2 * i})
+	}
+	return ps
+}
+
+func TestBattleshipPlacement(t *testing.T) {
+	g, _ := NewBattleship(false, "")
+	bad := map[string]func([]ShipPlacement) []ShipPlacement{
+		"off the board": func(ps []ShipPlacement) []ShipPlacement { ps[0].Col = 6; return ps },
+		"overlaps": func(ps []ShipPlacement) []ShipPlacement {
+			ps[1].Row = 0
+			ps[1].Vertical = true
+			ps[1].Col = 4
+			return ps
+		},
+		"missing":   func(ps []ShipPlacement) []ShipPlacement { ps[4].Name = "carrier"; return ps },
+		"place all": func(ps []ShipPlacement) []ShipPlacement { return ps[:4] },
+	}
+	for want, edit := range bad {
+		if err := g.Place("p1", edit(bsRows())); !errors.Is(err, ErrBadPlacement) || !strings.Contains(err.Error(), want) {
+			t.Errorf("%s: got %v", want, err)
+		}
+	}
+	if _, err := g.Fire("p1", 0, 0); err != ErrNotPlaying {
+		t.Fatalf("shots before placement must fail, got %v", err)
+	}
+	if err := g.Place("p1", bsRows()); err != nil || g.Phase != "placing" {
+		t.Fatalf("valid fleet refused: %v", err)
+	}
+	if err := g.PlaceRandom("p2"); err != nil || g.Phase != "playing" {
+		t.Fatalf("random fleet refused: %v", err)
+	}
+	if err := g.Place("p1", bsRows()); err != ErrNotPlacing {
+		t.Fatalf("fleets are fixed once the game starts, got %v", err)
+	}
+}
+
+func TestBattleshipShotsAndViews(t *testing.T) {
+	g, _ := NewBattleship(false, "")
+	g.Place("p1", bsRows())
+	g.Place("p2", bsRows())
+	if _, err := g.Fire("p2", 0, 0); err != ErrNotYourTurn {
+		t.Fatalf("p1 shoots first, got %v", err)
+	}
+	// p1 sinks the destroyer on row 8 while p2 keeps missing
+	for i, col := range []int{0, 1} {
+		shot, err := g.Fire("p1", 8, col)
+		if err != nil || !shot.Hit {
+			t.Fatalf("shot %d: %+v %v", i, shot, err)
+		}
+		if i == 1 && shot.Sunk != "destroyer" {
+			t.Fatalf("expected the destroyer sunk, got %+v", shot)
+		}
+		if shot, _ := g.Fire("p2", 9, col); shot.Hit {
+			t.Fatal("row 9 is empty")
+		}
+	}
+	if _, err := g.Fire("p1", 8, 0); err != ErrAlreadyShot {
+		t.Fatalf("expected repeated shot error, got %v", err)
+	}
+	if _, err := g.Fire("p1", 10, 0); err != ErrOffBoard {
+		t.Fatalf("expected off board error, got %v", err)
+	}
+
+	v := g.View("p1")
+	if len(v.Fleet) != 5 || v.Waters["p2"][8][0] != "sunk" || v.Waters["p1"][9][1] != "miss" || len(v.Sunk["p2"]) != 1 {
+		t.Fatalf("unexpected p1 view %+v", v)
+	}
+	for seat, view := range map[string]*BattleshipView{"spectator": g.View(""), "p2": g.View("p2")} {
+		b, _ := json.Marshal(view)
+		// the carrier of p1 sits on row 0; nobody but p1 may learn that
+		if seat == "spectator" && strings.Contains(string(b), `"carrier"`) {
+			t.Fatalf("spectators see a fleet: %s", b)
+		}
+		for _, s := range view.Fleet {
+			if view.Seat != "p2" || s.Name == "carrier" && s.Row != 0 {
+				t.Fatalf("%s sees the wrong fleet", seat)
+			}
+		}
+		if view.Revealed != nil {
+			t.Fatal("fleets are revealed only at the end")
+		}
+	}
+	b, _ := json.Marshal(g)
+	if strings.Contains(string(b), "carrier") {
+		t.Fatalf("the game itself must not carry the fleets: %s", b)
+	}
+
+	for r := 0; r < 8; r += 2 {
+		for c := 0; c < BattleshipFleet[r/2].Size; c++ {
+			g.Fire("p1", r, c)
+			if g.Phase == "playing" {
+				free := g.Legal()
+				g.Fire("p2", free[len(free)-1][0], free[len(free)-1][1])
+			}
+		}
+	}
+	if g.Winner != "p1" || g.Phase != "over" || g.View("").Revealed == nil {
+		t.Fatalf("p1 should have won: %+v", g.View(""))
+	}
+}
+
+func TestBattleshipDensityChasesHits(t *testing.T) {
+	w := make([][]string, bsSize)
+	for r := range w {
+		w[r] = make([]string, bsSize)
+	}
+	empty := BattleshipDensity(w, []int{5, 4, 3, 3, 2})
+	if empty[4][4] <= empty[0][0] || empty[4][4] != empty[5][5] {
+		t.Fatalf("the centre should be densest on an empty board: %v", empty)
+	}
+	w[5][5] = "hit"
+	w[4][5] = "miss"
+	d := BattleshipDensity(w, []int{2})
+	best := [2]int{}
+	for r := range d {
+		for c := range d[r] {
+			if d[r][c] > d[best[0]][best[1]] {
+				best = [2]int{r, c}
+			}
+		}
+	}
+	if best != [2]int{6, 5} && best != [2]int{5, 4} && best != [2]int{5, 6} {
+		t.Fatalf("expected a neighbour of the hit, got %v", best)
+	}
+	if d[4][5] != 0 || d[5][5] != 0 {
+		t.Fatal("shot cells must not be targeted")
+	}
+}
+
+func TestBattleshipAIBeatsRandom(t *testing.T) {
+	shots := map[string]int{}
+	for _, level := range []string{"easy", "medium", "hard"} {
+		for i := 0; i < 30; i++ {
+			g, _ := NewBattleship(true, level)
+			g.PlaceRandom("p1")
+			// only the AI shoots
+			seen := map[[2]int]bool{}
+			for g.Phase == "playing" {
+				g.Turn = "p2"
+				r, c := g.aiTarget()
+				if seen[[2]int{r, c}] {
+					t.Fatalf("AI shot %v twice", [2]int{r, c})
+				}
+				seen[[2]int{r, c}] = true
+				if _, err := g.fire("p2", r, c); err != nil {
+					t.Fatal(err)
+				}
+			}
+			shots[level] += len(seen)
+		}
+	}
+	t.Logf("shots to sink a fleet, over 30 games: %v", shots)
+	if shots["hard"] >= shots["medium"] || shots["medium"] >= shots["easy"] {
+		t.Fatalf("harder levels should need fewer shots: %v", shots)
+	}
+	if shots["hard"] > 30*60 {
+		t.Fatalf("density targeting averages over 60 shots: %v", shots)
+	}
+}
+
+func TestBattleshipAIFleetIsFixed(t *testing.T) {
+	g, _ := NewBattleship(true, "")
+	if err := g.Place("p2", bsRows()); err != ErrAISeat {
+		t.Fatalf("placing the AI fleet: got %v", err)
+	}
+	if err := g.PlaceRandom("p2"); err != ErrAISeat {
+		t.Fatalf("placing the AI fleet at random: got %v", err)
+	}
+	if !g.Ready("p2") {
+		t.Fatal("the AI places its fleet on reset")
+	}
+}
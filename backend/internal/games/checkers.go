@@ -0,0 +1,226 @@
+package games
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+	"strings"
+)
+
+// Checkers is American checkers (English draughts) on 8x8. Board[0] is
+// Black's home row; "b" and "w" are men, "B" and "W" kings. Black ("B")
+// moves first. Captures are compulsory, jumps continue as long as they can
+// and a man reaching the far row is crowned (ending its move). A side that
+// cannot move loses; the same position three times with the same side to
+// move, or 40 moves each without a capture or a man moving, is a draw.
+// Legal lists the moves of the side to move so the board can highlight them.
+// With VsAI the human plays Black and the AI answers as White.
+type Checkers struct {
+	Board         [8][8]string   `json:"board"`
+	CurrentPlayer string         `json:"currentPlayer"`
+	Winner        string         `json:"winner"` // "B", "W", "D" for a draw, or "" while ongoing
+	DrawReason    string         `json:"drawReason,omitempty"` // repetition or 40 moves
+	Legal         []CheckersMove `json:"legal"`
+	Moves         []CheckersMove `json:"moves"`
+	FEN           string         `json:"fen"`        // the position in PDN FEN, e.g. "B:W21,...:B1,..."
+	QuietMoves    int            `json:"quietMoves"` // plies since the last capture or man move
+	VsAI          bool           `json:"vsAI"`
+	Difficulty    string         `json:"difficulty"` // easy, medium or hard (search depth)
+	Series        *Series        `json:"series,omitempty"`
+	Spectators    int            `json:"spectators"`
+
+	start   ckPos
+	pos     ckPos
+	history []ckState // the state before each move, for undo
+	seen    map[string]int
+}
+
+// CheckersMove is one move: its standard notation ("11-15", "15x24x31"), the
+// [row, col] squares it visits and the ones it captures.
+type CheckersMove struct {
+	Notation string   `json:"notation"`
+	Path     [][2]int `json:"path"`
+	Captures [][2]int `json:"captures"`
+	Player   string   `json:"player,omitempty"`
+	Crowned  bool     `json:"crowned,omitempty"`
+}
+
+type ckState struct {
+	pos   ckPos
+	quiet int
+}
+
+// ckDepth is the alpha-beta search depth per difficulty.
+var ckDepth = map[string]int{"easy": 2, "medium": 5, "hard": 7}
+
+// ckQuietLimit is the 40-move rule in plies.
+const ckQuietLimit = 80
+
+var (
+	ErrBadCheckersLevel = errors.New("difficulty must be easy, medium or hard")
+	ErrIllegalMove      = errors.New("illegal move")
+)
+
+// NewCheckers starts a game from the initial position. An empty difficulty
+// means medium.
+func NewCheckers(vsAI bool, difficulty string) (*Checkers, error) {
+	if difficulty == "" { difficulty = "medium" }
+	if _, ok := ckDepth[difficulty]; !ok { return nil, ErrBadCheckersLevel }
+	g := &Checkers{VsAI: vsAI, Difficulty: difficulty, start: ckStart()}
+	g.Reset()
+	log.Printf("[CHECKERS] New game created vsAI=%v difficulty=%s", vsAI, difficulty)
+	return g, nil
+}
+
+// SetPosition restarts the game from a PDN FEN position; Reset returns to it.
+// Against the AI a position with White to move lets the AI move first.
+func (g *Checkers) SetPosition(fen string) error {
+	p, err := parseCheckersFEN(fen)
+	if err != nil { return err }
+	g.start = p
+	g.Reset()
+	return nil
+}
+
+// Reset goes back to the starting position keeping mode and difficulty.
+func (g *Checkers) Reset() {
+	g.pos, g.history = g.start, nil
+	g.Winner, g.DrawReason, g.QuietMoves = "", "", 0
+	g.Moves = []CheckersMove{}
+	g.seen = map[string]int{g.pos.key(): 1}
+	g.update()
+	if g.VsAI && g.CurrentPlayer == "W" && g.Winner == "" { g.aiMove() }
+}
+
+// Move plays notation for the side to move: the full path ("11-15",
+// "15x24x31") or, when only one capture fits, just its ends ("15x31").
+// Against the AI its answer is played straight away.
+func (g *Checkers) Move(notation string) error {
+	if g.Winner != "" { return ErrGameOver }
+	m, ok := g.find(strings.TrimSpace(notation))
+	if !ok { return fmt.Errorf("%w %q", ErrIllegalMove, notation) }
+	g.play(m)
+	if g.VsAI && g.CurrentPlayer == "W" && g.Winner == "" { g.aiMove() }
+	if g.Winner != "" { log.Printf("[CHECKERS] Game over, winner %s %s after %d moves", g.Winner, g.DrawReason, len(g.Moves)) }
+	return nil
+}
+
+func (g *Checkers) find(notation string) (ckMove, bool) {
+	var short []ckMove
+	for _, m := range g.pos.moves() {
+		n := m.notation()
+		if n == notation { return m, true }
+		parts := strings.Split(n, "x")
+		if len(parts) > 2 && parts[0]+"x"+parts[len(parts)-1] == notation { short = append(short, m) }
+	}
+	if len(short) == 1 { return short[0], true }
+	return ckMove{}, false
+}
+
+// play makes m and applies the end of game rules.
+func (g *Checkers) play(m ckMove) {
+	mover := g.CurrentPlayer
+	man := g.pos.sq[m.from()] == ckBlackMan || g.pos.sq[m.from()] == ckWhiteMan
+	g.history = append(g.history, ckState{pos: g.pos, quiet: g.QuietMoves})
+	cm := ckView(m, mover, man && ckCrowns(g.pos.sq[m.from()], m.to()))
+	g.pos.make(m)
+	g.QuietMoves++
+	if man || len(m.caps) > 0 { g.QuietMoves = 0 }
+	g.seen[g.pos.key()]++
+	g.Moves = append(g.Moves, cm)
+	g.update()
+	switch {
+	case len(g.Legal) == 0:
+		g.Winner = mover
+	case g.seen[g.pos.key()] >= 3:
+		g.Winner, g.DrawReason = "D", "repetition"
+	case g.QuietMoves >= ckQuietLimit:
+		g.Winner, g.DrawReason = "D", "40 moves"
+	}
+	if g.Winner != "" { g.Legal = []CheckersMove{} }
+}
+
+// update mirrors the engine position into the exported fields.
+func (g *Checkers) update() {
+	g.Board = [8][8]string{}
+	names := map[int8]string{ckBlackMan: "b", ckBlackKing: "B", ckWhiteMan: "w", ckWhiteKing: "W"}
+	for i, v := range g.pos.sq {
+		r, c := ckCoord(i)
+		g.Board[r][c] = names[v]
+	}
+	g.CurrentPlayer = "W"
+	if g.pos.black { g.CurrentPlayer = "B" }
+	g.Legal = []CheckersMove{}
+	for _, m := range g.pos.moves() { g.Legal = append(g.Legal, ckView(m, "", false)) }
+	g.FEN = g.pos.fen()
+}
+
+func ckView(m ckMove, player string, crowned bool) CheckersMove {
+	cm := CheckersMove{Notation: m.notation(), Path: [][2]int{}, Captures: [][2]int{}, Player: player, Crowned: crowned}
+	for _, s := range m.path {
+		r, c := ckCoord(int(s))
+		cm.Path = append(cm.Path, [2]int{r, c})
+	}
+	for _, s := range m.caps {
+		r, c := ckCoord(int(s))
+		cm.Captures = append(cm.Captures, [2]int{r, c})
+	}
+	return cm
+}
+
+// Undo takes back the last move; against the AI it also takes back the
+// human move before an AI reply so it is the human's turn again.
+func (g *Checkers) Undo() bool {
+	if len(g.history) == 0 { return false }
+	g.undoOne()
+	if g.VsAI && g.CurrentPlayer == "W" && len(g.history) > 0 { g.undoOne() }
+	return true
+}
+
+func (g *Checkers) undoOne() {
+	g.seen[g.pos.key()]--
+	last := g.history[len(g.history)-1]
+	g.history = g.history[:len(g.history)-1]
+	g.pos, g.QuietMoves = last.pos, last.quiet
+	g.Moves = g.Moves[:len(g.Moves)-1]
+	g.Winner, g.DrawReason = "", ""
+	g.update()
+}
+
+func (g *Checkers) aiMove() {
+	m := g.bestMove(ckDepth[g.Difficulty])
+	log.Printf("[CHECKERS][AI] depth=%d chose %s", ckDepth[g.Difficulty], m.notation())
+	g.play(m)
+}
+
+// BestMove returns the notation of the move the AI would play for the side
+// to move at difficulty, or "" when the game is over or the difficulty is
+// unknown.
+func (g *Checkers) BestMove(difficulty string) string {
+	depth, ok := ckDepth[difficulty]
+	if !ok || g.Winner != "" { return "" }
+	return g.bestMove(depth).notation()
+}
+
+// bestMove searches depth plies with alpha-beta; ties between equally good
+// moves are broken at random so games vary.
+func (g *Checkers) bestMove(depth int) ckMove {
+	p := g.pos
+	moves := p.moves()
+	ckOrder(moves)
+	bestScore := -ckWin * 2
+	var ties []ckMove
+	for _, m := range moves {
+		u := p.make(m)
+		score := -p.negamax(depth-1, -ckWin*2, -bestScore+1)
+		p.unmake(m, u)
+		switch {
+		case score > bestScore:
+			bestScore, ties = score, []ckMove{m}
+		case score == bestScore:
+			ties = append(ties, m)
+		}
+	}
+	return ties[rand.Intn(len(ties))]
+}
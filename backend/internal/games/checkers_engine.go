@@ -0,0 +1,298 @@
+package games
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// The checkers engine works on the 32 dark squares, indexed 0-31 in the
+// standard numbering minus one: square 1 is the top-left dark square of
+// Black's side (row 0), square 32 the bottom-right of White's. Black moves
+// down the board, White up.
+const (
+	ckBlackMan  int8 = 1
+	ckBlackKing int8 = 2
+	ckWhiteMan  int8 = -1
+	ckWhiteKing int8 = -2
+)
+
+// ckNext[i][d] is the diagonal neighbour of square i in direction d (-1 off
+// the board) and ckJump[i][d] the square beyond it. Directions 0 and 1 lead
+// down (Black's forward), 2 and 3 up.
+var ckNext, ckJump [32][4]int8
+
+func init() {
+	dirs := [4][2]int{{1, -1}, {1, 1}, {-1, -1}, {-1, 1}}
+	at := func(r, c int) int8 {
+		if r < 0 || r > 7 || c < 0 || c > 7 { return -1 }
+		return int8(r*4 + c/2)
+	}
+	for i := 0; i < 32; i++ {
+		r, c := ckCoord(i)
+		for d, dir := range dirs {
+			ckNext[i][d] = at(r+dir[0], c+dir[1])
+			ckJump[i][d] = at(r+2*dir[0], c+2*dir[1])
+		}
+	}
+}
+
+// ckCoord returns the board row and column of square i.
+func ckCoord(i int) (int, int) {
+	r := i / 4
+	return r, 2*(i%4) + 1 - r%2
+}
+
+// ckPos is a position: the 32 squares (positive Black, negative White, 2 for
+// kings) and the side to move.
+type ckPos struct {
+	sq    [32]int8
+	black bool
+}
+
+// ckMove is a move as the squares it visits and the squares it captures.
+type ckMove struct {
+	path []int8
+	caps []int8
+}
+
+func (m ckMove) from() int8 { return m.path[0] }
+func (m ckMove) to() int8   { return m.path[len(m.path)-1] }
+
+// notation writes m the standard way, e.g. "11-15" or "15x24x31".
+func (m ckMove) notation() string {
+	sep := "-"
+	if len(m.caps) > 0 { sep = "x" }
+	parts := make([]string, len(m.path))
+	for i, s := range m.path { parts[i] = strconv.Itoa(int(s) + 1) }
+	return strings.Join(parts, sep)
+}
+
+func ckStart() ckPos {
+	var p ckPos
+	for i := 0; i < 12; i++ {
+		p.sq[i] = ckBlackMan
+		p.sq[31-i] = ckWhiteMan
+	}
+	p.black = true
+	return p
+}
+
+func (p *ckPos) side() int8 { if p.black { return 1 }; return -1 }
+
+// ckDirs lists the directions a piece moves in.
+func ckDirs(piece int8) []int {
+	switch piece {
+	case ckBlackMan:
+		return []int{0, 1}
+	case ckWhiteMan:
+		return []int{2, 3}
+	}
+	return []int{0, 1, 2, 3}
+}
+
+// ckCrowns reports whether piece becomes a king on square s.
+func ckCrowns(piece int8, s int8) bool {
+	return piece == ckBlackMan && s >= 28 || piece == ckWhiteMan && s < 4
+}
+
+// moves generates the legal moves. Captures are compulsory and a capturing
+// piece must keep jumping while it can, except that a man crowned by a jump
+// stops there. Any capture sequence may be chosen, not only the longest.
+func (p *ckPos) moves() []ckMove {
+	side := p.side()
+	var out []ckMove
+	for s := int8(0); s < 32; s++ {
+		if piece := p.sq[s]; piece*side > 0 {
+			// lift the piece so a king may pass its own starting square
+			p.sq[s] = 0
+			p.jumps(piece, []int8{s}, nil, &out)
+			p.sq[s] = piece
+		}
+	}
+	if len(out) > 0 { return out }
+	for s := int8(0); s < 32; s++ {
+		piece := p.sq[s]
+		if piece*side <= 0 { continue }
+		for _, d := range ckDirs(piece) {
+			if to := ckNext[s][d]; to >= 0 && p.sq[to] == 0 { out = append(out, ckMove{path: []int8{s, to}}) }
+		}
+	}
+	return out
+}
+
+// jumps extends the capture sequence path by every possible next jump. Jumped
+// pieces stay on the board until the move is over, so they block landing
+// squares and cannot be jumped twice.
+func (p *ckPos) jumps(piece int8, path, caps []int8, out *[]ckMove) {
+	cur := path[len(path)-1]
+	extended := false
+	for _, d := range ckDirs(piece) {
+		mid, land := ckNext[cur][d], ckJump[cur][d]
+		if land < 0 || p.sq[mid]*piece >= 0 || p.sq[land] != 0 || ckHas(caps, mid) { continue }
+		extended = true
+		np := append(append([]int8{}, path...), land)
+		nc := append(append([]int8{}, caps...), mid)
+		if ckCrowns(piece, land) {
+			*out = append(*out, ckMove{path: np, caps: nc})
+		} else {
+			p.jumps(piece, np, nc, out)
+		}
+	}
+	if !extended && len(caps) > 0 { *out = append(*out, ckMove{path: path, caps: caps}) }
+}
+
+func ckHas(s []int8, v int8) bool {
+	for _, x := range s {
+		if x == v { return true }
+	}
+	return false
+}
+
+// ckUndo holds what make changed.
+type ckUndo struct {
+	piece    int8
+	captured []int8
+}
+
+// make plays m and returns what unmake needs to take it back.
+func (p *ckPos) make(m ckMove) ckUndo {
+	u := ckUndo{piece: p.sq[m.from()], captured: make([]int8, len(m.caps))}
+	p.sq[m.from()] = 0
+	for i, c := range m.caps {
+		u.captured[i] = p.sq[c]
+		p.sq[c] = 0
+	}
+	piece := u.piece
+	if ckCrowns(piece, m.to()) { piece *= 2 }
+	p.sq[m.to()] = piece
+	p.black = !p.black
+	return u
+}
+
+func (p *ckPos) unmake(m ckMove, u ckUndo) {
+	p.black = !p.black
+	p.sq[m.to()] = 0
+	for i, c := range m.caps { p.sq[c] = u.captured[i] }
+	p.sq[m.from()] = u.piece
+}
+
+// perft counts the move sequences of depth plies, the standard check of a
+// move generator.
+func (p *ckPos) perft(depth int) int {
+	if depth == 0 { return 1 }
+	moves := p.moves()
+	if depth == 1 { return len(moves) }
+	n := 0
+	for _, m := range moves {
+		u := p.make(m)
+		n += p.perft(depth - 1)
+		p.unmake(m, u)
+	}
+	return n
+}
+
+// key identifies the position for repetition.
+func (p *ckPos) key() string {
+	b := make([]byte, 33)
+	for i, v := range p.sq { b[i] = byte(v + 2) }
+	if p.black { b[32] = 1 }
+	return string(b)
+}
+
+var ErrBadCheckersFEN = errors.New(`position must look like "B:W21,22,K30:B1,2,K9" (side to move, then each side's squares, K for kings)`)
+
+// parseCheckersFEN reads a position in PDN FEN, e.g. "B:W21,22,K30:B1,2,K9":
+// the side to move, then White's and Black's squares with K marking kings.
+func parseCheckersFEN(fen string) (ckPos, error) {
+	var p ckPos
+	parts := strings.Split(strings.ToUpper(strings.ReplaceAll(strings.TrimSuffix(strings.TrimSpace(fen), "."), " ", "")), ":")
+	if len(parts) != 3 || (parts[0] != "B" && parts[0] != "W") { return p, ErrBadCheckersFEN }
+	p.black = parts[0] == "B"
+	for _, part := range parts[1:] {
+		if part == "" { return p, ErrBadCheckersFEN }
+		man := ckBlackMan
+		if part[0] == 'W' { man = ckWhiteMan } else if part[0] != 'B' { return p, ErrBadCheckersFEN }
+		if len(part) == 1 { continue }
+		for _, f := range strings.Split(part[1:], ",") {
+			piece := man
+			if strings.HasPrefix(f, "K") { piece, f = man*2, f[1:] }
+			n, err := strconv.Atoi(f)
+			if err != nil || n < 1 || n > 32 || p.sq[n-1] != 0 { return p, ErrBadCheckersFEN }
+			p.sq[n-1] = piece
+		}
+	}
+	return p, nil
+}
+
+// fen writes the position in PDN FEN.
+func (p *ckPos) fen() string {
+	side := "W"
+	if p.black { side = "B" }
+	list := func(man int8) string {
+		var sq []string
+		for i, v := range p.sq {
+			switch v {
+			case man:
+				sq = append(sq, strconv.Itoa(i+1))
+			case man * 2:
+				sq = append(sq, "K"+strconv.Itoa(i+1))
+			}
+		}
+		return strings.Join(sq, ",")
+	}
+	return fmt.Sprintf("%s:W%s:B%s", side, list(ckWhiteMan), list(ckBlackMan))
+}
+
+const ckWin = 100000
+
+// evaluate scores the position for the side to move: material (kings worth
+// more than men), men advancing towards the crowning row, and the back row
+// kept to stop the opponent from crowning.
+func (p *ckPos) evaluate() int {
+	score := 0
+	for i, v := range p.sq {
+		row := i / 4
+		switch v {
+		case ckBlackMan:
+			score += 100 + 3*row
+			if row == 0 { score += 8 }
+		case ckWhiteMan:
+			score -= 100 + 3*(7-row)
+			if row == 7 { score -= 8 }
+		case ckBlackKing:
+			score += 150
+		case ckWhiteKing:
+			score -= 150
+		}
+		// centre squares are worth a little to anyone
+		if c := i % 4; row >= 2 && row <= 5 && (c == 1 || c == 2) { score += int(v) * 2 }
+	}
+	return score * int(p.side())
+}
+
+// negamax scores the position for the side to move; a side without moves
+// has lost, sooner losses scoring worse.
+func (p *ckPos) negamax(depth, alpha, beta int) int {
+	moves := p.moves()
+	if len(moves) == 0 { return -(ckWin + depth) }
+	// keep searching forced captures past the horizon so exchanges are
+	// never cut in half
+	if depth <= 0 && len(moves[0].caps) == 0 { return p.evaluate() }
+	ckOrder(moves)
+	for _, m := range moves {
+		u := p.make(m)
+		score := -p.negamax(depth-1, -beta, -alpha)
+		p.unmake(m, u)
+		if score > alpha { alpha = score }
+		if alpha >= beta { break }
+	}
+	return alpha
+}
+
+// ckOrder tries the longest captures first.
+func ckOrder(moves []ckMove) {
+	sort.SliceStable(moves, func(a, b int) bool { return len(moves[a].caps) > len(moves[b].caps) })
+}
@@ -0,0 +1,126 @@
+package games
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckersPerft(t *testing.T) {
+	// published perft figures for the initial position
+	want := []int{1, 7, 49, 302, 1469, 7361, 36768, 179740}
+	p := ckStart()
+	for depth, n := range want {
+		if got := p.perft(depth); got != n {
+			t.Fatalf("perft(%d) = %d, want %d", depth, got, n)
+		}
+	}
+	if p != ckStart() {
+		t.Fatal("perft must leave the position unchanged")
+	}
+}
+
+func TestCheckersCapturesAreMandatory(t *testing.T) {
+	g, _ := NewCheckers(false, "")
+	for _, m := range []string{"11-15", "24-20", "15-19"} {
+		if err := g.Move(m); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// 23 must take 19 now
+	if len(g.Legal) != 1 || g.Legal[0].Notation != "23x16" {
+		t.Fatalf("expected the forced 23x16, got %+v", g.Legal)
+	}
+	if err := g.Move("22-17"); !errors.Is(err, ErrIllegalMove) {
+		t.Fatalf("a quiet move must be refused while a capture exists, got %v", err)
+	}
+	if err := g.Move("23x16"); err != nil || g.Board[4][6] != "" {
+		t.Fatalf("capture failed: %v %v", err, g.Board)
+	}
+}
+
+func TestCheckersMultiJumpAndCrowning(t *testing.T) {
+	g, _ := NewCheckers(false, "")
+	// a black man on 9 can take 14 then 23; a white man jumping into row 0 stops there
+	if err := g.SetPosition("B:W14,23,K30:B9"); err != nil {
+		t.Fatal(err)
+	}
+	if len(g.Legal) != 1 || g.Legal[0].Notation != "9x18x27" || len(g.Legal[0].Captures) != 2 {
+		t.Fatalf("expected the double jump, got %+v", g.Legal)
+	}
+	if err := g.Move("9x27"); err != nil {
+		t.Fatalf("the short form should pick the only matching capture: %v", err)
+	}
+	if g.FEN != "W:WK30:B27" {
+		t.Fatalf("unexpected position %s", g.FEN)
+	}
+
+	g.SetPosition("W:W10:B6,K15")
+	// 10x1 crowns on the first row and must stop although the new king could go on
+	if len(g.Legal) != 1 || g.Legal[0].Notation != "10x1" {
+		t.Fatalf("expected the crowning jump to end the move, got %+v", g.Legal)
+	}
+	g.Move("10x1")
+	if last := g.Moves[len(g.Moves)-1]; !last.Crowned || g.Board[0][1] != "W" {
+		t.Fatalf("the man should be crowned: %+v", last)
+	}
+}
+
+func TestCheckersEndings(t *testing.T) {
+	g, _ := NewCheckers(false, "")
+	g.SetPosition("B:WK32:BK1")
+	for _, m := range []string{"1-6", "32-27", "6-1", "27-32", "1-6", "32-27", "6-1", "27-32"} {
+		if err := g.Move(m); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if g.Winner != "D" || g.DrawReason != "repetition" {
+		t.Fatalf("expected a draw by repetition, got %q %q", g.Winner, g.DrawReason)
+	}
+	if !g.Undo() || g.Winner != "" || g.CurrentPlayer != "W" {
+		t.Fatal("undo should reopen the game")
+	}
+
+	g.SetPosition("B:WK32:BK1")
+	g.QuietMoves = ckQuietLimit - 1
+	g.Move("1-5")
+	if g.Winner != "D" || g.DrawReason != "40 moves" {
+		t.Fatalf("expected the 40-move draw, got %q %q", g.Winner, g.DrawReason)
+	}
+
+	// White is blocked completely: the man on 29 faces a black man on 25 with 22 behind it
+	g.SetPosition("B:W29:B25,22,10")
+	g.Move("10-14")
+	if g.Winner != "B" || len(g.Legal) != 0 {
+		t.Fatalf("a side without moves loses, got %q", g.Winner)
+	}
+	if err := g.Move("14-18"); err != ErrGameOver {
+		t.Fatalf("expected game over, got %v", err)
+	}
+}
+
+func TestCheckersAI(t *testing.T) {
+	g, err := NewCheckers(true, "hard")
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.Move("9-13")
+	if g.CurrentPlayer != "B" || len(g.Moves) != 2 {
+		t.Fatalf("the AI should have replied: %+v", g.Moves)
+	}
+	// against the AI a position with White to move starts with its move
+	g.SetPosition("W:WK15:B18,1")
+	if len(g.Moves) != 1 || g.Moves[0].Notation != "15x22" {
+		t.Fatalf("the AI should open by taking 18, got %+v", g.Moves)
+	}
+	g.VsAI = false
+	g.SetPosition("W:WK15:B18,1")
+	if m := g.BestMove("easy"); m != "15x22" {
+		t.Fatalf("expected the capture, got %s", m)
+	}
+	if _, err := NewCheckers(false, "grandmaster"); err != ErrBadCheckersLevel {
+		t.Fatalf("expected bad level, got %v", err)
+	}
+	if err := g.SetPosition("X:W1"); err != ErrBadCheckersFEN {
+		t.Fatalf("expected bad FEN, got %v", err)
+	}
+}
@@ -0,0 +1,314 @@
+package games
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Chess under the FIDE rules, castling, en passant and promotion included.
+// Board[0] is Black's back rank (rank 8) and Board[r][0] the a-file; white
+// pieces are "KQRBNP", black ones "kqrbnp". White ("W") moves first. Moves
+// are taken in UCI ("e2e4", "e7e8q") or SAN ("Nf3", "exd5", "O-O"); Legal
+// lists both forms for the side to move. Checkmate wins; stalemate, the
+// same position three times, 50 moves each without a capture or pawn move,
+// or too little material to mate is a draw. With VsAI the human plays
+// HumanPlays (White unless changed by PlayAs) and the AI the other colour.
+type Chess struct {
+	Board         [8][8]string `json:"board"`
+	CurrentPlayer string       `json:"currentPlayer"`
+	Winner        string       `json:"winner"` // "W", "B", "D" for a draw, or "" while ongoing
+	DrawReason    string       `json:"drawReason,omitempty"` // stalemate, repetition, 50 moves or insufficient material
+	Check         bool         `json:"check"`
+	Legal         []ChessMove  `json:"legal"`
+	Moves         []ChessMove  `json:"moves"`
+	FEN           string       `json:"fen"`
+	VsAI          bool         `json:"vsAI"`
+	Difficulty    string       `json:"difficulty"`           // easy, medium or hard (search depth)
+	HumanPlays    string       `json:"humanPlays,omitempty"` // the human's colour against the AI
+	Series        *Series      `json:"series,omitempty"`
+	Spectators    int          `json:"spectators"`
+
+	start   chPos
+	pos     chPos
+	history []chPos // the position before each move, for undo
+	seen    map[string]int
+	started time.Time
+}
+
+// ChessMove is one move in both notations with its from and to squares
+// ("e2", "e4").
+type ChessMove struct {
+	UCI    string `json:"uci"`
+	SAN    string `json:"san"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Player string `json:"player,omitempty"`
+}
+
+// chDepth is the alpha-beta search depth per difficulty, captures being
+// followed further.
+var chDepth = map[string]int{"easy": 1, "medium": 2, "hard": 4}
+
+// chFiftyMoves is the 50-move rule in plies.
+const chFiftyMoves = 100
+
+var ErrBadChessLevel = errors.New("difficulty must be easy, medium or hard")
+
+// NewChess starts a game from the initial position. An empty difficulty
+// means medium.
+func NewChess(vsAI bool, difficulty string) (*Chess, error) {
+	if difficulty == "" { difficulty = "medium" }
+	if _, ok := chDepth[difficulty]; !ok { return nil, ErrBadChessLevel }
+	g := &Chess{VsAI: vsAI, Difficulty: difficulty}
+	g.start, _ = parseFEN(chStartFEN)
+	if vsAI { g.HumanPlays = "W" }
+	g.Reset()
+	log.Printf("[CHESS] New game created vsAI=%v difficulty=%s", vsAI, difficulty)
+	return g, nil
+}
+
+// SetPosition restarts the game from a FEN position; Reset returns to it.
+// Against the AI a position with the AI to move lets it move first.
+func (g *Chess) SetPosition(fen string) error {
+	p, err := parseFEN(fen)
+	if err != nil { return err }
+	g.start = p
+	g.Reset()
+	return nil
+}
+
+// PlayAs sets the colour the human plays against the AI and restarts the
+// game, so an AI playing White opens straight away.
+func (g *Chess) PlayAs(color string) error {
+	if color != "W" && color != "B" { return ErrBadColor }
+	if !g.VsAI { return nil }
+	g.HumanPlays = color
+	g.Reset()
+	return nil
+}
+
+// Reset goes back to the starting position keeping mode and difficulty.
+func (g *Chess) Reset() {
+	g.pos, g.history = g.start, nil
+	g.Winner, g.DrawReason = "", ""
+	g.Moves = []ChessMove{}
+	g.started = time.Now()
+	g.update()
+	g.seen = map[string]int{g.key(): 1}
+	g.judge()
+	g.aiTurn()
+}
+
+// Move plays a UCI or SAN move for the side to move; against the AI its
+// answer is played straight away.
+func (g *Chess) Move(move string) error {
+	if g.Winner != "" { return ErrGameOver }
+	m, ok := g.pos.parse(move, g.pos.legal())
+	if !ok { return fmt.Errorf("%w %q", ErrIllegalMove, move) }
+	g.play(m)
+	g.aiTurn()
+	if g.Winner != "" { log.Printf("[CHESS] Game over, winner %s %s after %d moves", g.Winner, g.DrawReason, len(g.Moves)) }
+	return nil
+}
+
+func (g *Chess) aiTurn() {
+	if g.VsAI && g.Winner == "" && g.CurrentPlayer != g.HumanPlays {
+		m := g.bestMove(chDepth[g.Difficulty])
+		log.Printf("[CHESS][AI] depth=%d chose %s", chDepth[g.Difficulty], m.uci())
+		g.play(m)
+	}
+}
+
+// play makes m and applies the end of game rules.
+func (g *Chess) play(m chMove) {
+	cm := ChessMove{UCI: m.uci(), SAN: g.pos.san(m, g.pos.legal()), From: chName(m.from), To: chName(m.to), Player: g.CurrentPlayer}
+	g.history = append(g.history, g.pos)
+	g.pos.make(m)
+	g.Moves = append(g.Moves, cm)
+	g.update()
+	g.seen[g.key()]++
+	g.judge()
+}
+
+// judge ends the game when the side to move has no move or a draw rule
+// applies.
+func (g *Chess) judge() {
+	switch {
+	case len(g.Legal) == 0 && g.Check:
+		g.Winner = chOther(g.CurrentPlayer)
+	case len(g.Legal) == 0:
+		g.Winner, g.DrawReason = "D", "stalemate"
+	case g.seen[g.key()] >= 3:
+		g.Winner, g.DrawReason = "D", "repetition"
+	case g.pos.half >= chFiftyMoves:
+		g.Winner, g.DrawReason = "D", "50 moves"
+	case g.pos.insufficient():
+		g.Winner, g.DrawReason = "D", "insufficient material"
+	}
+	if g.Winner != "" { g.Legal = []ChessMove{} }
+}
+
+func chOther(p string) string { if p == "W" { return "B" }; return "W" }
+
+// key identifies a position for repetition: the FEN without its counters,
+// the en passant square only counting when a capture there is legal.
+func (g *Chess) key() string {
+	f := strings.Fields(g.FEN)
+	ep := "-"
+	for _, m := range g.pos.legal() {
+		if m.flags&chEnPassant != 0 { ep = f[3] }
+	}
+	return strings.Join([]string{f[0], f[1], f[2], ep}, " ")
+}
+
+// insufficient reports whether neither side can mate: bare kings, a single
+// minor piece, or only bishops all on squares of one colour.
+func (p *chPos) insufficient() bool {
+	minors, bishops := 0, [2]int{}
+	for s, v := range p.sq {
+		switch chAbs(v) {
+		case 0, chKing:
+		case chKnight:
+			minors++
+		case chBishop:
+			minors++
+			bishops[(s/8+s%8)%2]++
+		default:
+			return false
+		}
+	}
+	return minors <= 1 || bishops[0] == minors || bishops[1] == minors
+}
+
+// update mirrors the engine position into the exported fields.
+func (g *Chess) update() {
+	g.Board = [8][8]string{}
+	for s, v := range g.pos.sq {
+		if v != 0 { g.Board[7-s/8][s%8] = chLetter(v) }
+	}
+	g.CurrentPlayer = "B"
+	if g.pos.white { g.CurrentPlayer = "W" }
+	g.Check = g.pos.inCheck()
+	g.FEN = g.pos.fen()
+	legal := g.pos.legal()
+	g.Legal = make([]ChessMove, 0, len(legal))
+	for _, m := range legal {
+		g.Legal = append(g.Legal, ChessMove{UCI: m.uci(), SAN: g.pos.san(m, legal), From: chName(m.from), To: chName(m.to)})
+	}
+}
+
+// Undo takes back the last move; against the AI it also takes back the
+// human move before an AI reply so it is the human's turn again. An AI
+// opening alone is not taken back.
+func (g *Chess) Undo() bool {
+	n := 1
+	if g.VsAI && g.CurrentPlayer == g.HumanPlays { n = 2 }
+	if len(g.history) < n { return false }
+	for ; n > 0; n-- { g.undoOne() }
+	return true
+}
+
+func (g *Chess) undoOne() {
+	g.seen[g.key()]--
+	g.pos = g.history[len(g.history)-1]
+	g.history = g.history[:len(g.history)-1]
+	g.Moves = g.Moves[:len(g.Moves)-1]
+	g.Winner, g.DrawReason = "", ""
+	g.update()
+}
+
+// BestMove returns the UCI move the AI would play for the side to move at
+// difficulty, or "" when the game is over or the difficulty is unknown.
+func (g *Chess) BestMove(difficulty string) string {
+	depth, ok := chDepth[difficulty]
+	if !ok || g.Winner != "" { return "" }
+	return g.bestMove(depth).uci()
+}
+
+// bestMove searches depth plies with alpha-beta; ties between equally good
+// moves are broken at random so games vary.
+func (g *Chess) bestMove(depth int) chMove {
+	p := g.pos
+	moves := p.legal()
+	p.order(moves)
+	bestScore := -chMate * 2
+	var ties []chMove
+	for _, m := range moves {
+		u := p.make(m)
+		score := -p.negamax(depth-1, 1, -chMate*2, -bestScore+1)
+		p.unmake(m, u)
+		switch {
+		case score > bestScore:
+			bestScore, ties = score, []chMove{m}
+		case score == bestScore:
+			ties = append(ties, m)
+		}
+	}
+	return ties[rand.Intn(len(ties))]
+}
+
+// Result is the game's PGN result: "1-0", "0-1", "1/2-1/2" or "*".
+func (g *Chess) Result() string {
+	switch g.Winner {
+	case "W":
+		return "1-0"
+	case "B":
+		return "0-1"
+	case "D":
+		return "1/2-1/2"
+	}
+	return "*"
+}
+
+// PGN exports the game so far in Portable Game Notation with the seven tag
+// roster, plus SetUp and FEN tags when it did not start from the initial
+// position. Empty player names are written as "?".
+func (g *Chess) PGN(white, black string) string {
+	tag := func(v string) string {
+		if v == "" { v = "?" }
+		return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(v)
+	}
+	var b strings.Builder
+	tags := [][2]string{{"Event", "gaMerZ game"}, {"Site", "gaMerZ"}, {"Date", g.started.Format("2006.01.02")},
+		{"Round", "-"}, {"White", white}, {"Black", black}, {"Result", g.Result()}}
+	if start := g.start.fen(); start != chStartFEN { tags = append(tags, [2]string{"SetUp", "1"}, [2]string{"FEN", start}) }
+	for _, t := range tags { fmt.Fprintf(&b, "[%s \"%s\"]\n", t[0], tag(t[1])) }
+	b.WriteByte('\n')
+
+	var tokens []string
+	whiteMoves, number := g.start.white, g.start.full
+	for i, m := range g.Moves {
+		switch {
+		case whiteMoves:
+			tokens = append(tokens, strconv.Itoa(number)+".")
+		case i == 0:
+			tokens = append(tokens, strconv.Itoa(number)+"...")
+		}
+		tokens = append(tokens, m.SAN)
+		if !whiteMoves { number++ }
+		whiteMoves = !whiteMoves
+	}
+	tokens = append(tokens, g.Result())
+	// movetext lines stay under 80 characters
+	line := 0
+	for i, t := range tokens {
+		switch {
+		case i == 0:
+		case line+1+len(t) > 79:
+			b.WriteByte('\n')
+			line = 0
+		default:
+			b.WriteByte(' ')
+			line++
+		}
+		b.WriteString(t)
+		line += len(t)
+	}
+	b.WriteByte('\n')
+	return b.String()
+}
@@ -0,0 +1,418 @@
+package games
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// The chess board is 64 squares indexed rank*8+file from a1 = 0 to h8 = 63;
+// white pieces are positive, black negative.
+const (
+	chPawn int8 = iota + 1
+	chKnight
+	chBishop
+	chRook
+	chQueen
+	chKing
+)
+
+// castling rights
+const (
+	chWhiteShort uint8 = 1 << iota
+	chWhiteLong
+	chBlackShort
+	chBlackLong
+)
+
+// move flags
+const (
+	chCapture uint8 = 1 << iota
+	chEnPassant
+	chCastle
+	chDouble
+)
+
+const chStartFEN = "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1"
+
+// chPos is a position with everything FEN records.
+type chPos struct {
+	sq     [64]int8
+	white  bool // white to move
+	castle uint8
+	ep     int8 // en passant target square, -1 if none
+	half   int  // plies since the last capture or pawn move
+	full   int  // move number
+}
+
+type chMove struct {
+	from, to int8
+	promo    int8 // piece type a pawn promotes to, 0 otherwise
+	flags    uint8
+}
+
+// chUndo holds what make changed.
+type chUndo struct {
+	captured int8
+	castle   uint8
+	ep       int8
+	half     int
+}
+
+var (
+	chKnightTo, chKingTo [64][]int8
+	// chCastleMask clears the castling rights lost when a piece leaves or
+	// lands on a square
+	chCastleMask [64]uint8
+	chRookDirs   = [4][2]int{{0, 1}, {0, -1}, {1, 0}, {-1, 0}}
+	chBishopDirs = [4][2]int{{1, 1}, {1, -1}, {-1, 1}, {-1, -1}}
+)
+
+func init() {
+	for s := 0; s < 64; s++ {
+		f, r := s%8, s/8
+		for _, d := range [8][2]int{{1, 2}, {2, 1}, {2, -1}, {1, -2}, {-1, -2}, {-2, -1}, {-2, 1}, {-1, 2}} {
+			if t, ok := chAt(f+d[0], r+d[1]); ok { chKnightTo[s] = append(chKnightTo[s], t) }
+		}
+		for df := -1; df <= 1; df++ {
+			for dr := -1; dr <= 1; dr++ {
+				if t, ok := chAt(f+df, r+dr); ok && (df != 0 || dr != 0) { chKingTo[s] = append(chKingTo[s], t) }
+			}
+		}
+		chCastleMask[s] = 0xf
+	}
+	chCastleMask[0] &^= chWhiteLong
+	chCastleMask[7] &^= chWhiteShort
+	chCastleMask[4] &^= chWhiteShort | chWhiteLong
+	chCastleMask[56] &^= chBlackLong
+	chCastleMask[63] &^= chBlackShort
+	chCastleMask[60] &^= chBlackShort | chBlackLong
+}
+
+func chAt(file, rank int) (int8, bool) {
+	if file < 0 || file > 7 || rank < 0 || rank > 7 { return -1, false }
+	return int8(rank*8 + file), true
+}
+
+func chAbs(p int8) int8 { if p < 0 { return -p }; return p }
+
+func (p *chPos) sign() int8 { if p.white { return 1 }; return -1 }
+
+func (p *chPos) king(white bool) int8 {
+	k := chKing
+	if !white { k = -chKing }
+	for s, v := range p.sq {
+		if v == k { return int8(s) }
+	}
+	return -1
+}
+
+// attacked reports whether square s is attacked by the given side.
+func (p *chPos) attacked(s int8, byWhite bool) bool {
+	sign := int8(-1)
+	if byWhite { sign = 1 }
+	f, r := int(s%8), int(s/8)
+	// a white pawn attacks from the rank below, a black one from above
+	for _, df := range []int{-1, 1} {
+		if t, ok := chAt(f+df, r-int(sign)); ok && p.sq[t] == sign*chPawn { return true }
+	}
+	for _, t := range chKnightTo[s] {
+		if p.sq[t] == sign*chKnight { return true }
+	}
+	for _, t := range chKingTo[s] {
+		if p.sq[t] == sign*chKing { return true }
+	}
+	for i, dirs := range [2][4][2]int{chRookDirs, chBishopDirs} {
+		slider := chRook
+		if i == 1 { slider = chBishop }
+		for _, d := range dirs {
+			for t, ok := chAt(f+d[0], r+d[1]); ok; t, ok = chAt(int(t%8)+d[0], int(t/8)+d[1]) {
+				if v := p.sq[t]; v != 0 {
+					if v == sign*slider || v == sign*chQueen { return true }
+					break
+				}
+			}
+		}
+	}
+	return false
+}
+
+func (p *chPos) inCheck() bool { return p.attacked(p.king(p.white), !p.white) }
+
+// pseudo generates the moves of the side to move without checking that its
+// king is safe afterwards; with capturesOnly it keeps captures and
+// promotions, for the quiescence search.
+func (p *chPos) pseudo(capturesOnly bool) []chMove {
+	sign := p.sign()
+	moves := make([]chMove, 0, 48)
+	add := func(from, to int8, flags uint8) {
+		if capturesOnly && flags&chCapture == 0 { return }
+		moves = append(moves, chMove{from: from, to: to, flags: flags})
+	}
+	for s8 := int8(0); s8 < 64; s8++ {
+		v := p.sq[s8]
+		if v*sign <= 0 { continue }
+		f, r := int(s8%8), int(s8/8)
+		switch chAbs(v) {
+		case chPawn:
+			dir := int(sign)
+			last := (sign == 1 && r+dir == 7) || (sign == -1 && r+dir == 0)
+			pawnMove := func(to int8, flags uint8) {
+				if !last {
+					add(s8, to, flags)
+					return
+				}
+				for _, promo := range []int8{chQueen, chRook, chBishop, chKnight} {
+					moves = append(moves, chMove{from: s8, to: to, promo: promo, flags: flags})
+				}
+			}
+			if t, ok := chAt(f, r+dir); ok && p.sq[t] == 0 {
+				if !capturesOnly || last { pawnMove(t, 0) }
+				start := (sign == 1 && r == 1) || (sign == -1 && r == 6)
+				if t2, _ := chAt(f, r+2*dir); start && p.sq[t2] == 0 { add(s8, t2, chDouble) }
+			}
+			for _, df := range []int{-1, 1} {
+				t, ok := chAt(f+df, r+dir)
+				if !ok { continue }
+				if p.sq[t]*sign < 0 {
+					pawnMove(t, chCapture)
+				} else if t == p.ep {
+					add(s8, t, chCapture|chEnPassant)
+				}
+			}
+		case chKnight, chKing:
+			targets := chKnightTo[s8]
+			if chAbs(v) == chKing { targets = chKingTo[s8] }
+			for _, t := range targets {
+				switch {
+				case p.sq[t] == 0:
+					add(s8, t, 0)
+				case p.sq[t]*sign < 0:
+					add(s8, t, chCapture)
+				}
+			}
+		default:
+			var dirs [][2]int
+			if chAbs(v) != chBishop { dirs = append(dirs, chRookDirs[:]...) }
+			if chAbs(v) != chRook { dirs = append(dirs, chBishopDirs[:]...) }
+			for _, d := range dirs {
+				for t, ok := chAt(f+d[0], r+d[1]); ok; t, ok = chAt(int(t%8)+d[0], int(t/8)+d[1]) {
+					if p.sq[t] == 0 {
+						add(s8, t, 0)
+						continue
+					}
+					if p.sq[t]*sign < 0 { add(s8, t, chCapture) }
+					break
+				}
+			}
+		}
+	}
+	if !capturesOnly { moves = append(moves, p.castles()...) }
+	return moves
+}
+
+// castles lists the castling moves: the king and rook unmoved, the squares
+// between them empty and the king not in check, nor passing or landing on an
+// attacked square.
+func (p *chPos) castles() []chMove {
+	var out []chMove
+	king, short, long, enemy := int8(4), chWhiteShort, chWhiteLong, !p.white
+	if !p.white { king, short, long = 60, chBlackShort, chBlackLong }
+	rook := chRook * p.sign()
+	if p.sq[king] != chKing*p.sign() || p.attacked(king, enemy) { return nil }
+	if p.castle&short != 0 && p.sq[king+3] == rook && p.sq[king+1] == 0 && p.sq[king+2] == 0 &&
+		!p.attacked(king+1, enemy) && !p.attacked(king+2, enemy) {
+		out = append(out, chMove{from: king, to: king + 2, flags: chCastle})
+	}
+	if p.castle&long != 0 && p.sq[king-4] == rook && p.sq[king-1] == 0 && p.sq[king-2] == 0 && p.sq[king-3] == 0 &&
+		!p.attacked(king-1, enemy) && !p.attacked(king-2, enemy) {
+		out = append(out, chMove{from: king, to: king - 2, flags: chCastle})
+	}
+	return out
+}
+
+// legal filters the pseudo-legal moves down to those leaving the king safe.
+func (p *chPos) legal() []chMove {
+	moves := p.pseudo(false)
+	out := moves[:0]
+	for _, m := range moves {
+		u := p.make(m)
+		if !p.attacked(p.king(!p.white), p.white) { out = append(out, m) }
+		p.unmake(m, u)
+	}
+	return out
+}
+
+func (p *chPos) make(m chMove) chUndo {
+	u := chUndo{captured: p.sq[m.to], castle: p.castle, ep: p.ep, half: p.half}
+	piece := p.sq[m.from]
+	if m.flags&chEnPassant != 0 {
+		capSq := m.to - 8*p.sign()
+		u.captured = p.sq[capSq]
+		p.sq[capSq] = 0
+	}
+	p.sq[m.from] = 0
+	if m.promo != 0 { piece = m.promo * p.sign() }
+	p.sq[m.to] = piece
+	if m.flags&chCastle != 0 {
+		rookFrom, rookTo := m.to+1, m.to-1 // short
+		if m.to < m.from { rookFrom, rookTo = m.to-2, m.to+1 }
+		p.sq[rookTo], p.sq[rookFrom] = p.sq[rookFrom], 0
+	}
+	p.castle &= chCastleMask[m.from] & chCastleMask[m.to]
+	p.ep = -1
+	if m.flags&chDouble != 0 { p.ep = (m.from + m.to) / 2 }
+	p.half++
+	if chAbs(piece) == chPawn || m.promo != 0 || u.captured != 0 { p.half = 0 }
+	if !p.white { p.full++ }
+	p.white = !p.white
+	return u
+}
+
+func (p *chPos) unmake(m chMove, u chUndo) {
+	p.white = !p.white
+	if !p.white { p.full-- }
+	piece := p.sq[m.to]
+	if m.promo != 0 { piece = chPawn * p.sign() }
+	p.sq[m.from] = piece
+	p.sq[m.to] = 0
+	if m.flags&chEnPassant != 0 {
+		p.sq[m.to-8*p.sign()] = u.captured
+	} else {
+		p.sq[m.to] = u.captured
+	}
+	if m.flags&chCastle != 0 {
+		rookFrom, rookTo := m.to+1, m.to-1
+		if m.to < m.from { rookFrom, rookTo = m.to-2, m.to+1 }
+		p.sq[rookFrom], p.sq[rookTo] = p.sq[rookTo], 0
+	}
+	p.castle, p.ep, p.half = u.castle, u.ep, u.half
+}
+
+// perft counts the legal move sequences of depth plies, the standard check
+// of a move generator.
+func (p *chPos) perft(depth int) int {
+	moves := p.legal()
+	if depth <= 1 {
+		if depth == 0 { return 1 }
+		return len(moves)
+	}
+	n := 0
+	for _, m := range moves {
+		u := p.make(m)
+		n += p.perft(depth - 1)
+		p.unmake(m, u)
+	}
+	return n
+}
+
+const chPieceLetters = " pnbrqk"
+
+var ErrBadFEN = errors.New("invalid FEN")
+
+// parseFEN reads a position in Forsyth-Edwards Notation; the move counters
+// may be left out.
+func parseFEN(fen string) (chPos, error) {
+	p := chPos{ep: -1, full: 1}
+	f := strings.Fields(fen)
+	if len(f) < 4 || len(f) > 6 { return p, ErrBadFEN }
+	ranks := strings.Split(f[0], "/")
+	if len(ranks) != 8 { return p, ErrBadFEN }
+	kings := map[int8]int{}
+	for i, rank := range ranks {
+		file := 0
+		for _, c := range rank {
+			switch {
+			case c >= '1' && c <= '8':
+				file += int(c - '0')
+			case strings.ContainsRune("pnbrqkPNBRQK", c):
+				if file > 7 { return p, ErrBadFEN }
+				v := int8(strings.IndexRune(chPieceLetters, c|0x20))
+				if c >= 'a' { v = -v }
+				p.sq[(7-i)*8+file] = v
+				kings[v]++
+				file++
+			default:
+				return p, ErrBadFEN
+			}
+		}
+		if file != 8 { return p, ErrBadFEN }
+	}
+	if kings[chKing] != 1 || kings[-chKing] != 1 { return p, ErrBadFEN }
+	switch f[1] {
+	case "w":
+		p.white = true
+	case "b":
+	default:
+		return p, ErrBadFEN
+	}
+	if f[2] != "-" {
+		for _, c := range f[2] {
+			i := strings.IndexRune("KQkq", c)
+			if i < 0 { return p, ErrBadFEN }
+			p.castle |= 1 << i
+		}
+	}
+	// drop rights the pieces on the board cannot have
+	for i, sq := range [4][2]int8{{4, 7}, {4, 0}, {60, 63}, {60, 56}} {
+		sign := int8(1)
+		if i >= 2 { sign = -1 }
+		if p.sq[sq[0]] != chKing*sign || p.sq[sq[1]] != chRook*sign { p.castle &^= 1 << i }
+	}
+	if f[3] != "-" {
+		s, ok := chSquare(f[3])
+		if !ok { return p, ErrBadFEN }
+		p.ep = s
+	}
+	if len(f) > 4 {
+		n, err := strconv.Atoi(f[4])
+		if err != nil || n < 0 { return p, ErrBadFEN }
+		p.half = n
+	}
+	if len(f) > 5 {
+		n, err := strconv.Atoi(f[5])
+		if err != nil || n < 1 { return p, ErrBadFEN }
+		p.full = n
+	}
+	// the side not to move must not be in check
+	if p.attacked(p.king(!p.white), p.white) { return p, ErrBadFEN }
+	return p, nil
+}
+
+// fen writes the position in Forsyth-Edwards Notation.
+func (p *chPos) fen() string {
+	var b strings.Builder
+	for r := 7; r >= 0; r-- {
+		empty := 0
+		for f := 0; f < 8; f++ {
+			v := p.sq[r*8+f]
+			if v == 0 { empty++; continue }
+			if empty > 0 { b.WriteByte(byte('0' + empty)); empty = 0 }
+			b.WriteString(chLetter(v))
+		}
+		if empty > 0 { b.WriteByte(byte('0' + empty)) }
+		if r > 0 { b.WriteByte('/') }
+	}
+	side, castle, ep := "b", "", "-"
+	if p.white { side = "w" }
+	for i, c := range "KQkq" {
+		if p.castle&(1<<i) != 0 { castle += string(c) }
+	}
+	if castle == "" { castle = "-" }
+	if p.ep >= 0 { ep = chName(p.ep) }
+	return strings.Join([]string{b.String(), side, castle, ep, strconv.Itoa(p.half), strconv.Itoa(p.full)}, " ")
+}
+
+// chLetter is the FEN letter of a piece: upper case for white.
+func chLetter(v int8) string {
+	l := chPieceLetters[chAbs(v) : chAbs(v)+1]
+	if v > 0 { return strings.ToUpper(l) }
+	return l
+}
+
+func chName(s int8) string { return string([]byte{'a' + byte(s%8), '1' + byte(s/8)}) }
+
+func chSquare(name string) (int8, bool) {
+	if len(name) != 2 || name[0] < 'a' || name[0] > 'h' || name[1] < '1' || name[1] > '8' { return -1, false }
+	return int8(name[1]-'1')*8 + int8(name[0]-'a'), true
+}
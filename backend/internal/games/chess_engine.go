@@ -0,0 +1,149 @@
+package games
+
+import "sort"
+
+// The chess engine is a plain alpha-beta search on material and
+// piece-square tables, with a capture-only quiescence search at the horizon
+// so it does not stop in the middle of an exchange.
+const chMate = 100000
+
+var chValue = [7]int{0, 100, 320, 330, 500, 900, 0}
+
+// chTables are the piece-square bonuses from White's side, rank 8 first
+// (Michniewski's simplified evaluation function).
+var chTables = [7][64]int{
+	chPawn: {
+		0, 0, 0, 0, 0, 0, 0, 0,
+		50, 50, 50, 50, 50, 50, 50, 50,
+		10, 10, 20, 30, 30, 20, 10, 10,
+		5, 5, 10, 25, 25, 10, 5, 5,
+		0, 0, 0, 20, 20, 0, 0, 0,
+		5, -5, -10, 0, 0, -10, -5, 5,
+		5, 10, 10, -20, -20, 10, 10, 5,
+		0, 0, 0, 0, 0, 0, 0, 0,
+	},
+	chKnight: {
+		-50, -40, -30, -30, -30, -30, -40, -50,
+		-40, -20, 0, 0, 0, 0, -20, -40,
+		-30, 0, 10, 15, 15, 10, 0, -30,
+		-30, 5, 15, 20, 20, 15, 5, -30,
+		-30, 0, 15, 20, 20, 15, 0, -30,
+		-30, 5, 10, 15, 15, 10, 5, -30,
+		-40, -20, 0, 5, 5, 0, -20, -40,
+		-50, -40, -30, -30, -30, -30, -40, -50,
+	},
+	chBishop: {
+		-20, -10, -10, -10, -10, -10, -10, -20,
+		-10, 0, 0, 0, 0, 0, 0, -10,
+		-10, 0, 5, 10, 10, 5, 0, -10,
+		-10, 5, 5, 10, 10, 5, 5, -10,
+		-10, 0, 10, 10, 10, 10, 0, -10,
+		-10, 10, 10, 10, 10, 10, 10, -10,
+		-10, 5, 0, 0, 0, 0, 5, -10,
+		-20, -10, -10, -10, -10, -10, -10, -20,
+	},
+	chRook: {
+		0, 0, 0, 0, 0, 0, 0, 0,
+		5, 10, 10, 10, 10, 10, 10, 5,
+		-5, 0, 0, 0, 0, 0, 0, -5,
+		-5, 0, 0, 0, 0, 0, 0, -5,
+		-5, 0, 0, 0, 0, 0, 0, -5,
+		-5, 0, 0, 0, 0, 0, 0, -5,
+		-5, 0, 0, 0, 0, 0, 0, -5,
+		0, 0, 0, 5, 5, 0, 0, 0,
+	},
+	chQueen: {
+		-20, -10, -10, -5, -5, -10, -10, -20,
+		-10, 0, 0, 0, 0, 0, 0, -10,
+		-10, 0, 5, 5, 5, 5, 0, -10,
+		-5, 0, 5, 5, 5, 5, 0, -5,
+		0, 0, 5, 5, 5, 5, 0, -5,
+		-10, 5, 5, 5, 5, 5, 0, -10,
+		-10, 0, 5, 0, 0, 0, 0, -10,
+		-20, -10, -10, -5, -5, -10, -10, -20,
+	},
+	chKing: {
+		-30, -40, -40, -50, -50, -40, -40, -30,
+		-30, -40, -40, -50, -50, -40, -40, -30,
+		-30, -40, -40, -50, -50, -40, -40, -30,
+		-30, -40, -40, -50, -50, -40, -40, -30,
+		-20, -30, -30, -40, -40, -30, -30, -20,
+		-10, -20, -20, -20, -20, -20, -20, -10,
+		20, 20, 0, 0, 0, 0, 20, 20,
+		20, 30, 10, 0, 0, 10, 30, 20,
+	},
+}
+
+// evaluate scores the position for the side to move.
+func (p *chPos) evaluate() int {
+	score := 0
+	for s, v := range p.sq {
+		if v == 0 { continue }
+		if v > 0 {
+			score += chValue[v] + chTables[v][(7-s/8)*8+s%8]
+		} else {
+			score -= chValue[-v] + chTables[-v][s]
+		}
+	}
+	if !p.white { return -score }
+	return score
+}
+
+// order puts promotions and captures first, the most valuable victim
+// taken by the least valuable attacker leading.
+func (p *chPos) order(moves []chMove) {
+	rank := func(m chMove) int {
+		r := chValue[m.promo]
+		if m.flags&chCapture != 0 { r += 10*chValue[chAbs(p.sq[m.to])] - chValue[chAbs(p.sq[m.from])] + 1000 }
+		return r
+	}
+	sort.SliceStable(moves, func(i, j int) bool { return rank(moves[i]) > rank(moves[j]) })
+}
+
+// negamax scores the position for the side to move; mates score more the
+// sooner they come.
+func (p *chPos) negamax(depth, ply, alpha, beta int) int {
+	if depth <= 0 { return p.quiesce(alpha, beta) }
+	moves := p.pseudo(false)
+	p.order(moves)
+	legal := false
+	for _, m := range moves {
+		u := p.make(m)
+		if p.attacked(p.king(!p.white), p.white) {
+			p.unmake(m, u)
+			continue
+		}
+		legal = true
+		score := -p.negamax(depth-1, ply+1, -beta, -alpha)
+		p.unmake(m, u)
+		if score > alpha { alpha = score }
+		if alpha >= beta { return alpha }
+	}
+	if !legal {
+		if p.inCheck() { return -chMate + ply }
+		return 0
+	}
+	return alpha
+}
+
+// quiesce only follows captures and promotions, standing pat on the static
+// evaluation.
+func (p *chPos) quiesce(alpha, beta int) int {
+	stand := p.evaluate()
+	if stand >= beta { return stand }
+	if stand > alpha { alpha = stand }
+	moves := p.pseudo(true)
+	p.order(moves)
+	for _, m := range moves {
+		u := p.make(m)
+		if p.attacked(p.king(!p.white), p.white) {
+			p.unmake(m, u)
+			continue
+		}
+		score := -p.quiesce(-beta, -alpha)
+		p.unmake(m, u)
+		if score > alpha { alpha = score }
+		if alpha >= beta { break }
+	}
+	return alpha
+}
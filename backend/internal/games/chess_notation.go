@@ -0,0 +1,80 @@
+package games
+
+import (
+	"strings"
+)
+
+// uci writes a move in UCI long algebraic notation: "e2e4", "e7e8q", castling
+// as the king's move ("e1g1").
+func (m chMove) uci() string {
+	s := chName(m.from) + chName(m.to)
+	if m.promo != 0 { s += chPieceLetters[m.promo : m.promo+1] }
+	return s
+}
+
+// san writes m, legal in p, in Standard Algebraic Notation: "Nf3", "exd5",
+// "Rad1", "e8=Q+", "O-O-O#". The file, rank or both of the moving piece are
+// added when another piece of the same kind could go to the same square.
+func (p *chPos) san(m chMove, legal []chMove) string {
+	var b strings.Builder
+	piece := chAbs(p.sq[m.from])
+	switch {
+	case m.flags&chCastle != 0:
+		b.WriteString("O-O")
+		if m.to < m.from { b.WriteString("-O") }
+	case piece == chPawn:
+		if m.flags&chCapture != 0 { b.WriteString(chName(m.from)[:1] + "x") }
+		b.WriteString(chName(m.to))
+		if m.promo != 0 { b.WriteString("=" + chLetter(m.promo)) }
+	default:
+		b.WriteString(chLetter(piece))
+		sameFile, sameRank, other := false, false, false
+		for _, o := range legal {
+			if o.to != m.to || o.from == m.from || chAbs(p.sq[o.from]) != piece { continue }
+			other = true
+			sameFile = sameFile || o.from%8 == m.from%8
+			sameRank = sameRank || o.from/8 == m.from/8
+		}
+		from := chName(m.from)
+		switch {
+		case other && !sameFile:
+			b.WriteString(from[:1])
+		case other && !sameRank:
+			b.WriteString(from[1:])
+		case other:
+			b.WriteString(from)
+		}
+		if m.flags&chCapture != 0 { b.WriteString("x") }
+		b.WriteString(chName(m.to))
+	}
+	u := p.make(m)
+	if p.inCheck() {
+		if len(p.legal()) == 0 { b.WriteString("#") } else { b.WriteString("+") }
+	}
+	p.unmake(m, u)
+	return b.String()
+}
+
+// chLoose reduces a SAN move to what must match, so "Nxf3+", "Nf3" and
+// "Nf3!?" agree, as do "e8=Q", "e8Q" and "0-0" with "O-O".
+func chLoose(s string) string {
+	s = strings.TrimRight(strings.TrimSpace(s), "+#!?")
+	return strings.NewReplacer("x", "", "=", "", ":", "", "0", "O").Replace(s)
+}
+
+// parse finds the legal move written in UCI, in long algebraic notation
+// ("e2-e4", "Ng1xf3", "e7e8=Q") or in SAN.
+func (p *chPos) parse(s string, legal []chMove) (chMove, bool) {
+	s = strings.TrimRight(strings.TrimSpace(s), "+#!?")
+	long, piece := strings.NewReplacer("-", "", "x", "", "=", "").Replace(s), ""
+	if long != "" && strings.ContainsRune("NBRQK", rune(long[0])) { piece, long = long[:1], long[1:] }
+	for _, m := range legal {
+		if m.uci() == strings.ToLower(long) && (piece == "" || chLetter(chAbs(p.sq[m.from])) == piece) { return m, true }
+	}
+	want := chLoose(s)
+	if want == "" { return chMove{}, false }
+	for _, m := range legal {
+		if chLoose(p.san(m, legal)) == want { return m, true }
+	}
+	return chMove{}, false
+}
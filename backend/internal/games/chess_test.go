@@ -0,0 +1,249 @@
+package games
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestChessPerft(t *testing.T) {
+	// published perft figures (chessprogramming.org) covering castling, en
+	// passant, promotions and discovered checks
+	cases := []struct {
+		name, fen string
+		want      []int
+	}{
+		{"initial", chStartFEN, []int{20, 400, 8902, 197281}},
+		{"kiwipete", "r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1", []int{48, 2039, 97862}},
+		{"position 3", "8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - - 0 1", []int{14, 191, 2812, 43238}},
+		{"position 4", "r3k2r/Pppp1ppp/1b3nbN/nP6/BBP1P3/q4N2/Pp1P2PP/R2Q1RK1 w kq - 0 1", []int{6, 264, 9467}},
+		{"position 5", "rnbq1k1r/pp1Pbppp/2p5/8/2B5/8/PPP1NnPP/RNBQK2R w KQ - 1 8", []int{44, 1486, 62379}},
+	}
+	for _, c := range cases {
+		p, err := parseFEN(c.fen)
+		if err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+		before := p
+		for i, n := range c.want {
+			if got := p.perft(i + 1); got != n {
+				t.Fatalf("%s: perft(%d) = %d, want %d", c.name, i+1, got, n)
+			}
+		}
+		if p != before || p.fen() != c.fen {
+			t.Fatalf("%s: perft must leave the position unchanged, got %s", c.name, p.fen())
+		}
+	}
+}
+
+func TestChessFEN(t *testing.T) {
+	for _, fen := range []string{
+		"",
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP w KQkq - 0 1", // seven ranks
+		"rnbqkbnr/pppppppp/9/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1",
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQ1BNR w kq - 0 1", // no white king
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR x KQkq - 0 1",
+		"4k3/8/8/8/8/8/8/4R1K1 w - - 0 1", // the side not to move is in check
+	} {
+		if _, err := parseFEN(fen); !errors.Is(err, ErrBadFEN) {
+			t.Fatalf("%q should be rejected, got %v", fen, err)
+		}
+	}
+	g, _ := NewChess(false, "")
+	g.Move("e4")
+	if g.FEN != "rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1" {
+		t.Fatalf("unexpected FEN %s", g.FEN)
+	}
+	if g.Board[4][4] != "P" || g.Board[6][4] != "" || g.Board[0][4] != "k" {
+		t.Fatalf("board should have rank 8 first: %v", g.Board)
+	}
+	// rights the pieces cannot have are dropped
+	if g.SetPosition("4k3/8/8/8/8/8/8/4K3 w KQkq - 0 1"); !strings.Contains(g.FEN, " w - ") {
+		t.Fatalf("castling rights without rooks kept: %s", g.FEN)
+	}
+}
+
+func TestChessNotation(t *testing.T) {
+	g, _ := NewChess(false, "")
+	// the same game in UCI, SAN and loosely written SAN
+	for _, m := range []string{"e2e4", "e5", "Ng1f3", "Nc6", "Bb5", "a6", "Bxc6", "dxc6", "0-0", "Bg4", "h3", "h5", "hxg4", "hxg4", "Nh2", "Qh4", "Nxg4!?", "Qh1#"} {
+		if err := g.Move(m); err != nil {
+			t.Fatalf("%s: %v", m, err)
+		}
+	}
+	var san []string
+	for _, m := range g.Moves {
+		san = append(san, m.SAN)
+	}
+	want := "e4 e5 Nf3 Nc6 Bb5 a6 Bxc6 dxc6 O-O Bg4 h3 h5 hxg4 hxg4 Nh2 Qh4 Nxg4 Qh1#"
+	if got := strings.Join(san, " "); got != want {
+		t.Fatalf("SAN\n got %s\nwant %s", got, want)
+	}
+	if g.Winner != "B" || !g.Check || len(g.Legal) != 0 {
+		t.Fatalf("expected mate by Black, got winner %q", g.Winner)
+	}
+	if err := g.Move("Kxh1"); !errors.Is(err, ErrGameOver) {
+		t.Fatalf("finished games take no moves, got %v", err)
+	}
+
+	// disambiguation by file, rank and both; promotion and en passant
+	g.SetPosition("4k3/1P6/8/3pP1N1/8/Q7/3N4/Q1Q1K1N1 w - d6 0 1")
+	sans := map[string]string{}
+	for _, m := range g.Legal {
+		sans[m.UCI] = m.SAN
+	}
+	for uci, want := range map[string]string{
+		"g1f3": "N1f3", "g5f3": "N5f3", "d2f3": "Ndf3", "a1b2": "Qa1b2", "a3b2": "Q3b2", "c1b2": "Qcb2",
+		"e5d6": "exd6", "b7b8q": "b8=Q+", "b7b8n": "b8=N", "e1e2": "Ke2",
+	} {
+		if sans[uci] != want {
+			t.Fatalf("%s: SAN %q, want %q", uci, sans[uci], want)
+		}
+	}
+	for _, bad := range []string{"Nf3", "Qb2", "Ng2f3", "e5e6e7", "", "exd6ep"} {
+		if err := g.Move(bad); !errors.Is(err, ErrIllegalMove) {
+			t.Fatalf("%q should be refused, got %v", bad, err)
+		}
+	}
+	if err := g.Move("exd6"); err != nil || g.Board[3][3] != "" || g.Board[2][3] != "P" {
+		t.Fatalf("en passant failed: %v %v", err, g.Board)
+	}
+	g.Undo()
+	if err := g.Move("b8N"); err != nil || g.Board[0][1] != "N" {
+		t.Fatalf("under-promotion failed: %v %v", err, g.Board)
+	}
+}
+
+func TestChessCastling(t *testing.T) {
+	g, _ := NewChess(false, "")
+	g.SetPosition("r3k2r/8/8/8/8/8/8/R3K2R w KQkq - 0 1")
+	g.Move("O-O")
+	if g.Board[7][6] != "K" || g.Board[7][5] != "R" || !strings.Contains(g.FEN, " kq ") {
+		t.Fatalf("castling failed: %s", g.FEN)
+	}
+	// a rook on f8 covers f1, so only O-O-O
+	g.SetPosition("1k3r2/8/8/8/8/8/8/R3K2R w KQ - 0 1")
+	for _, m := range g.Legal {
+		if m.SAN == "O-O" {
+			t.Fatal("castling through an attacked square")
+		}
+	}
+	if err := g.Move("O-O-O"); err != nil || g.Board[7][2] != "K" || g.Board[7][3] != "R" {
+		t.Fatalf("long castling failed: %v %s", err, g.FEN)
+	}
+	// a moved rook loses its side's right
+	g.SetPosition("r3k2r/8/8/8/8/8/8/R3K2R w KQkq - 0 1")
+	g.Move("Rb1")
+	if !strings.Contains(g.FEN, " Kkq ") {
+		t.Fatalf("rook move should drop Q: %s", g.FEN)
+	}
+}
+
+func TestChessDraws(t *testing.T) {
+	g, _ := NewChess(false, "")
+	for i := 0; i < 2; i++ {
+		for _, m := range []string{"Nf3", "Nf6", "Ng1", "Ng8"} {
+			if err := g.Move(m); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if g.Winner != "D" || g.DrawReason != "repetition" {
+		t.Fatalf("the initial position three times is a draw, got %q %q", g.Winner, g.DrawReason)
+	}
+	if !g.Undo() || g.Winner != "" || len(g.Legal) == 0 {
+		t.Fatal("undo should reopen the game")
+	}
+
+	g.SetPosition("7k/8/6K1/8/8/8/8/R7 w - - 99 80")
+	g.Move("Ra2")
+	if g.Winner != "D" || g.DrawReason != "50 moves" {
+		t.Fatalf("expected the 50-move rule, got %q %q", g.Winner, g.DrawReason)
+	}
+	g.SetPosition("7k/8/6K1/8/8/8/8/R7 w - - 99 80")
+	if g.Move("Ra8#"); g.Winner != "W" {
+		t.Fatalf("mate on the 100th ply still wins, got %q %q", g.Winner, g.DrawReason)
+	}
+
+	g.SetPosition("7k/5Q2/6K1/8/8/8/8/8 b - - 0 1")
+	if g.Winner != "D" || g.DrawReason != "stalemate" {
+		t.Fatalf("expected stalemate, got %q %q", g.Winner, g.DrawReason)
+	}
+	g.SetPosition("7k/8/8/8/8/8/8/r5BK b - - 0 1")
+	g.Move("Rxg1+")
+	g.Move("Kxg1")
+	if g.Winner != "D" || g.DrawReason != "insufficient material" {
+		t.Fatalf("expected insufficient material, got %q %q", g.Winner, g.DrawReason)
+	}
+}
+
+func TestChessPGN(t *testing.T) {
+	g, _ := NewChess(false, "")
+	for _, m := range []string{"f3", "e5", "g4", "Qh4#"} {
+		g.Move(m)
+	}
+	pgn := g.PGN("alice", "")
+	for _, want := range []string{`[White "alice"]`, `[Black "?"]`, `[Result "0-1"]`, "\n\n1. f3 e5 2. g4 Qh4# 0-1\n"} {
+		if !strings.Contains(pgn, want) {
+			t.Fatalf("PGN misses %q:\n%s", want, pgn)
+		}
+	}
+	if strings.Contains(pgn, "FEN") {
+		t.Fatal("the initial position needs no FEN tag")
+	}
+
+	g.SetPosition("4k3/8/8/8/8/8/8/4K2R b K - 0 30")
+	g.Move("Kd7")
+	g.Move("O-O")
+	pgn = g.PGN("", "")
+	for _, want := range []string{`[SetUp "1"]`, `[FEN "4k3/8/8/8/8/8/8/4K2R b K - 0 30"]`, "30... Kd7 31. O-O *"} {
+		if !strings.Contains(pgn, want) {
+			t.Fatalf("PGN misses %q:\n%s", want, pgn)
+		}
+	}
+}
+
+func TestChessAI(t *testing.T) {
+	if _, err := NewChess(true, "grandmaster"); err != ErrBadChessLevel {
+		t.Fatalf("expected bad level, got %v", err)
+	}
+	g, _ := NewChess(true, "easy")
+	if err := g.PlayAs("B"); err != nil || len(g.Moves) != 1 || g.CurrentPlayer != "B" {
+		t.Fatalf("an AI playing White opens: %v %+v", err, g.Moves)
+	}
+	if g.Undo() {
+		t.Fatal("the AI opening alone cannot be taken back")
+	}
+	g.Move(g.Legal[0].UCI)
+	if len(g.Moves) != 3 || !g.Undo() || len(g.Moves) != 1 {
+		t.Fatalf("undo should take back the AI reply too: %+v", g.Moves)
+	}
+
+	// every level takes a hanging queen; medium and hard find mate in one
+	for _, level := range []string{"easy", "medium", "hard"} {
+		g, _ := NewChess(false, "")
+		g.SetPosition("4k3/8/8/3q4/8/8/8/3RK3 w - - 0 1")
+		if m := g.BestMove(level); m != "d1d5" {
+			t.Fatalf("%s should take the queen, got %s", level, m)
+		}
+		if level == "easy" {
+			continue
+		}
+		g.SetPosition("6k1/5ppp/8/8/8/8/8/R5K1 w - - 0 1")
+		if m := g.BestMove(level); m != "a1a8" {
+			t.Fatalf("%s should mate on a8, got %s", level, m)
+		}
+	}
+	// hard mates in two whatever Black does
+	g, _ = NewChess(false, "")
+	g.SetPosition("k7/8/1K6/8/8/8/8/1Q6 w - - 0 1")
+	for i := 0; i < 2 && g.Winner == ""; i++ {
+		g.Move(g.BestMove("hard"))
+		if g.Winner == "" {
+			g.Move(g.Legal[0].UCI)
+		}
+	}
+	if g.Winner != "W" {
+		t.Fatalf("hard should mate in two, got %+v", g.Moves)
+	}
+}
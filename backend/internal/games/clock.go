@@ -0,0 +1,171 @@
+package games
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+)
+
+// What happens to a seat whose time runs out.
+const (
+	TimeoutLoss   = "loss"   // the seat loses the game
+	TimeoutRandom = "random" // a random legal move is played for it
+	TimeoutAI     = "ai"     // the AI plays a move for it
+)
+
+// TimeControl configures a game clock, in seconds. PerMove limits each move,
+// Bank is the total time per seat topped up by Increment after every move.
+// Zero disables a limit.
+type TimeControl struct {
+	PerMove   int    `json:"perMove,omitempty"`
+	Bank      int    `json:"bank,omitempty"`
+	Increment int    `json:"increment,omitempty"`
+	OnTimeout string `json:"onTimeout"`
+}
+
+var ErrBadTimeControl = errors.New("time control needs a per-move limit or a bank, and onTimeout loss, random or ai")
+
+// Validate fills in the default timeout policy (loss) and rejects unusable
+// settings.
+func (tc *TimeControl) Validate() error {
+	if tc.OnTimeout == "" { tc.OnTimeout = TimeoutLoss }
+	switch tc.OnTimeout {
+	case TimeoutLoss, TimeoutRandom, TimeoutAI:
+	default:
+		return ErrBadTimeControl
+	}
+	if tc.PerMove < 0 || tc.Bank < 0 || tc.Increment < 0 || (tc.PerMove == 0 && tc.Bank == 0) { return ErrBadTimeControl }
+	return nil
+}
+
+// Clock keeps the remaining time of every seat. Seats that are expected to
+// move are running; several can run at once for simultaneous games (RPS).
+// The caller passes the current time so the clock itself stays deterministic.
+type Clock struct {
+	Control   TimeControl
+	remaining map[string]time.Duration // bank left, only meaningful with a bank
+	running   map[string]time.Time     // seat -> when its move started
+	Flagged   string                   // seat that ran out of time
+}
+
+// NewClock creates a stopped clock with a full bank for every seat.
+func NewClock(tc TimeControl, seats ...string) *Clock {
+	c := &Clock{Control: tc, remaining: map[string]time.Duration{}, running: map[string]time.Time{}}
+	for _, s := range seats { c.remaining[s] = time.Duration(tc.Bank) * time.Second }
+	return c
+}
+
+// Reset refills every bank and stops the clock.
+func (c *Clock) Reset() {
+	for s := range c.remaining { c.remaining[s] = time.Duration(c.Control.Bank) * time.Second }
+	c.running = map[string]time.Time{}
+	c.Flagged = ""
+}
+
+// Wait starts the clocks of seats that now have to move and are not running yet.
+func (c *Clock) Wait(now time.Time, seats ...string) {
+	for _, s := range seats {
+		if _, ok := c.running[s]; !ok { c.running[s] = now }
+	}
+}
+
+// Moved stops seat's clock, charging the time used and adding the increment.
+func (c *Clock) Moved(seat string, now time.Time) {
+	start, ok := c.running[seat]
+	if !ok { return }
+	delete(c.running, seat)
+	if c.Control.Bank > 0 { c.remaining[seat] += time.Duration(c.Control.Increment)*time.Second - now.Sub(start) }
+}
+
+// Stop freezes every clock, e.g. when the game is over.
+func (c *Clock) Stop(now time.Time) {
+	for s, start := range c.running {
+		if c.Control.Bank > 0 { c.remaining[s] -= now.Sub(start) }
+	}
+	c.running = map[string]time.Time{}
+}
+
+// deadline is when seat's current move runs out of time.
+func (c *Clock) deadline(seat string) time.Time {
+	start := c.running[seat]
+	var d time.Time
+	if c.Control.Bank > 0 { d = start.Add(c.remaining[seat]) }
+	if c.Control.PerMove > 0 {
+		if m := start.Add(time.Duration(c.Control.PerMove) * time.Second); d.IsZero() || m.Before(d) { d = m }
+	}
+	return d
+}
+
+// Deadline is the earliest moment a running seat runs out of time.
+func (c *Clock) Deadline() (time.Time, bool) {
+	var first time.Time
+	for s := range c.running {
+		if d := c.deadline(s); first.IsZero() || d.Before(first) { first = d }
+	}
+	return first, !first.IsZero()
+}
+
+// Expired returns a running seat that is out of time at now, if any.
+func (c *Clock) Expired(now time.Time) (string, bool) {
+	for _, s := range sortedSeats(c.running) {
+		if !now.Before(c.deadline(s)) { return s, true }
+	}
+	return "", false
+}
+
+// OutOfBank reports whether seat has used up its whole bank, as opposed to
+// only running over the per-move limit.
+func (c *Clock) OutOfBank(seat string, now time.Time) bool {
+	start, ok := c.running[seat]
+	return ok && c.Control.Bank > 0 && c.remaining[seat] <= now.Sub(start)
+}
+
+// Flag records that seat ran out of time and stops the clock.
+func (c *Clock) Flag(seat string, now time.Time) {
+	c.Stop(now)
+	if c.remaining[seat] < 0 { c.remaining[seat] = 0 }
+	c.Flagged = seat
+}
+
+// clockNow is the time used when a clock is rendered; tests may replace it.
+var clockNow = time.Now
+
+// MarshalJSON renders the clock as of now so clients can draw countdowns:
+// remaining bank per seat (when there is a bank), the seats whose clock is
+// running and the time left for the current move.
+func (c *Clock) MarshalJSON() ([]byte, error) {
+	now := clockNow()
+	out := struct {
+		Control     TimeControl      `json:"control"`
+		RemainingMs map[string]int64 `json:"remainingMs,omitempty"`
+		Running     []string         `json:"running"`
+		MoveLeftMs  map[string]int64 `json:"moveLeftMs,omitempty"`
+		Flagged     string           `json:"flagged,omitempty"`
+	}{Control: c.Control, Running: []string{}, Flagged: c.Flagged}
+	if c.Control.Bank > 0 {
+		out.RemainingMs = map[string]int64{}
+		for s, r := range c.remaining {
+			if start, ok := c.running[s]; ok { r -= now.Sub(start) }
+			out.RemainingMs[s] = msLeft(r)
+		}
+	}
+	if len(c.running) > 0 { out.MoveLeftMs = map[string]int64{} }
+	for _, s := range sortedSeats(c.running) {
+		out.Running = append(out.Running, s)
+		out.MoveLeftMs[s] = msLeft(c.deadline(s).Sub(now))
+	}
+	return json.Marshal(out)
+}
+
+func sortedSeats(m map[string]time.Time) []string {
+	var out []string
+	for s := range m { out = append(out, s) }
+	sort.Strings(out)
+	return out
+}
+
+func msLeft(d time.Duration) int64 {
+	if d < 0 { return 0 }
+	return d.Milliseconds()
+}
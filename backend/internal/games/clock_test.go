@@ -0,0 +1,70 @@
+package games
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestClockBankAndIncrement(t *testing.T) {
+	t0 := time.Unix(0, 0)
+	c := NewClock(TimeControl{Bank: 60, Increment: 5}, "X", "O")
+	c.Wait(t0, "X")
+	c.Moved("X", t0.Add(20*time.Second)) // 60 - 20 + 5
+	c.Wait(t0.Add(20*time.Second), "O")
+	if d, ok := c.Deadline(); !ok || !d.Equal(t0.Add(80*time.Second)) {
+		t.Fatalf("expected O to run out at 80s, got %v %v", d, ok)
+	}
+	c.Moved("O", t0.Add(30*time.Second))
+	c.Wait(t0.Add(30*time.Second), "X")
+	if _, ok := c.Expired(t0.Add(74 * time.Second)); ok {
+		t.Fatal("X still has 45s of bank")
+	}
+	if s, ok := c.Expired(t0.Add(75 * time.Second)); !ok || s != "X" || !c.OutOfBank("X", t0.Add(75*time.Second)) {
+		t.Fatalf("expected X out of bank, got %q %v", s, ok)
+	}
+}
+
+func TestClockPerMoveLimitAndRendering(t *testing.T) {
+	t0 := time.Unix(0, 0)
+	c := NewClock(TimeControl{PerMove: 10, Bank: 100}, "p1", "p2")
+	c.Wait(t0, "p1", "p2")
+	if d, _ := c.Deadline(); !d.Equal(t0.Add(10 * time.Second)) {
+		t.Fatalf("per-move limit should come first, got %v", d)
+	}
+	if s, ok := c.Expired(t0.Add(10 * time.Second)); !ok || c.OutOfBank(s, t0.Add(10*time.Second)) {
+		t.Fatalf("expected a per-move timeout, got %q %v", s, ok)
+	}
+	clockNow = func() time.Time { return t0.Add(4 * time.Second) }
+	defer func() { clockNow = time.Now }()
+	c.Moved("p1", t0.Add(4*time.Second))
+	var out struct {
+		RemainingMs map[string]int64 `json:"remainingMs"`
+		Running     []string         `json:"running"`
+		MoveLeftMs  map[string]int64 `json:"moveLeftMs"`
+	}
+	b, _ := json.Marshal(c)
+	json.Unmarshal(b, &out)
+	if out.RemainingMs["p1"] != 96000 || out.RemainingMs["p2"] != 96000 || len(out.Running) != 1 || out.MoveLeftMs["p2"] != 6000 {
+		t.Fatalf("unexpected rendering %s", b)
+	}
+}
+
+func TestTimeoutEndsGames(t *testing.T) {
+	g := NewTicTacToe(false, "")
+	g.MakeMove(0)
+	g.Timeout("O")
+	if g.Winner != "X" || g.TimedOut != "O" || g.MakeMove(1) {
+		t.Fatalf("expected O to lose on time, got %+v", g)
+	}
+	r := NewRPSVersus(3)
+	r.PlaySeat("p2", "rock")
+	r.Timeout("p1")
+	if !r.Finished || r.Winner != "p2" || r.PlaySeat("p1", "rock") {
+		t.Fatalf("expected p1 to lose on time, got %+v", r)
+	}
+	h := NewTicTacToe(true, "optimal")
+	if !h.AutoMove(false) || len(h.Moves) != 2 {
+		t.Fatalf("auto move should play for X and let the AI answer, got %+v", h.Moves)
+	}
+}
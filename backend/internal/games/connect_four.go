@@ -0,0 +1,235 @@
+package games
+
+import (
+	"log"
+	"math/rand"
+)
+
+const (
+	c4Rows = 6
+	c4Cols = 7
+)
+
+// ConnectFour is the classic 7x6 drop game. Board[0] is the top row; pieces
+// fall to the lowest empty row of a column. Players are "R" (moves first)
+// and "Y"; Winner is "R", "Y", "D" for a draw, or "" while ongoing.
+// With VsAI the human plays R and the AI answers as Y.
+type ConnectFour struct {
+	Board         [c4Rows][c4Cols]string `json:"board"`
+	CurrentPlayer string                 `json:"currentPlayer"`
+	Winner        string                 `json:"winner"`
+	WinningCells  [][2]int               `json:"winningCells"` // [row, col] of the winning four
+	Moves         []C4Move               `json:"moves"`
+	VsAI          bool                   `json:"vsAI"`
+	Difficulty    string                 `json:"difficulty"` // easy, medium or hard (search depth)
+	Series        *Series                `json:"series,omitempty"`
+	Spectators    int                    `json:"spectators"`
+	Quiet         bool                   `json:"-"` // no move logs, for bot-vs-bot runs
+}
+
+// C4Move is one dropped piece.
+type C4Move struct {
+	Col    int    `json:"col"`
+	Row    int    `json:"row"`
+	Player string `json:"player"`
+}
+
+// c4Depth is the alpha-beta search depth per difficulty.
+var c4Depth = map[string]int{"easy": 2, "medium": 4, "hard": 7}
+
+func NewConnectFour(vsAI bool, difficulty string) *ConnectFour {
+	if _, ok := c4Depth[difficulty]; !ok { difficulty = "medium" }
+	g := &ConnectFour{CurrentPlayer: "R", VsAI: vsAI, Difficulty: difficulty, WinningCells: [][2]int{}, Moves: []C4Move{}}
+	log.Printf("[C4] New game created vsAI=%v difficulty=%s", vsAI, difficulty)
+	return g
+}
+
+// logf logs unless the game is quiet.
+func (g *ConnectFour) logf(format string, args ...any) { if !g.Quiet { log.Printf(format, args...) } }
+
+// Reset clears the board keeping mode and difficulty.
+func (g *ConnectFour) Reset() {
+	g.Board = [c4Rows][c4Cols]string{}
+	g.CurrentPlayer, g.Winner = "R", ""
+	g.WinningCells, g.Moves = [][2]int{}, []C4Move{}
+}
+
+// dropRow returns the row a piece dropped in col lands on, or -1 when the
+// column is full.
+func (g *ConnectFour) dropRow(col int) int {
+	for r := c4Rows - 1; r >= 0; r-- {
+		if g.Board[r][col] == "" { return r }
+	}
+	return -1
+}
+
+// Legal lists the columns that still take a piece while the game is on.
+func (g *ConnectFour) Legal() []int {
+	if g.Winner != "" { return nil }
+	var cols []int
+	for c := 0; c < c4Cols; c++ { if g.Board[0][c] == "" { cols = append(cols, c) } }
+	return cols
+}
+
+// Drop plays col for the current player and lets the AI answer when it is
+// its turn. It returns false for full or out of range columns and finished games.
+func (g *ConnectFour) Drop(col int) bool {
+	if !g.play(col) { return false }
+	if g.VsAI && g.CurrentPlayer == "Y" && g.Winner == "" { g.aiMove() }
+	if g.Winner != "" { g.logf("[C4] Game over, winner %s after %d moves", g.Winner, len(g.Moves)) }
+	return true
+}
+
+// play drops a piece without AI reply or logging; the search uses it too.
+func (g *ConnectFour) play(col int) bool {
+	if g.Winner != "" || col < 0 || col >= c4Cols { return false }
+	row := g.dropRow(col)
+	if row < 0 { return false }
+	g.Board[row][col] = g.CurrentPlayer
+	g.Moves = append(g.Moves, C4Move{Col: col, Row: row, Player: g.CurrentPlayer})
+	if cells := g.fourAt(row, col); cells != nil {
+		g.Winner, g.WinningCells = g.CurrentPlayer, cells
+		return true
+	}
+	if len(g.Moves) == c4Rows*c4Cols {
+		g.Winner = "D"
+		return true
+	}
+	g.CurrentPlayer = c4Other(g.CurrentPlayer)
+	return true
+}
+
+func c4Other(p string) string { if p == "R" { return "Y" }; return "R" }
+
+// c4Dirs are the four line directions: horizontal, vertical and both diagonals.
+var c4Dirs = [4][2]int{{0, 1}, {1, 0}, {1, 1}, {1, -1}}
+
+// fourAt returns the cells of a line of at least four through (row, col)
+// in that piece's colour, or nil.
+func (g *ConnectFour) fourAt(row, col int) [][2]int {
+	p := g.Board[row][col]
+	for _, d := range c4Dirs {
+		cells := [][2]int{{row, col}}
+		for _, sign := range []int{1, -1} {
+			r, c := row+sign*d[0], col+sign*d[1]
+			for r >= 0 && r < c4Rows && c >= 0 && c < c4Cols && g.Board[r][c] == p {
+				cells = append(cells, [2]int{r, c})
+				r, c = r+sign*d[0], c+sign*d[1]
+			}
+		}
+		if len(cells) >= 4 { return cells }
+	}
+	return nil
+}
+
+// Undo takes back the last move; against the AI it also takes back the
+// human move before an AI reply so it is the human's turn again.
+func (g *ConnectFour) Undo() bool {
+	if len(g.Moves) == 0 { return false }
+	g.undoOne()
+	if g.VsAI && g.CurrentPlayer == "Y" && len(g.Moves) > 0 { g.undoOne() }
+	return true
+}
+
+func (g *ConnectFour) undoOne() {
+	last := g.Moves[len(g.Moves)-1]
+	g.Board[last.Row][last.Col] = ""
+	g.Moves = g.Moves[:len(g.Moves)-1]
+	g.Winner, g.WinningCells = "", [][2]int{}
+	g.CurrentPlayer = last.Player
+}
+
+// c4Order searches center columns first, which makes alpha-beta cut off far sooner.
+var c4Order = [c4Cols]int{3, 2, 4, 1, 5, 0, 6}
+
+const c4Win = 1000000
+
+func (g *ConnectFour) aiMove() {
+	col := g.BestColumn(c4Depth[g.Difficulty])
+	g.logf("[C4][AI] depth=%d chose column %d", c4Depth[g.Difficulty], col)
+	g.play(col)
+}
+
+// SuggestColumn returns the column the AI would play for the side to move
+// at difficulty, or -1 when the game is over or the difficulty is unknown.
+func (g *ConnectFour) SuggestColumn(difficulty string) int {
+	depth, ok := c4Depth[difficulty]
+	if !ok { return -1 }
+	return g.BestColumn(depth)
+}
+
+// BestColumn searches depth plies ahead for the side to move and returns
+// its best column (-1 when the game is over). Ties between equally good
+// columns are broken at random so easy games vary.
+func (g *ConnectFour) BestColumn(depth int) int {
+	if g.Winner != "" { return -1 }
+	s := *g // search on a copy; only the board and move list change
+	s.Moves = append([]C4Move(nil), g.Moves...)
+	s.VsAI = false
+	best, bestScore := -1, -c4Win*2
+	var ties []int
+	for _, col := range c4Order {
+		if !s.play(col) { continue }
+		score := -s.negamax(depth-1, -c4Win*2, c4Win*2)
+		s.undoOne()
+		switch {
+		case score > bestScore:
+			best, bestScore, ties = col, score, []int{col}
+		case score == bestScore:
+			ties = append(ties, col)
+		}
+	}
+	if len(ties) > 1 { best = ties[rand.Intn(len(ties))] }
+	return best
+}
+
+// negamax scores the position for the side to move. Wins found sooner (and
+// losses found later) score higher in absolute terms.
+func (g *ConnectFour) negamax(depth, alpha, beta int) int {
+	if g.Winner != "" {
+		if g.Winner == "D" { return 0 }
+		// the previous mover won, which is bad for the side to move
+		return -(c4Win + depth)
+	}
+	if depth <= 0 { return g.evaluate(g.CurrentPlayer) }
+	for _, col := range c4Order {
+		if !g.play(col) { continue }
+		score := -g.negamax(depth-1, -beta, -alpha)
+		g.undoOne()
+		if score > alpha { alpha = score }
+		if alpha >= beta { break }
+	}
+	return alpha
+}
+
+// evaluate scores every window of four cells for p: windows shared with the
+// opponent are dead, open windows count more the fuller they are, and
+// center column pieces get a small bonus.
+func (g *ConnectFour) evaluate(p string) int {
+	o := c4Other(p)
+	score := 0
+	for r := 0; r < c4Rows; r++ {
+		if g.Board[r][3] == p { score += 3 } else if g.Board[r][3] == o { score -= 3 }
+	}
+	weights := [4]int{0, 1, 5, 50}
+	for r := 0; r < c4Rows; r++ {
+		for c := 0; c < c4Cols; c++ {
+			for _, d := range c4Dirs {
+				er, ec := r+3*d[0], c+3*d[1]
+				if er < 0 || er >= c4Rows || ec < 0 || ec >= c4Cols { continue }
+				mine, theirs := 0, 0
+				for i := 0; i < 4; i++ {
+					switch g.Board[r+i*d[0]][c+i*d[1]] {
+					case p:
+						mine++
+					case o:
+						theirs++
+					}
+				}
+				if theirs == 0 && mine < 4 { score += weights[mine] }
+				if mine == 0 && theirs < 4 { score -= weights[theirs] }
+			}
+		}
+	}
+	return score
+}
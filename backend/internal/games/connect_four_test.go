@@ -0,0 +1,84 @@
+package games
+
+import "testing"
+
+func dropAll(t *testing.T, g *ConnectFour, cols ...int) {
+	t.Helper()
+	for _, c := range cols {
+		if !g.Drop(c) { t.Fatalf("drop in column %d rejected", c) }
+	}
+}
+
+func TestConnectFourGravityAndFullColumn(t *testing.T) {
+	g := NewConnectFour(false, "")
+	dropAll(t, g, 0, 0, 0, 0, 0, 0)
+	if g.Board[5][0] != "R" || g.Board[0][0] != "Y" {
+		t.Fatalf("pieces should stack from the bottom: %+v", g.Board)
+	}
+	if g.Drop(0) || g.Drop(7) || g.Drop(-1) { t.Fatal("full or missing columns must be rejected") }
+}
+
+func TestConnectFourWins(t *testing.T) {
+	cases := map[string][]int{
+		"horizontal": {0, 0, 1, 1, 2, 2, 3},
+		"vertical":   {4, 5, 4, 5, 4, 5, 4},
+		"diagonal":   {0, 1, 1, 2, 2, 3, 2, 3, 3, 6, 3},
+		"anti-diag":  {6, 5, 5, 4, 4, 3, 4, 3, 3, 0, 3},
+	}
+	for name, moves := range cases {
+		g := NewConnectFour(false, "")
+		dropAll(t, g, moves...)
+		if g.Winner != "R" || len(g.WinningCells) < 4 {
+			t.Fatalf("%s: expected R to win, got %q %v", name, g.Winner, g.WinningCells)
+		}
+		if g.Drop(6) { t.Fatalf("%s: moves after the win must be rejected", name) }
+	}
+}
+
+func TestConnectFourDraw(t *testing.T) {
+	g := NewConnectFour(false, "")
+	// fill columns in pairs with a shifted pattern so no four ever line up
+	for _, pair := range [][2]int{{0, 1}, {2, 3}, {4, 5}} {
+		for i := 0; i < 3; i++ { dropAll(t, g, pair[0], pair[1]) }
+		for i := 0; i < 3; i++ { dropAll(t, g, pair[1], pair[0]) }
+	}
+	for i := 0; i < 6; i++ { dropAll(t, g, 6) }
+	if g.Winner != "D" { t.Fatalf("expected draw, got %q\n%v", g.Winner, g.Board) }
+}
+
+func TestConnectFourUndo(t *testing.T) {
+	g := NewConnectFour(false, "")
+	dropAll(t, g, 0, 0, 1, 1, 2, 2, 3)
+	if !g.Undo() || g.Winner != "" || g.CurrentPlayer != "R" || g.Board[5][3] != "" {
+		t.Fatalf("undo should reopen the game for R, got %+v", g)
+	}
+	ai := NewConnectFour(true, "easy")
+	dropAll(t, ai, 3)
+	if len(ai.Moves) != 2 || !ai.Undo() || len(ai.Moves) != 0 || ai.CurrentPlayer != "R" {
+		t.Fatalf("undo against the AI should remove both moves, got %+v", ai.Moves)
+	}
+}
+
+func TestConnectFourAIWinsAndBlocks(t *testing.T) {
+	for _, diff := range []string{"easy", "medium", "hard"} {
+		g := NewConnectFour(false, diff)
+		dropAll(t, g, 0, 6, 1, 6, 2) // R threatens column 3, Y to move
+		if col := g.BestColumn(c4Depth[diff]); col != 3 {
+			t.Fatalf("%s: AI should block column 3, chose %d", diff, col)
+		}
+		dropAll(t, g, 6, 5) // Y now has three in column 6 and it is Y's turn
+		if col := g.BestColumn(c4Depth[diff]); col != 6 {
+			t.Fatalf("%s: AI should win in column 6, chose %d", diff, col)
+		}
+	}
+}
+
+func TestConnectFourHardBeatsEasy(t *testing.T) {
+	g := NewConnectFour(false, "")
+	for g.Winner == "" {
+		depth := c4Depth["hard"]
+		if g.CurrentPlayer == "Y" { depth = 1 }
+		g.play(g.BestColumn(depth))
+	}
+	if g.Winner != "R" { t.Fatalf("depth 7 should beat depth 1, got %q", g.Winner) }
+}
@@ -0,0 +1,92 @@
+package games
+
+import (
+	"bufio"
+	_ "embed"
+	"errors"
+	"io"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Dictionary is a set of lowercase words the word games draw from: Hangman
+// picks its secret word from it, Wordle its answers and the guesses it
+// accepts.
+type Dictionary struct {
+	words []string // sorted
+	set   map[string]bool
+}
+
+// ErrEmptyDictionary is returned when a word list has no usable words.
+var ErrEmptyDictionary = errors.New("dictionary has no words")
+
+// NewDictionary keeps the words made only of letters a-z (after lowering
+// them), without duplicates.
+func NewDictionary(words []string) *Dictionary {
+	d := &Dictionary{set: map[string]bool{}}
+	for _, w := range words {
+		w = strings.ToLower(strings.TrimSpace(w))
+		if w == "" || d.set[w] || strings.Trim(w, "abcdefghijklmnopqrstuvwxyz") != "" { continue }
+		d.set[w] = true
+		d.words = append(d.words, w)
+	}
+	sort.Strings(d.words)
+	return d
+}
+
+// LoadDictionary reads a word list with one word per line.
+func LoadDictionary(r io.Reader) (*Dictionary, error) {
+	var words []string
+	sc := bufio.NewScanner(r)
+	for sc.Scan() { words = append(words, sc.Text()) }
+	if err := sc.Err(); err != nil { return nil, err }
+	d := NewDictionary(words)
+	if d.Len() == 0 { return nil, ErrEmptyDictionary }
+	return d, nil
+}
+
+func (d *Dictionary) Len() int { return len(d.words) }
+
+// Contains reports whether word (any case) is in the dictionary.
+func (d *Dictionary) Contains(word string) bool { return d.set[strings.ToLower(word)] }
+
+// Words lists the words with min to max letters.
+func (d *Dictionary) Words(min, max int) []string {
+	var out []string
+	for _, w := range d.words {
+		if len(w) >= min && len(w) <= max { out = append(out, w) }
+	}
+	return out
+}
+
+// Random picks a word with min to max letters, or "" when there is none.
+func (d *Dictionary) Random(min, max int) string {
+	words := d.Words(min, max)
+	if len(words) == 0 { return "" }
+	return words[rand.Intn(len(words))]
+}
+
+//go:embed words.txt
+var builtinWords string
+
+var (
+	poolMu   sync.RWMutex
+	wordPool = NewDictionary(strings.Fields(builtinWords))
+)
+
+// Words returns the dictionary the word games currently share.
+func Words() *Dictionary {
+	poolMu.RLock()
+	defer poolMu.RUnlock()
+	return wordPool
+}
+
+// UseWords replaces the shared dictionary; games already running keep their
+// words.
+func UseWords(d *Dictionary) {
+	poolMu.Lock()
+	defer poolMu.Unlock()
+	wordPool = d
+}
@@ -18,31 +18,24 @@ type Hangman struct {
     Finished    bool     `json:"finished"`
     Won         bool     `json:"won"`
     Difficulty  string   `json:"difficulty"`
-}
-
-var wordPool = []string{
-    "go", "code", "game", "react", "pixel", "binary", "dragon", "async", "memory", "network",
-    "galaxy", "hangman", "puzzle", "random", "frontend", "backend", "context", "pointer", "compiler", "optimize",
+    Series      *Series  `json:"series,omitempty"`
+    Spectators  int      `json:"spectators"`
 }
 
 func NewHangman(diff string) *Hangman {
     if diff == "" { diff = "normal" }
     rand.Seed(time.Now().UnixNano())
     // Filter by length heuristics
-    var candidates []string
-    for _, w := range wordPool {
-        l := len(w)
-        switch diff {
-        case "easy":
-            if l <= 5 { candidates = append(candidates, w) }
-        case "hard":
-            if l >= 6 { candidates = append(candidates, w) }
-        default: // normal
-            if l >= 4 && l <= 8 { candidates = append(candidates, w) }
-        }
+    min, max := 4, 8 // normal
+    switch diff {
+    case "easy":
+        min, max = 1, 5
+    case "hard":
+        min, max = 6, 99
     }
-    if len(candidates) == 0 { candidates = wordPool }
-    word := candidates[rand.Intn(len(candidates))]
+    words := Words()
+    word := words.Random(min, max)
+    if word == "" { word = words.Random(1, 99) }
     maxWrong := 6
     if diff == "easy" { maxWrong = 8 } else if diff == "hard" { maxWrong = 5 }
     h := &Hangman{Word: word, Difficulty: diff, MaxWrong: maxWrong, Guessed: []string{}}
@@ -75,6 +68,7 @@ func (h *Hangman) Guess(letter string) {
 
 // Reset starts a new word with same difficulty parameters.
 func (h *Hangman) Reset() {
-    diff := h.Difficulty
+    diff, series := h.Difficulty, h.Series
     *h = *NewHangman(diff)
+    h.Series = series
 }
@@ -0,0 +1,246 @@
+package games
+
+import (
+	"errors"
+	"log"
+	"math/rand"
+	"strconv"
+	"time"
+)
+
+// Minesweeper is the classic mine field. Board[r][c] is what the player
+// sees: "" for a covered cell, "F" for a flag and "0"-"8" for an uncovered
+// cell's count of neighbouring mines. Once the game is lost every mine shows
+// as "*" and the one that went off as "X". Mines are placed on the first
+// reveal, never on or next to that cell; with NoGuess the field is also
+// checked to be solvable by deduction alone from there; NoGuess is cleared
+// when no such field was found.
+type Minesweeper struct {
+	Width      int        `json:"width"`
+	Height     int        `json:"height"`
+	Mines      int        `json:"mines"`
+	NoGuess    bool       `json:"noGuess"`
+	Difficulty string     `json:"difficulty"` // beginner, intermediate, expert or custom
+	Board      [][]string `json:"board"`
+	Layout     []bool     `json:"-"` // Layout[r*Width+c] holds a mine; nil until the first reveal
+	Flags      int        `json:"flags"`
+	Status     string     `json:"status"` // ready, playing, won or lost
+	Moves      int        `json:"moves"`
+	StartedAt  *time.Time `json:"startedAt,omitempty"`
+	Seconds    int        `json:"seconds"` // play time, set when the game ends
+	Series     *Series    `json:"series,omitempty"`
+	Spectators int        `json:"spectators"`
+
+	askedNoGuess bool // NoGuess as asked for; each new field tries again
+}
+
+// MinesweeperPresets are the classic fields as width, height and mines.
+var MinesweeperPresets = map[string][3]int{
+	"beginner":     {9, 9, 10},
+	"intermediate": {16, 16, 40},
+	"expert":       {30, 16, 99},
+}
+
+var (
+	ErrBadPreset    = errors.New("difficulty must be beginner, intermediate or expert")
+	ErrBadMinefield = errors.New("mine fields are 5-30 wide and 5-24 tall, with at least one mine and 9 free cells")
+	ErrTooDense     = errors.New("no-guess fields can be at most 22% mines")
+)
+
+// msAttempts bounds the fields tried for a no-guess board; a field that
+// still needs a guess after that many is handed out with NoGuess cleared.
+var msAttempts = 2000
+
+// NewMinesweeper starts a field from a preset, or from width, height and
+// mines when difficulty is empty (beginner when those are zero too).
+func NewMinesweeper(difficulty string, width, height, mines int, noGuess bool) (*Minesweeper, error) {
+	if difficulty == "" && width == 0 && height == 0 && mines == 0 { difficulty = "beginner" }
+	if difficulty == "" {
+		difficulty = "custom"
+	} else {
+		p, ok := MinesweeperPresets[difficulty]
+		if !ok { return nil, ErrBadPreset }
+		width, height, mines = p[0], p[1], p[2]
+	}
+	if width < 5 || width > 30 || height < 5 || height > 24 || mines < 1 || mines > width*height-9 { return nil, ErrBadMinefield }
+	if noGuess && mines*100 > width*height*22 { return nil, ErrTooDense } // denser fields almost never avoid guesses
+	g := &Minesweeper{Width: width, Height: height, Mines: mines, NoGuess: noGuess, Difficulty: difficulty, askedNoGuess: noGuess}
+	g.Reset()
+	log.Printf("[MINES] New game %dx%d mines=%d noGuess=%v", width, height, mines, noGuess)
+	return g, nil
+}
+
+// Reset covers the field again; mines are placed anew on the first reveal.
+func (g *Minesweeper) Reset() {
+	g.Board = make([][]string, g.Height)
+	for r := range g.Board { g.Board[r] = make([]string, g.Width) }
+	g.Layout, g.Flags, g.Status, g.Moves = nil, 0, "ready", 0
+	g.StartedAt, g.Seconds = nil, 0
+	g.NoGuess = g.askedNoGuess
+}
+
+// Again starts a new game with the same field settings.
+func (g *Minesweeper) Again() *Minesweeper {
+	ng := &Minesweeper{Width: g.Width, Height: g.Height, Mines: g.Mines, Difficulty: g.Difficulty, askedNoGuess: g.askedNoGuess}
+	ng.Reset()
+	return ng
+}
+
+// Over reports whether the game is won or lost.
+func (g *Minesweeper) Over() bool { return g.Status == "won" || g.Status == "lost" }
+
+func (g *Minesweeper) cell(row, col int) (int, bool) {
+	if g.Over() || row < 0 || row >= g.Height || col < 0 || col >= g.Width { return 0, false }
+	return row*g.Width + col, true
+}
+
+func (g *Minesweeper) at(i int) *string { return &g.Board[i/g.Width][i%g.Width] }
+
+// Reveal uncovers a covered, unflagged cell. Cells without neighbouring
+// mines open their whole empty region. It returns false for moves that do
+// nothing.
+func (g *Minesweeper) Reveal(row, col int) bool {
+	i, ok := g.cell(row, col)
+	if !ok || *g.at(i) != "" { return false }
+	if g.Layout == nil {
+		now := time.Now()
+		var solvable bool
+		g.Layout, solvable = placeMines(g.Width, g.Height, g.Mines, i, g.NoGuess)
+		if g.NoGuess && !solvable { g.NoGuess = false }
+		g.StartedAt, g.Status = &now, "playing"
+	}
+	g.Moves++
+	g.open([]int{i})
+	return true
+}
+
+// Flag toggles a flag on a covered cell.
+func (g *Minesweeper) Flag(row, col int) bool {
+	i, ok := g.cell(row, col)
+	if !ok { return false }
+	switch *g.at(i) {
+	case "":
+		*g.at(i) = "F"
+		g.Flags++
+	case "F":
+		*g.at(i) = ""
+		g.Flags--
+	default:
+		return false
+	}
+	g.Moves++
+	return true
+}
+
+// Chord uncovers every unflagged neighbour of an uncovered number whose
+// mines are all flagged. A wrong flag makes that lose the game.
+func (g *Minesweeper) Chord(row, col int) bool {
+	i, ok := g.cell(row, col)
+	if !ok { return false }
+	n, err := strconv.Atoi(*g.at(i))
+	if err != nil || n == 0 { return false }
+	var covered []int
+	flags := 0
+	for _, j := range neighbours(g.Width, g.Height, i) {
+		switch *g.at(j) {
+		case "F":
+			flags++
+		case "":
+			covered = append(covered, j)
+		}
+	}
+	if flags != n || len(covered) == 0 { return false }
+	g.Moves++
+	g.open(covered)
+	return true
+}
+
+// open uncovers cells, flooding outwards from zeros, and settles the game
+// when a mine is hit or the last safe cell is uncovered.
+func (g *Minesweeper) open(cells []int) {
+	for _, i := range cells {
+		if g.Layout[i] { g.lose(i); return }
+	}
+	queue := append([]int(nil), cells...)
+	for len(queue) > 0 {
+		i := queue[0]
+		queue = queue[1:]
+		if *g.at(i) != "" { continue }
+		n := 0
+		nb := neighbours(g.Width, g.Height, i)
+		for _, j := range nb { if g.Layout[j] { n++ } }
+		*g.at(i) = strconv.Itoa(n)
+		if n == 0 { queue = append(queue, nb...) } // flagged neighbours stay covered
+	}
+	g.Flags = 0
+	covered := 0
+	for i := range g.Layout {
+		switch *g.at(i) {
+		case "F":
+			g.Flags++
+			covered++
+		case "":
+			covered++
+		}
+	}
+	if covered == g.Mines { g.finish("won") }
+}
+
+func (g *Minesweeper) lose(exploded int) {
+	for i, mine := range g.Layout {
+		if mine { *g.at(i) = "*" }
+	}
+	*g.at(exploded) = "X"
+	g.finish("lost")
+}
+
+func (g *Minesweeper) finish(status string) {
+	g.Status = status
+	if status == "won" {
+		for i, mine := range g.Layout {
+			if mine { *g.at(i) = "F" }
+		}
+		g.Flags = g.Mines
+	}
+	if g.StartedAt != nil { g.Seconds = int(time.Since(*g.StartedAt).Seconds()) }
+	log.Printf("[MINES] Game %s after %d moves", status, g.Moves)
+}
+
+// neighbours lists the up to eight cells around i on a w x h field.
+func neighbours(w, h, i int) []int {
+	r, c := i/w, i%w
+	out := make([]int, 0, 8)
+	for dr := -1; dr <= 1; dr++ {
+		for dc := -1; dc <= 1; dc++ {
+			rr, cc := r+dr, c+dc
+			if (dr != 0 || dc != 0) && rr >= 0 && rr < h && cc >= 0 && cc < w { out = append(out, rr*w+cc) }
+		}
+	}
+	return out
+}
+
+// placeMines scatters mines away from the first click at safe. With noGuess
+// it keeps drawing until the solver clears the field from safe and reports
+// whether it did.
+func placeMines(w, h, mines, safe int, noGuess bool) ([]bool, bool) {
+	keepOut := map[int]bool{safe: true}
+	for _, j := range neighbours(w, h, safe) { keepOut[j] = true }
+	var free []int
+	for i := 0; i < w*h; i++ {
+		if !keepOut[i] { free = append(free, i) }
+	}
+	for attempt := 1; ; attempt++ {
+		layout := make([]bool, w*h)
+		rand.Shuffle(len(free), func(a, b int) { free[a], free[b] = free[b], free[a] })
+		for _, i := range free[:mines] { layout[i] = true }
+		if !noGuess { return layout, false }
+		if mineSolvable(w, h, layout, safe) {
+			log.Printf("[MINES] no-guess field found after %d attempts", attempt)
+			return layout, true
+		}
+		if attempt >= msAttempts {
+			log.Printf("[MINES] no no-guess field in %d attempts, using a random one", attempt)
+			return layout, false
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package games
+
+import "sort"
+
+// msNodeLimit caps the backtracking of one frontier region; regions that
+// need more are treated as undecidable.
+const msNodeLimit = 200000
+
+// msSolver plays a field from its first click using deductions only, the way
+// a careful player would: first the single-number rules, then every
+// consistent mine arrangement along the uncovered frontier.
+type msSolver struct {
+	w, h   int
+	mines  []bool
+	known  []byte // 0 covered, 'o' uncovered, 'f' deduced mine
+	number []int
+	left   int // mines not yet deduced
+	closed int // covered safe cells
+}
+
+// mineSolvable reports whether the field can be cleared from start without
+// ever guessing.
+func mineSolvable(w, h int, mines []bool, start int) bool {
+	s := &msSolver{w: w, h: h, mines: mines, known: make([]byte, w*h), number: make([]int, w*h)}
+	for i, m := range mines {
+		if m {
+			s.left++
+			continue
+		}
+		s.closed++
+		for _, j := range neighbours(w, h, i) { if mines[j] { s.number[i]++ } }
+	}
+	s.open(start)
+	for s.closed > 0 {
+		if !s.simple() && !s.enumerate() { return false }
+	}
+	return true
+}
+
+// open uncovers a deduced safe cell, flooding from zeros.
+func (s *msSolver) open(i int) {
+	queue := []int{i}
+	for len(queue) > 0 {
+		i := queue[0]
+		queue = queue[1:]
+		if s.known[i] != 0 { continue }
+		s.known[i] = 'o'
+		s.closed--
+		if s.number[i] == 0 { queue = append(queue, neighbours(s.w, s.h, i)...) }
+	}
+}
+
+func (s *msSolver) flag(i int) {
+	if s.known[i] == 0 {
+		s.known[i] = 'f'
+		s.left--
+	}
+}
+
+// constraint says how many of cells, all covered, are mines.
+type constraint struct {
+	cells []int
+	need  int
+}
+
+// constraints lists one constraint per uncovered number that still touches
+// covered cells.
+func (s *msSolver) constraints() []constraint {
+	var out []constraint
+	for i, k := range s.known {
+		if k != 'o' { continue }
+		c := constraint{need: s.number[i]}
+		for _, j := range neighbours(s.w, s.h, i) {
+			switch s.known[j] {
+			case 0:
+				c.cells = append(c.cells, j)
+			case 'f':
+				c.need--
+			}
+		}
+		if len(c.cells) > 0 { out = append(out, c) }
+	}
+	return out
+}
+
+// simple applies the one-number rules and the global mine count; it reports
+// whether anything was deduced.
+func (s *msSolver) simple() bool {
+	progress := false
+	for _, c := range s.constraints() {
+		switch c.need {
+		case 0:
+			for _, j := range c.cells { s.open(j) }
+			progress = true
+		case len(c.cells):
+			for _, j := range c.cells { s.flag(j) }
+			progress = true
+		}
+	}
+	if progress { return true }
+	covered := 0
+	for _, k := range s.known { if k == 0 { covered++ } }
+	if s.left == 0 || s.left == covered {
+		for i, k := range s.known {
+			if k != 0 { continue }
+			if s.left == 0 { s.open(i) } else { s.flag(i) }
+		}
+		return covered > 0
+	}
+	return false
+}
+
+// enumerate splits the frontier into independent regions and tries every
+// mine arrangement of each; cells that are safe (or mined) in all of them
+// are deduced.
+func (s *msSolver) enumerate() bool {
+	cons := s.constraints()
+	// union the cells of each constraint into regions
+	parent := map[int]int{}
+	var find func(int) int
+	find = func(x int) int {
+		if parent[x] != x { parent[x] = find(parent[x]) }
+		return parent[x]
+	}
+	for _, c := range cons {
+		for _, j := range c.cells { if _, ok := parent[j]; !ok { parent[j] = j } }
+		for _, j := range c.cells[1:] { parent[find(j)] = find(c.cells[0]) }
+	}
+	regions := map[int][]int{}
+	for j := range parent { regions[find(j)] = append(regions[find(j)], j) }
+	for _, cells := range regions { sort.Ints(cells) } // neighbours close together prune sooner
+	progress := false
+	for root, cells := range regions {
+		var rc []constraint
+		for _, c := range cons {
+			if find(c.cells[0]) == root { rc = append(rc, c) }
+		}
+		safe, mined, ok := s.region(cells, rc)
+		if !ok { continue }
+		for _, j := range safe { s.open(j); progress = true }
+		for _, j := range mined { s.flag(j); progress = true }
+	}
+	return progress
+}
+
+// region backtracks over the arrangements of one frontier region. ok is
+// false when the node limit was hit.
+func (s *msSolver) region(cells []int, cons []constraint) (safe, mined []int, ok bool) {
+	index := map[int]int{}
+	for k, j := range cells { index[j] = k }
+	touches := make([][]int, len(cells)) // cell -> constraints it appears in
+	unset := make([]int, len(cons))
+	for ci, c := range cons {
+		unset[ci] = len(c.cells)
+		for _, j := range c.cells { touches[index[j]] = append(touches[index[j]], ci) }
+	}
+	need := make([]int, len(cons))
+	for ci, c := range cons { need[ci] = c.need }
+	assign := make([]bool, len(cells))
+	hits := make([]int, len(cells))
+	solutions, nodes := 0, 0
+	var walk func(k, used int) bool
+	walk = func(k, used int) bool {
+		if nodes++; nodes > msNodeLimit { return false }
+		if k == len(cells) {
+			solutions++
+			for i, m := range assign { if m { hits[i]++ } }
+			return true
+		}
+		for _, mine := range []bool{false, true} {
+			if mine && used == s.left { continue }
+			fits := true
+			for _, ci := range touches[k] {
+				unset[ci]--
+				if mine { need[ci]-- }
+				if need[ci] < 0 || need[ci] > unset[ci] { fits = false }
+			}
+			if fits {
+				assign[k] = mine
+				u := used
+				if mine { u++ }
+				if !walk(k+1, u) { return false }
+			}
+			for _, ci := range touches[k] {
+				unset[ci]++
+				if mine { need[ci]++ }
+			}
+		}
+		return true
+	}
+	if !walk(0, 0) || solutions == 0 { return nil, nil, false }
+	for k, j := range cells {
+		switch hits[k] {
+		case 0:
+			safe = append(safe, j)
+		case solutions:
+			mined = append(mined, j)
+		}
+	}
+	return safe, mined, true
+}
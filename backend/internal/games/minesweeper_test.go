@@ -0,0 +1,160 @@
+package games
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// fixedField returns a 5x5 game with mines at the given cells, as if the
+// first reveal had already placed them.
+func fixedField(t *testing.T, mines ...int) *Minesweeper {
+	t.Helper()
+	g, err := NewMinesweeper("", 5, 5, len(mines), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.Layout = make([]bool, 25)
+	for _, i := range mines {
+		g.Layout[i] = true
+	}
+	g.Status = "playing"
+	return g
+}
+
+func TestMinesweeperValidatesFields(t *testing.T) {
+	for _, tc := range []struct {
+		diff    string
+		w, h, m int
+		noGuess bool
+		want    error
+	}{
+		{"", 0, 0, 0, false, nil},
+		{"expert", 0, 0, 0, true, nil},
+		{"huge", 0, 0, 0, false, ErrBadPreset},
+		{"", 4, 9, 3, false, ErrBadMinefield},
+		{"", 9, 9, 0, false, ErrBadMinefield},
+		{"", 9, 9, 73, false, ErrBadMinefield},
+		{"", 9, 9, 72, false, nil},
+		{"", 9, 9, 30, true, ErrTooDense},
+	} {
+		if _, err := NewMinesweeper(tc.diff, tc.w, tc.h, tc.m, tc.noGuess); err != tc.want {
+			t.Errorf("%+v: got %v want %v", tc, err, tc.want)
+		}
+	}
+}
+
+func TestFirstRevealIsSafeAndOpens(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		g, _ := NewMinesweeper("", 9, 9, 72, false) // every cell but the 3x3 around the click is a mine
+		if !g.Reveal(4, 4) || g.Status != "won" {
+			t.Fatalf("first reveal must be safe and open its neighbourhood, got %s", g.Status)
+		}
+	}
+	g, _ := NewMinesweeper("intermediate", 0, 0, 0, false)
+	g.Reveal(0, 0)
+	if g.Board[0][0] != "0" || g.Status != "playing" {
+		t.Fatalf("corner click should open an empty region, got %q %s", g.Board[0][0], g.Status)
+	}
+	b, _ := json.Marshal(g)
+	if strings.Contains(string(b), "ayout") || strings.Contains(string(b), "*") {
+		t.Fatalf("mine positions leaked: %s", b)
+	}
+}
+
+func TestFloodFillStopsAtNumbersAndFlags(t *testing.T) {
+	// mine in the bottom right corner; the rest floods open
+	g := fixedField(t, 24)
+	g.Flag(0, 4)
+	g.Reveal(0, 0)
+	if g.Board[3][3] != "1" || g.Board[4][3] != "1" || g.Board[0][4] != "F" {
+		t.Fatalf("unexpected board %v", g.Board)
+	}
+	if g.Status != "playing" {
+		t.Fatal("a wrongly flagged safe cell keeps the game going")
+	}
+	g.Flag(0, 4)
+	g.Reveal(0, 4)
+	if g.Status != "won" || g.Board[4][4] != "F" || g.Flags != 1 {
+		t.Fatalf("expected a win with the mine flagged, got %s %v", g.Status, g.Board)
+	}
+}
+
+func TestChording(t *testing.T) {
+	// mines at (0,1) and (2,3); chord around the "1" at (1,1)
+	g := fixedField(t, 1, 13)
+	g.Reveal(1, 1)
+	if g.Board[1][1] != "1" {
+		t.Fatalf("expected a 1, got %q", g.Board[1][1])
+	}
+	if g.Chord(1, 1) {
+		t.Fatal("chord needs the mine flagged first")
+	}
+	g.Flag(0, 1)
+	if !g.Chord(1, 1) || g.Board[0][0] != "1" || g.Board[2][2] != "1" {
+		t.Fatalf("chord should open the other neighbours, got %v", g.Board)
+	}
+
+	g = fixedField(t, 1, 13)
+	g.Reveal(1, 1)
+	g.Flag(0, 0) // wrong flag
+	if !g.Chord(1, 1) || g.Status != "lost" || g.Board[0][1] != "X" || g.Board[2][3] != "*" {
+		t.Fatalf("chording on a wrong flag hits the mine, got %s %v", g.Status, g.Board)
+	}
+}
+
+func TestSolverNeedsNoGuesses(t *testing.T) {
+	// the mines at (3,2) and (4,2) are deducible from the right, but then
+	// (4,0) and (4,1) share every clue: a forced 50/50
+	//   . . . . .
+	//   . . . . .
+	//   . 1 1 1 .
+	//   1 3 * 2 .
+	//   * ? * 2 .
+	layout := make([]bool, 25)
+	layout[17], layout[20], layout[22] = true, true, true
+	if mineSolvable(5, 5, layout, 0) {
+		t.Fatal("a forced 50/50 must not count as solvable")
+	}
+	layout = make([]bool, 25)
+	layout[24] = true
+	if !mineSolvable(5, 5, layout, 0) {
+		t.Fatal("a single corner mine is deducible")
+	}
+	for i := 0; i < 20; i++ {
+		g, _ := NewMinesweeper("intermediate", 0, 0, 0, true)
+		g.Reveal(8, 8)
+		if !mineSolvable(16, 16, g.Layout, 8*16+8) {
+			t.Fatal("no-guess mode handed out a field that needs a guess")
+		}
+	}
+}
+
+func TestNoGuessFallbackClearsFlag(t *testing.T) {
+	defer func(n int) { msAttempts = n }(msAttempts)
+	msAttempts = 1
+	fellBack := false
+	for i := 0; i < 200 && !fellBack; i++ {
+		g, err := NewMinesweeper("", 5, 5, 5, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		g.Reveal(2, 2)
+		solvable := mineSolvable(5, 5, g.Layout, 12)
+		if g.NoGuess != solvable {
+			t.Fatalf("NoGuess is %v for a field that solvable=%v", g.NoGuess, solvable)
+		}
+		if !solvable {
+			fellBack = true
+			if g.Reset(); !g.NoGuess {
+				t.Fatal("a reset field should try for no-guess again")
+			}
+			if !g.Again().NoGuess {
+				t.Fatal("a rematch should ask for no-guess again")
+			}
+		}
+	}
+	if !fellBack {
+		t.Fatal("expected a field needing a guess with a single attempt")
+	}
+}
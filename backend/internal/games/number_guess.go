@@ -13,6 +13,8 @@ type NumberGuess struct {
 	Won        bool   `json:"won"`
 	Max        int    `json:"max"`
 	Difficulty string `json:"difficulty"`
+	Series     *Series `json:"series,omitempty"`
+	Spectators int     `json:"spectators"`
 }
 
 func NewNumberGuess(difficulty string) *NumberGuess {
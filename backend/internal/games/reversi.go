@@ -0,0 +1,191 @@
+package games
+
+import (
+	"errors"
+	"log"
+	"math/bits"
+	"math/rand"
+)
+
+// Reversi (Othello) on 8x8. Board[row][col] is "B", "W" or ""; Black moves
+// first. A move must flip at least one line of the opponent's discs, in any
+// of the 8 directions. A side without a legal move passes (Passed names it
+// and Moves records it); the game ends when neither side can move, the most
+// discs winning. Legal lists the [row, col] squares open to the side to move.
+// With VsAI the human plays HumanPlays (Black unless changed by PlayAs) and
+// the AI the other colour.
+type Reversi struct {
+	Board         [8][8]string  `json:"board"`
+	CurrentPlayer string        `json:"currentPlayer"`
+	Winner        string        `json:"winner"` // "B", "W", "D" for a draw, or "" while ongoing
+	Black         int           `json:"black"`  // disc counts
+	White         int           `json:"white"`
+	Legal         [][2]int      `json:"legal"`
+	Passed        string        `json:"passed,omitempty"` // the colour that just had to pass
+	Moves         []ReversiMove `json:"moves"`
+	VsAI          bool          `json:"vsAI"`
+	Difficulty    string        `json:"difficulty"`           // easy, medium, hard or expert (only relevant when VsAI)
+	HumanPlays    string        `json:"humanPlays,omitempty"` // the human's colour against the AI
+	Series        *Series       `json:"series,omitempty"`
+	Spectators    int           `json:"spectators"`
+
+	black, white uint64
+}
+
+// ReversiMove is one placed disc, or a pass.
+type ReversiMove struct {
+	Row     int    `json:"row"`
+	Col     int    `json:"col"`
+	Player  string `json:"player"`
+	Flipped int    `json:"flipped"`
+	Pass    bool   `json:"pass,omitempty"`
+}
+
+// rvDepth is the alpha-beta search depth per difficulty.
+var rvDepth = map[string]int{"easy": 1, "medium": 3, "hard": 5, "expert": 7}
+
+// rvExact is how many empty squares the AI reads to the end of the game
+// from, at hard and expert.
+const rvExact = 12
+
+var (
+	ErrBadReversiLevel = errors.New("difficulty must be easy, medium, hard or expert")
+	ErrBadColor        = errors.New(`humanPlays must be "B" or "W"`)
+)
+
+// NewReversi creates a game from the standard opening position. An empty
+// difficulty means easy; unknown ones return ErrBadReversiLevel.
+func NewReversi(vsAI bool, difficulty string) (*Reversi, error) {
+	if difficulty == "" { difficulty = "easy" }
+	if _, ok := rvDepth[difficulty]; !ok { return nil, ErrBadReversiLevel }
+	g := &Reversi{VsAI: vsAI, Difficulty: difficulty}
+	if vsAI { g.HumanPlays = "B" }
+	g.Reset()
+	log.Printf("[REVERSI] New game created vsAI=%v difficulty=%s", vsAI, difficulty)
+	return g, nil
+}
+
+// PlayAs sets the colour the human plays against the AI and restarts the
+// game, so an AI playing Black opens straight away.
+func (g *Reversi) PlayAs(color string) error {
+	if color != "B" && color != "W" { return ErrBadColor }
+	if !g.VsAI { return nil }
+	g.HumanPlays = color
+	g.Reset()
+	return nil
+}
+
+func rvOther(p string) string { if p == "B" { return "W" }; return "B" }
+
+// Reset restores the opening position keeping mode and difficulty.
+func (g *Reversi) Reset() {
+	// d5 and e4 black, d4 and e5 white
+	g.black = 1<<(3*8+4) | 1<<(4*8+3)
+	g.white = 1<<(3*8+3) | 1<<(4*8+4)
+	g.CurrentPlayer, g.Winner, g.Passed = "B", "", ""
+	g.Moves = []ReversiMove{}
+	g.update()
+	if g.VsAI && g.HumanPlays == "W" { g.aiMove() }
+}
+
+// sides returns the discs of the side to move and of its opponent.
+func (g *Reversi) sides() (me, opp uint64) {
+	if g.CurrentPlayer == "B" { return g.black, g.white }
+	return g.white, g.black
+}
+
+// Play places a disc for the side to move and lets the AI answer when it
+// is its turn. It returns false for illegal squares and finished games.
+func (g *Reversi) Play(row, col int) bool {
+	if !g.play(row, col) { return false }
+	for g.VsAI && g.Winner == "" && g.CurrentPlayer != g.HumanPlays { g.aiMove() }
+	if g.Winner != "" { log.Printf("[REVERSI] Game over, winner %s (%d-%d)", g.Winner, g.Black, g.White) }
+	return true
+}
+
+func (g *Reversi) play(row, col int) bool {
+	if g.Winner != "" || row < 0 || row > 7 || col < 0 || col > 7 { return false }
+	sq := row*8 + col
+	me, opp := g.sides()
+	if rvMoves(me, opp)&(1<<sq) == 0 { return false }
+	f := rvFlips(me, opp, sq)
+	me, opp = me|f|1<<sq, opp^f
+	if g.CurrentPlayer == "B" { g.black, g.white = me, opp } else { g.white, g.black = me, opp }
+	g.Moves = append(g.Moves, ReversiMove{Row: row, Col: col, Player: g.CurrentPlayer, Flipped: bits.OnesCount64(f)})
+	g.CurrentPlayer, g.Passed = rvOther(g.CurrentPlayer), ""
+	g.update()
+	if len(g.Legal) > 0 { return true }
+	// the side to move is stuck: it passes, unless the other side is too
+	me, opp = g.sides()
+	if rvMoves(opp, me) == 0 {
+		g.finish()
+		return true
+	}
+	g.Passed = g.CurrentPlayer
+	g.Moves = append(g.Moves, ReversiMove{Row: -1, Col: -1, Player: g.CurrentPlayer, Pass: true})
+	g.CurrentPlayer = rvOther(g.CurrentPlayer)
+	g.update()
+	return true
+}
+
+func (g *Reversi) finish() {
+	g.Legal = [][2]int{}
+	switch {
+	case g.Black > g.White:
+		g.Winner = "B"
+	case g.White > g.Black:
+		g.Winner = "W"
+	default:
+		g.Winner = "D"
+	}
+}
+
+// update mirrors the bitboards into the exported fields.
+func (g *Reversi) update() {
+	g.Board = [8][8]string{}
+	for i := 0; i < 64; i++ {
+		switch {
+		case g.black&(1<<i) != 0:
+			g.Board[i/8][i%8] = "B"
+		case g.white&(1<<i) != 0:
+			g.Board[i/8][i%8] = "W"
+		}
+	}
+	g.Black, g.White = bits.OnesCount64(g.black), bits.OnesCount64(g.white)
+	g.Legal = [][2]int{}
+	me, opp := g.sides()
+	for m := rvMoves(me, opp); m != 0; m &= m - 1 {
+		sq := bits.TrailingZeros64(m)
+		g.Legal = append(g.Legal, [2]int{sq / 8, sq % 8})
+	}
+}
+
+func (g *Reversi) aiMove() {
+	best := g.BestMove(g.Difficulty)
+	log.Printf("[REVERSI][AI] %s chose %v", g.Difficulty, best)
+	g.play(best[0], best[1])
+}
+
+// BestMove returns the [row, col] the AI plays for the side to move at
+// difficulty, or [-1, -1] when there is none. Hard and expert play the last
+// rvExact empty squares perfectly; ties are broken at random.
+func (g *Reversi) BestMove(difficulty string) [2]int {
+	depth, ok := rvDepth[difficulty]
+	if !ok || len(g.Legal) == 0 { return [2]int{-1, -1} }
+	me, opp := g.sides()
+	if depth >= rvDepth["hard"] && 64-g.Black-g.White <= rvExact { depth = 64 }
+	bestScore := -rvWin * 2
+	var ties [][2]int
+	for _, c := range g.Legal {
+		sq := c[0]*8 + c[1]
+		f := rvFlips(me, opp, sq)
+		score := -rvNegamax(opp^f, me|f|1<<sq, depth-1, -rvWin*2, -bestScore+1, false)
+		switch {
+		case score > bestScore:
+			bestScore, ties = score, [][2]int{c}
+		case score == bestScore:
+			ties = append(ties, c)
+		}
+	}
+	return ties[rand.Intn(len(ties))]
+}
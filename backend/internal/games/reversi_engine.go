@@ -0,0 +1,131 @@
+package games
+
+import "math/bits"
+
+// The Reversi engine keeps each colour as a bitboard: bit row*8+col is set
+// where that colour has a disc.
+const (
+	rvNotA uint64 = 0xfefefefefefefefe // every column but the first
+	rvNotH uint64 = 0x7f7f7f7f7f7f7f7f // every column but the last
+)
+
+// rvShift moves every bit one step in direction d (0-7), dropping bits that
+// would wrap around an edge.
+func rvShift(b uint64, d int) uint64 {
+	switch d {
+	case 0:
+		return b << 1 & rvNotA // east
+	case 1:
+		return b >> 1 & rvNotH // west
+	case 2:
+		return b << 8 // south
+	case 3:
+		return b >> 8 // north
+	case 4:
+		return b << 9 & rvNotA // south-east
+	case 5:
+		return b << 7 & rvNotH // south-west
+	case 6:
+		return b >> 7 & rvNotA // north-east
+	}
+	return b >> 9 & rvNotH // north-west
+}
+
+// rvMoves returns the empty squares where me flips at least one opp disc.
+func rvMoves(me, opp uint64) uint64 {
+	empty := ^(me | opp)
+	var moves uint64
+	for d := 0; d < 8; d++ {
+		x := rvShift(me, d) & opp
+		for i := 0; i < 5; i++ { x |= rvShift(x, d) & opp }
+		moves |= rvShift(x, d) & empty
+	}
+	return moves
+}
+
+// rvFlips returns the opp discs a disc placed by me on sq turns over.
+func rvFlips(me, opp uint64, sq int) uint64 {
+	var flips uint64
+	for d := 0; d < 8; d++ {
+		var line uint64
+		x := rvShift(1<<sq, d)
+		for x&opp != 0 {
+			line |= x
+			x = rvShift(x, d)
+		}
+		if x&me != 0 { flips |= line }
+	}
+	return flips
+}
+
+// rvPerft counts the move sequences of depth plies from me to move, a pass
+// counting as one move, and ends of the game counting as a leaf.
+func rvPerft(me, opp uint64, depth int, passed bool) int {
+	if depth == 0 { return 1 }
+	moves := rvMoves(me, opp)
+	if moves == 0 {
+		if passed { return 1 }
+		return rvPerft(opp, me, depth-1, true)
+	}
+	n := 0
+	for m := moves; m != 0; m &= m - 1 {
+		sq := bits.TrailingZeros64(m)
+		f := rvFlips(me, opp, sq)
+		n += rvPerft(opp^f, me|f|1<<sq, depth-1, false)
+	}
+	return n
+}
+
+const (
+	rvWin            = 1000000
+	rvCorners uint64 = 0x8100000000000081
+)
+
+// rvEvaluate scores a position for me: corners are worth most, X-squares
+// next to an empty corner cost, and having more moves than the opponent
+// (mobility) matters more than the disc count until the board fills up.
+func rvEvaluate(me, opp uint64) int {
+	score := 25 * (bits.OnesCount64(me&rvCorners) - bits.OnesCount64(opp&rvCorners))
+	empty := ^(me | opp)
+	// each corner with its X-square, the diagonal neighbour that hands the
+	// corner to the opponent while it is empty
+	for _, c := range [4]struct{ corner, x uint64 }{{1, 1 << 9}, {1 << 7, 1 << 14}, {1 << 56, 1 << 49}, {1 << 63, 1 << 54}} {
+		if empty&c.corner == 0 { continue }
+		if me&c.x != 0 { score -= 12 }
+		if opp&c.x != 0 { score += 12 }
+	}
+	score += 4 * (bits.OnesCount64(rvMoves(me, opp)) - bits.OnesCount64(rvMoves(opp, me)))
+	if bits.OnesCount64(empty) < 16 { score += bits.OnesCount64(me) - bits.OnesCount64(opp) }
+	return score
+}
+
+// rvNegamax scores the position for me with alpha-beta; finished games score
+// their final disc difference beyond any evaluation.
+func rvNegamax(me, opp uint64, depth, alpha, beta int, passed bool) int {
+	moves := rvMoves(me, opp)
+	if moves == 0 {
+		if passed || rvMoves(opp, me) == 0 {
+			diff := bits.OnesCount64(me) - bits.OnesCount64(opp)
+			switch {
+			case diff > 0:
+				return rvWin + diff
+			case diff < 0:
+				return -rvWin + diff
+			}
+			return 0
+		}
+		return -rvNegamax(opp, me, depth, -beta, -alpha, true)
+	}
+	if depth <= 0 { return rvEvaluate(me, opp) }
+	// corners first: they cut off the most
+	for _, set := range [2]uint64{moves & rvCorners, moves &^ rvCorners} {
+		for m := set; m != 0; m &= m - 1 {
+			sq := bits.TrailingZeros64(m)
+			f := rvFlips(me, opp, sq)
+			score := -rvNegamax(opp^f, me|f|1<<sq, depth-1, -beta, -alpha, false)
+			if score > alpha { alpha = score }
+			if alpha >= beta { return alpha }
+		}
+	}
+	return alpha
+}
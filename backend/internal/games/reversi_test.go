@@ -0,0 +1,107 @@
+package games
+
+import "testing"
+
+func TestReversiPerft(t *testing.T) {
+	// published perft figures for the opening position
+	want := []int{1, 4, 12, 56, 244, 1396, 8200, 55092, 390216}
+	g, _ := NewReversi(false, "")
+	for depth, n := range want {
+		if got := rvPerft(g.black, g.white, depth, false); got != n {
+			t.Fatalf("perft(%d) = %d, want %d", depth, got, n)
+		}
+	}
+}
+
+func TestReversiFlipsAndLegal(t *testing.T) {
+	g, _ := NewReversi(false, "")
+	if len(g.Legal) != 4 || g.Black != 2 || g.White != 2 {
+		t.Fatalf("unexpected opening %+v", g)
+	}
+	if g.Play(0, 0) || g.Play(3, 3) {
+		t.Fatal("moves that flip nothing must be refused")
+	}
+	// f5 (row 4, col 5) flips e5
+	if !g.Play(4, 5) || g.Board[4][4] != "B" || g.Black != 4 || g.White != 1 || g.CurrentPlayer != "W" {
+		t.Fatalf("f5 should flip e5: %v", g.Board)
+	}
+	if m := g.Moves[0]; m.Flipped != 1 || m.Player != "B" {
+		t.Fatalf("unexpected move record %+v", m)
+	}
+}
+
+func TestReversiPassAndEnd(t *testing.T) {
+	g, _ := NewReversi(false, "")
+	// Black a1, h1, h2, h3; White b1 and h4. Black c1 takes b1, which leaves
+	// White's h4 without a move while Black can still take it from h5.
+	g.black, g.white = 1<<0|1<<7|1<<15|1<<23, 1<<1|1<<31
+	g.update()
+	if !g.Play(0, 2) {
+		t.Fatal("c1 should be legal")
+	}
+	last := g.Moves[len(g.Moves)-1]
+	if g.Passed != "W" || !last.Pass || last.Player != "W" || g.CurrentPlayer != "B" || g.Winner != "" {
+		t.Fatalf("White should have passed: %+v", g)
+	}
+	if len(g.Legal) != 1 || g.Legal[0] != [2]int{4, 7} {
+		t.Fatalf("expected h5 only, got %v", g.Legal)
+	}
+	// h5 takes White's last disc: neither side can move any more
+	g.Play(4, 7)
+	if g.Winner != "B" || g.Black != 8 || g.White != 0 || len(g.Legal) != 0 {
+		t.Fatalf("expected a finished game won by Black: %+v", g)
+	}
+	if g.Play(5, 7) {
+		t.Fatal("finished games take no moves")
+	}
+}
+
+func TestReversiAI(t *testing.T) {
+	if _, err := NewReversi(true, "grandmaster"); err != ErrBadReversiLevel {
+		t.Fatalf("expected bad level, got %v", err)
+	}
+	g, _ := NewReversi(true, "medium")
+	if err := g.PlayAs("W"); err != nil || len(g.Moves) != 1 || g.CurrentPlayer != "W" {
+		t.Fatalf("an AI playing Black opens: %v %+v", err, g.Moves)
+	}
+	if g.PlayAs("X") != ErrBadColor {
+		t.Fatal("expected bad colour")
+	}
+
+	// the AI takes a corner when it can: Black reaches a1 over White b2
+	g, _ = NewReversi(false, "")
+	g.black, g.white = 1<<27|1<<18, 1<<9|1<<36
+	g.update()
+	for _, level := range []string{"easy", "medium", "hard"} {
+		found := false
+		for _, c := range g.Legal {
+			found = found || c == [2]int{0, 0}
+		}
+		if !found {
+			t.Fatalf("set-up should allow a1: %v", g.Legal)
+		}
+		if m := g.BestMove(level); m != [2]int{0, 0} {
+			t.Fatalf("%s should take the corner, got %v", level, m)
+		}
+	}
+
+	// deeper search beats shallower: hard as Black against easy
+	wins := 0
+	for i := 0; i < 6; i++ {
+		g, _ := NewReversi(false, "")
+		for g.Winner == "" {
+			level := "easy"
+			if g.CurrentPlayer == "B" {
+				level = "hard"
+			}
+			m := g.BestMove(level)
+			g.Play(m[0], m[1])
+		}
+		if g.Winner == "B" {
+			wins++
+		}
+	}
+	if wins < 5 {
+		t.Fatalf("hard won only %d of 6 games against easy", wins)
+	}
+}
@@ -9,6 +9,8 @@ import (
 // RPSGame represents a Rock Paper Scissors match vs simple RNG AI.
 // Player plays until someone reaches Target wins.
 // Moves: rock, paper, scissors.
+// In Versus mode two humans play instead: seat "p1" uses the Player* fields,
+// seat "p2" the AI* fields, and Winner is "p1" or "p2".
 
 type RPSGame struct {
     PlayerScore int    `json:"playerScore"`
@@ -20,6 +22,13 @@ type RPSGame struct {
     LastResult  string `json:"lastResult"` // win, lose, draw
     Finished    bool   `json:"finished"`
     Winner      string `json:"winner"` // player, ai
+    Versus      bool   `json:"versus"`
+    Waiting     []string `json:"waiting,omitempty"` // versus: seats that still have to move this round
+    Series      *Series  `json:"series,omitempty"`
+    Spectators  int      `json:"spectators"`
+    Clock       *Clock   `json:"clock,omitempty"`
+    TimedOut    string   `json:"timedOut,omitempty"` // versus: seat that lost on time
+    pending     map[string]string // versus: moves kept secret until both seats played
 }
 
 func NewRPS(target int) *RPSGame {
@@ -27,14 +36,46 @@ func NewRPS(target int) *RPSGame {
     return &RPSGame{Target: target}
 }
 
+// NewRPSVersus creates a two player match between seats "p1" and "p2".
+func NewRPSVersus(target int) *RPSGame {
+    g := NewRPS(target)
+    g.Versus = true
+    g.Waiting = []string{"p1", "p2"}
+    return g
+}
+
 var rpsMoves = []string{"rock", "paper", "scissors"}
 
 func (g *RPSGame) Play(move string) {
-    if g.Finished { return }
+    if g.Finished || g.Versus { return }
     m := strings.ToLower(move)
     if !validMove(m) { return }
     rand.Seed(time.Now().UnixNano())
-    ai := rpsMoves[rand.Intn(3)]
+    g.resolve(m, rpsMoves[rand.Intn(3)])
+}
+
+// PlaySeat submits seat's move in a versus match. The move stays hidden until
+// the other seat has played too, then the round is resolved. It returns false
+// for invalid moves or when seat already played this round.
+func (g *RPSGame) PlaySeat(seat, move string) bool {
+    if !g.Versus || g.Finished { return false }
+    if seat != "p1" && seat != "p2" { return false }
+    m := strings.ToLower(move)
+    if !validMove(m) { return false }
+    if _, played := g.pending[seat]; played { return false }
+    if g.pending == nil { g.pending = map[string]string{} }
+    g.pending[seat] = m
+    g.Waiting = nil
+    for _, s := range []string{"p1", "p2"} { if _, ok := g.pending[s]; !ok { g.Waiting = append(g.Waiting, s) } }
+    if len(g.pending) < 2 { return true }
+    g.resolve(g.pending["p1"], g.pending["p2"])
+    g.pending = nil
+    if !g.Finished { g.Waiting = []string{"p1", "p2"} }
+    return true
+}
+
+// resolve scores one round; m is the player's (or p1's) move, ai the opponent's.
+func (g *RPSGame) resolve(m, ai string) {
     g.LastPlayer = m
     g.LastAI = ai
     g.Rounds++
@@ -51,6 +92,7 @@ func (g *RPSGame) Play(move string) {
     if g.PlayerScore >= g.Target || g.AIScore >= g.Target {
         g.Finished = true
         if g.PlayerScore > g.AIScore { g.Winner = "player" } else if g.AIScore > g.PlayerScore { g.Winner = "ai" }
+        if g.Versus { g.Winner = map[string]string{"player": "p1", "ai": "p2"}[g.Winner] }
     }
 }
 
@@ -60,6 +102,23 @@ func (g *RPSGame) Reset() {
     g.LastPlayer, g.LastAI, g.LastResult = "", "", ""
     g.Finished = false
     g.Winner = ""
+    g.pending = nil
+    if g.Versus { g.Waiting = []string{"p1", "p2"} }
+    g.TimedOut = ""
+    if g.Clock != nil { g.Clock.Reset() }
+}
+
+// AutoMove plays a random move for a versus seat whose clock ran out.
+func (g *RPSGame) AutoMove(seat string) bool {
+    return g.PlaySeat(seat, rpsMoves[rand.Intn(3)])
+}
+
+// Timeout ends a versus match with seat losing on time.
+func (g *RPSGame) Timeout(seat string) {
+    if !g.Versus || g.Finished { return }
+    g.Finished, g.TimedOut, g.Waiting, g.pending = true, seat, nil, nil
+    g.Winner = "p1"
+    if seat == "p1" { g.Winner = "p2" }
 }
 
 func validMove(m string) bool {
@@ -0,0 +1,26 @@
+package games
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestRPSVersusHidesMoveUntilBothPlayed(t *testing.T) {
+	g := NewRPSVersus(1)
+	if !g.PlaySeat("p1", "rock") { t.Fatal("p1 move rejected") }
+	if g.LastPlayer != "" || g.Rounds != 0 { t.Fatalf("round resolved early: %+v", g) }
+	if g.PlaySeat("p1", "paper") { t.Fatal("second move in same round should be rejected") }
+	if !g.PlaySeat("p2", "paper") { t.Fatal("p2 move rejected") }
+	if !g.Finished || g.Winner != "p2" { t.Fatalf("expected p2 win, got %+v", g) }
+}
+
+func TestPublishedStatesKeepSecretsHidden(t *testing.T) {
+	g := NewRPSVersus(3)
+	g.PlaySeat("p1", "scissors")
+	h := NewHangman("hard")
+	for _, c := range []struct{ state any; secret string }{{g, "scissors"}, {h, `"` + h.Word + `"`}} {
+		b, _ := json.Marshal(c.state)
+		if strings.Contains(string(b), c.secret) { t.Fatalf("%s leaked in %s", c.secret, b) }
+	}
+}
@@ -0,0 +1,57 @@
+package games
+
+// Series tracks a best-of-N run of consecutive games between the same
+// participants. Every game of the run points at the same Series so any of
+// them shows the running score. Participants are keyed by user id, or by
+// seat name when a seat has no user (AI or anonymous play).
+type Series struct {
+	BestOf   int            `json:"bestOf"`
+	Scores   map[string]int `json:"scores"`
+	Draws    int            `json:"draws"`
+	Games    []SeriesGame   `json:"games"`
+	Finished bool           `json:"finished"`
+	Winner   string         `json:"winner,omitempty"` // empty while running or when tied
+}
+
+// SeriesGame is one finished game of a series; Winner is empty for a draw.
+type SeriesGame struct {
+	GameID string `json:"gameId"`
+	Winner string `json:"winner"`
+}
+
+// NewSeries starts a best-of-N series between participants (default best of 3).
+func NewSeries(bestOf int, participants ...string) *Series {
+	if bestOf < 1 { bestOf = 3 }
+	s := &Series{BestOf: bestOf, Scores: map[string]int{}, Games: []SeriesGame{}}
+	for _, p := range participants { s.Scores[p] = 0 }
+	return s
+}
+
+// Needed is the number of wins that clinches the series.
+func (s *Series) Needed() int { return s.BestOf/2 + 1 }
+
+// Record adds a finished game. Games are counted once and ignored after the
+// series is decided. The series also ends, possibly tied, once BestOf games
+// have been played.
+func (s *Series) Record(gameID, winner string) {
+	if s.Finished { return }
+	for _, g := range s.Games { if g.GameID == gameID { return } }
+	s.Games = append(s.Games, SeriesGame{GameID: gameID, Winner: winner})
+	if winner == "" {
+		s.Draws++
+	} else {
+		s.Scores[winner]++
+		if s.Scores[winner] >= s.Needed() {
+			s.Finished, s.Winner = true, winner
+			return
+		}
+	}
+	if len(s.Games) >= s.BestOf {
+		s.Finished = true
+		best, tied := -1, false
+		for p, sc := range s.Scores {
+			if sc > best { best, tied, s.Winner = sc, false, p } else if sc == best { tied = true }
+		}
+		if tied { s.Winner = "" }
+	}
+}
@@ -0,0 +1,16 @@
+package games
+
+import "testing"
+
+func TestSeriesBestOfThree(t *testing.T) {
+	s := NewSeries(3, "ann", "bob")
+	s.Record("g1", "ann")
+	s.Record("g1", "ann") // counted once
+	s.Record("g2", "")
+	if s.Finished || s.Scores["ann"] != 1 || s.Draws != 1 { t.Fatalf("unexpected series %+v", s) }
+	s.Record("g3", "bob")
+	if !s.Finished || s.Winner != "" { t.Fatalf("3 games played 1-1 should end tied, got %+v", s) }
+	s = NewSeries(3, "ann", "bob")
+	s.Record("a", "bob"); s.Record("b", "bob")
+	if !s.Finished || s.Winner != "bob" { t.Fatalf("2 wins should clinch best of 3, got %+v", s) }
+}
@@ -0,0 +1,210 @@
+package games
+
+import (
+	"errors"
+	"log"
+	"math/bits"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Sudoku is a 9x9 puzzle with exactly one solution. Grid holds the givens
+// and the player's entries (0 for empty); Marks are the pencil marks of
+// empty cells. Conflicts lists every [row, col] whose digit repeats in its
+// row, column or box. Difficulty is the hardest solving technique the
+// puzzle needs, see sdkLevels.
+type Sudoku struct {
+	Givens     [9][9]int   `json:"givens"`
+	Grid       [9][9]int   `json:"grid"`
+	Marks      [9][9][]int `json:"marks"`
+	Conflicts  [][2]int    `json:"conflicts"`
+	Solution   [9][9]int   `json:"-"`
+	Difficulty string      `json:"difficulty"` // easy, medium, hard or expert
+	Solved     bool        `json:"solved"`
+	Moves      int         `json:"moves"`
+	Hints      int         `json:"hints"`
+	LastHint   *SudokuHint `json:"lastHint,omitempty"`
+	Series     *Series     `json:"series,omitempty"`
+	Spectators int         `json:"spectators"`
+}
+
+// SudokuHint is a cell filled in by Hint and the technique that finds it;
+// "mistake" corrects a wrong entry and "solution" is used when no technique
+// applies.
+type SudokuHint struct {
+	Row       int    `json:"row"`
+	Col       int    `json:"col"`
+	Value     int    `json:"value"`
+	Technique string `json:"technique"`
+}
+
+var (
+	ErrBadSudokuLevel = errors.New("difficulty must be easy, medium, hard or expert")
+	ErrBadPuzzle      = errors.New("puzzle must be 81 digits, with 0 or . for empty cells")
+)
+
+// sdkBudget bounds the time spent looking for a puzzle of the asked level;
+// hard ones take the longest, a few milliseconds per grid tried.
+const sdkBudget = 2 * time.Second
+
+// NewSudoku generates a puzzle of difficulty (medium by default). It fails
+// with ErrSudokuBusy when none of that level turned up within sdkBudget.
+func NewSudoku(difficulty string) (*Sudoku, error) {
+	if difficulty == "" { difficulty = "medium" }
+	level := -1
+	for i, name := range sdkLevels {
+		if name == difficulty { level = i }
+	}
+	if level < 0 { return nil, ErrBadSudokuLevel }
+	start := time.Now()
+	puzzle, solution, err := sdkGenerate(level, rand.New(rand.NewSource(rand.Int63())), sdkBudget)
+	if err != nil { return nil, err }
+	g := &Sudoku{Difficulty: difficulty}
+	for i := range puzzle {
+		g.Givens[i/9][i%9], g.Solution[i/9][i%9] = puzzle[i], solution[i]
+	}
+	g.Reset()
+	log.Printf("[SUDOKU] New %s puzzle in %v", g.Difficulty, time.Since(start))
+	return g, nil
+}
+
+// NewSudokuFrom starts a game on a given puzzle, rating its difficulty.
+func NewSudokuFrom(puzzle string) (*Sudoku, error) {
+	givens, err := ParseSudoku(puzzle)
+	if err != nil { return nil, err }
+	solution, err := SolveSudoku(givens)
+	if err != nil { return nil, err }
+	var cells [81]int
+	for i := range cells { cells[i] = givens[i/9][i%9] }
+	g := &Sudoku{Givens: givens, Solution: solution, Difficulty: sdkLevels[sdkRate(cells)]}
+	g.Reset()
+	return g, nil
+}
+
+// ParseSudoku reads a puzzle written row by row as 81 digits; 0 or . mark
+// empty cells and whitespace is ignored.
+func ParseSudoku(s string) ([9][9]int, error) {
+	var grid [9][9]int
+	s = strings.Join(strings.Fields(s), "")
+	if len(s) != 81 { return grid, ErrBadPuzzle }
+	for i, ch := range s {
+		switch {
+		case ch == '.' || ch == '0':
+		case ch >= '1' && ch <= '9':
+			grid[i/9][i%9] = int(ch - '0')
+		default:
+			return grid, ErrBadPuzzle
+		}
+	}
+	return grid, nil
+}
+
+// Reset clears every entry and pencil mark.
+func (g *Sudoku) Reset() {
+	g.Grid = g.Givens
+	for r := range g.Marks {
+		for c := range g.Marks[r] { g.Marks[r][c] = []int{} }
+	}
+	g.Moves, g.Hints, g.LastHint = 0, 0, nil
+	g.update()
+}
+
+func (g *Sudoku) editable(row, col int) bool {
+	return !g.Solved && row >= 0 && row < 9 && col >= 0 && col < 9 && g.Givens[row][col] == 0
+}
+
+// Set enters value (0 clears the cell). The cell's pencil marks go, and so
+// does value from the marks of the cells it sees.
+func (g *Sudoku) Set(row, col, value int) bool {
+	if !g.editable(row, col) || value < 0 || value > 9 { return false }
+	g.Grid[row][col] = value
+	g.Marks[row][col] = []int{}
+	if value != 0 {
+		for _, p := range sdkPeers[row*9+col] {
+			g.Marks[p/9][p%9] = removeInt(g.Marks[p/9][p%9], value)
+		}
+	}
+	g.Moves++
+	g.update()
+	return true
+}
+
+// Mark toggles the pencil mark value on an empty cell.
+func (g *Sudoku) Mark(row, col, value int) bool {
+	if !g.editable(row, col) || g.Grid[row][col] != 0 || value < 1 || value > 9 { return false }
+	marks := g.Marks[row][col]
+	if out := removeInt(marks, value); len(out) < len(marks) {
+		g.Marks[row][col] = out
+	} else {
+		g.Marks[row][col] = append(marks, value)
+		sort.Ints(g.Marks[row][col])
+	}
+	return true
+}
+
+func removeInt(s []int, v int) []int {
+	out := []int{}
+	for _, x := range s {
+		if x != v { out = append(out, x) }
+	}
+	return out
+}
+
+// Hint fills in one cell: a wrong entry is corrected first, otherwise the
+// next cell a person could deduce from the entries so far. It returns
+// false once the puzzle is solved.
+func (g *Sudoku) Hint() bool {
+	if g.Solved { return false }
+	h := g.nextHint()
+	g.Grid[h.Row][h.Col] = h.Value
+	g.Marks[h.Row][h.Col] = []int{}
+	g.Hints++
+	g.LastHint = &h
+	g.update()
+	return true
+}
+
+func (g *Sudoku) nextHint() SudokuHint {
+	var cells [81]int
+	for i := range cells {
+		r, c := i/9, i%9
+		if v := g.Grid[r][c]; v != 0 && v != g.Solution[r][c] {
+			return SudokuHint{Row: r, Col: c, Value: g.Solution[r][c], Technique: "mistake"}
+		}
+		cells[i] = g.Grid[r][c]
+	}
+	l := newSdkLogic(cells)
+	hardest := sdkTechnique{level: -1}
+	for {
+		t, cell, digit, ok := l.step()
+		if !ok { break }
+		if t.level > hardest.level { hardest = t }
+		if cell >= 0 { return SudokuHint{Row: cell / 9, Col: cell % 9, Value: digit, Technique: hardest.name} }
+	}
+	// out of techniques: reveal the empty cell with the fewest candidates
+	best := -1
+	for i, v := range l.cells {
+		if v == 0 && (best < 0 || bits.OnesCount16(l.cand[i]) < bits.OnesCount16(l.cand[best])) { best = i }
+	}
+	return SudokuHint{Row: best / 9, Col: best % 9, Value: g.Solution[best/9][best%9], Technique: "solution"}
+}
+
+// update recomputes the conflicts and whether the grid is solved.
+func (g *Sudoku) update() {
+	g.Conflicts = [][2]int{}
+	full := true
+	for i := 0; i < 81; i++ {
+		v := g.Grid[i/9][i%9]
+		if v == 0 { full = false; continue }
+		for _, p := range sdkPeers[i] {
+			if g.Grid[p/9][p%9] == v {
+				g.Conflicts = append(g.Conflicts, [2]int{i / 9, i % 9})
+				break
+			}
+		}
+	}
+	g.Solved = full && len(g.Conflicts) == 0 && g.Grid == g.Solution
+	if g.Solved { log.Printf("[SUDOKU] Solved after %d moves and %d hints", g.Moves, g.Hints) }
+}
@@ -0,0 +1,217 @@
+package games
+
+import "math/bits"
+
+// sdkLevels names the puzzle levels by the hardest technique they need:
+// singles only, locked candidates, pairs and X-wings, or more than that.
+var sdkLevels = []string{"easy", "medium", "hard", "expert"}
+
+// sdkLogic is a grid with pencil-mark candidates that is solved the way a
+// person would, one named technique at a time.
+type sdkLogic struct {
+	cells [81]int
+	cand  [81]uint16
+}
+
+func newSdkLogic(cells [81]int) *sdkLogic {
+	l := &sdkLogic{}
+	for i := range l.cand { l.cand[i] = sdkAll }
+	for i, v := range cells {
+		if v != 0 { l.place(i, v) }
+	}
+	return l
+}
+
+func (l *sdkLogic) place(i, v int) {
+	l.cells[i], l.cand[i] = v, 0
+	for _, p := range sdkPeers[i] { l.cand[p] &^= 1 << v }
+}
+
+// eliminate removes the digits of mask from cell i and reports whether any
+// were there.
+func (l *sdkLogic) eliminate(i int, mask uint16) bool {
+	if l.cells[i] != 0 || l.cand[i]&mask == 0 { return false }
+	l.cand[i] &^= mask
+	return true
+}
+
+func (l *sdkLogic) solved() bool {
+	for _, v := range l.cells {
+		if v == 0 { return false }
+	}
+	return true
+}
+
+// sdkTechnique either places a digit (cell >= 0) or only removes
+// candidates (cell -1); ok is false when it found nothing.
+type sdkTechnique struct {
+	name  string
+	level int
+	apply func(l *sdkLogic) (cell, digit int, ok bool)
+}
+
+var sdkTechniques = []sdkTechnique{
+	{"naked single", 0, (*sdkLogic).nakedSingle},
+	{"hidden single", 0, (*sdkLogic).hiddenSingle},
+	{"locked candidates", 1, (*sdkLogic).lockedCandidates},
+	{"naked pair", 2, (*sdkLogic).nakedPair},
+	{"hidden pair", 2, (*sdkLogic).hiddenPair},
+	{"x-wing", 2, (*sdkLogic).xWing},
+}
+
+// step applies the easiest technique that makes progress.
+func (l *sdkLogic) step() (t sdkTechnique, cell, digit int, ok bool) {
+	for _, t := range sdkTechniques {
+		if cell, digit, ok := t.apply(l); ok {
+			if cell >= 0 { l.place(cell, digit) }
+			return t, cell, digit, true
+		}
+	}
+	return sdkTechnique{}, -1, 0, false
+}
+
+// sdkRate returns the level of a puzzle: the hardest technique its logical
+// solution needs, or expert when the techniques run out.
+func sdkRate(cells [81]int) int {
+	l := newSdkLogic(cells)
+	level := 0
+	for !l.solved() {
+		t, _, _, ok := l.step()
+		if !ok { return len(sdkLevels) - 1 }
+		if t.level > level { level = t.level }
+	}
+	return level
+}
+
+func digitOf(mask uint16) int { return bits.TrailingZeros16(mask) }
+
+func (l *sdkLogic) nakedSingle() (int, int, bool) {
+	for i, m := range l.cand {
+		if l.cells[i] == 0 && bits.OnesCount16(m) == 1 { return i, digitOf(m), true }
+	}
+	return -1, 0, false
+}
+
+func (l *sdkLogic) hiddenSingle() (int, int, bool) {
+	for _, unit := range sdkUnits {
+		for d := 1; d <= 9; d++ {
+			at, n := -1, 0
+			for _, c := range unit {
+				if l.cand[c]&(1<<d) != 0 { at, n = c, n+1 }
+			}
+			if n == 1 { return at, d, true }
+		}
+	}
+	return -1, 0, false
+}
+
+// lockedCandidates: when a digit's places in a box all lie on one line it
+// leaves the rest of that line (pointing), and when its places on a line
+// all lie in one box it leaves the rest of that box (claiming).
+func (l *sdkLogic) lockedCandidates() (int, int, bool) {
+	for u, unit := range sdkUnits {
+		for d := 1; d <= 9; d++ {
+			bit := uint16(1) << d
+			var where []int
+			for _, c := range unit {
+				if l.cand[c]&bit != 0 { where = append(where, c) }
+			}
+			if len(where) < 2 { continue }
+			// the other units every place shares (box for a line, lines for a box)
+			for kind := 0; kind < 3; kind++ {
+				other := sdkCellUnits[where[0]][kind]
+				if other == u { continue }
+				shared := true
+				for _, c := range where[1:] {
+					if sdkCellUnits[c][kind] != other { shared = false; break }
+				}
+				if !shared { continue }
+				changed := false
+				for _, c := range sdkUnits[other] {
+					if sdkCellUnits[c][u/9] != u && l.eliminate(c, bit) { changed = true }
+				}
+				if changed { return -1, 0, true }
+			}
+		}
+	}
+	return -1, 0, false
+}
+
+// nakedPair: two cells of a unit with the same two candidates own them.
+func (l *sdkLogic) nakedPair() (int, int, bool) {
+	for _, unit := range sdkUnits {
+		for a := 0; a < 9; a++ {
+			m := l.cand[unit[a]]
+			if bits.OnesCount16(m) != 2 { continue }
+			for b := a + 1; b < 9; b++ {
+				if l.cand[unit[b]] != m { continue }
+				changed := false
+				for k, c := range unit {
+					if k != a && k != b && l.eliminate(c, m) { changed = true }
+				}
+				if changed { return -1, 0, true }
+			}
+		}
+	}
+	return -1, 0, false
+}
+
+// hiddenPair: two digits that fit only the same two cells of a unit push
+// every other candidate out of those cells.
+func (l *sdkLogic) hiddenPair() (int, int, bool) {
+	for _, unit := range sdkUnits {
+		var places [10]uint16 // digit -> positions in the unit
+		for k, c := range unit {
+			for d := 1; d <= 9; d++ {
+				if l.cand[c]&(1<<d) != 0 { places[d] |= 1 << k }
+			}
+		}
+		for d1 := 1; d1 <= 9; d1++ {
+			if bits.OnesCount16(places[d1]) != 2 { continue }
+			for d2 := d1 + 1; d2 <= 9; d2++ {
+				if places[d2] != places[d1] { continue }
+				keep := uint16(1)<<d1 | uint16(1)<<d2
+				changed := false
+				for k, c := range unit {
+					if places[d1]&(1<<k) != 0 && l.eliminate(c, sdkAll&^keep) { changed = true }
+				}
+				if changed { return -1, 0, true }
+			}
+		}
+	}
+	return -1, 0, false
+}
+
+// xWing: when a digit fits exactly the same two columns in two rows, the
+// rest of those columns lose it (and the same with rows and columns swapped).
+func (l *sdkLogic) xWing() (int, int, bool) {
+	for _, lines := range [][2]int{{0, 9}, {9, 18}} { // rows then columns
+		for d := 1; d <= 9; d++ {
+			bit := uint16(1) << d
+			var places [9]uint16
+			for u := lines[0]; u < lines[1]; u++ {
+				for k, c := range sdkUnits[u] {
+					if l.cand[c]&bit != 0 { places[u-lines[0]] |= 1 << k }
+				}
+			}
+			for a := 0; a < 9; a++ {
+				if bits.OnesCount16(places[a]) != 2 { continue }
+				for b := a + 1; b < 9; b++ {
+					if places[b] != places[a] { continue }
+					changed := false
+					for k := 0; k < 9; k++ {
+						if places[a]&(1<<k) == 0 { continue }
+						// the crossing line through position k
+						cross := sdkUnits[(lines[0]+9)%18+k]
+						for _, c := range cross {
+							line := sdkCellUnits[c][lines[0]/9]
+							if line != lines[0]+a && line != lines[0]+b && l.eliminate(c, bit) { changed = true }
+						}
+					}
+					if changed { return -1, 0, true }
+				}
+			}
+		}
+	}
+	return -1, 0, false
+}
@@ -0,0 +1,149 @@
+package games
+
+import (
+	"errors"
+	"log"
+	"math/bits"
+	"math/rand"
+	"time"
+)
+
+var (
+	ErrNoSolution        = errors.New("sudoku has no solution")
+	ErrMultipleSolutions = errors.New("sudoku has more than one solution")
+	ErrSudokuBusy        = errors.New("no puzzle of that difficulty could be generated in time, try again")
+)
+
+// sdkUnits are the 27 rows, columns and boxes as cell indexes (r*9+c);
+// sdkPeers are the 20 cells sharing a unit with each cell.
+var (
+	sdkUnits [27][9]int
+	sdkPeers [81][]int
+	sdkCellUnits [81][3]int // row, column and box unit of each cell
+)
+
+func init() {
+	for i := 0; i < 9; i++ {
+		for j := 0; j < 9; j++ {
+			sdkUnits[i][j] = i*9 + j
+			sdkUnits[9+i][j] = j*9 + i
+			sdkUnits[18+i][j] = (i/3*3+j/3)*9 + i%3*3 + j%3
+		}
+	}
+	for u, unit := range sdkUnits {
+		for _, c := range unit { sdkCellUnits[c][u/9] = u }
+	}
+	for c := 0; c < 81; c++ {
+		seen := map[int]bool{c: true}
+		for _, u := range sdkCellUnits[c] {
+			for _, p := range sdkUnits[u] {
+				if !seen[p] {
+					seen[p] = true
+					sdkPeers[c] = append(sdkPeers[c], p)
+				}
+			}
+		}
+	}
+}
+
+// sdkAll is the candidate mask of every digit; bit d stands for digit d.
+const sdkAll uint16 = 0x3fe
+
+// SolveSudoku returns the only solution of grid (0 for empty cells), or
+// ErrNoSolution / ErrMultipleSolutions.
+func SolveSudoku(grid [9][9]int) ([9][9]int, error) {
+	var cells [81]int
+	for i := range cells { cells[i] = grid[i/9][i%9] }
+	var sol [81]int
+	switch sdkCount(cells, 2, &sol, nil) {
+	case 0:
+		return grid, ErrNoSolution
+	case 1:
+		var out [9][9]int
+		for i, v := range sol { out[i/9][i%9] = v }
+		return out, nil
+	}
+	return grid, ErrMultipleSolutions
+}
+
+// sdkCount counts the solutions of cells up to limit, storing the first one
+// in first. With rng set digits are tried in random order, which makes
+// sdkCount(empty, 1, ...) a generator of random full grids.
+func sdkCount(cells [81]int, limit int, first *[81]int, rng *rand.Rand) int {
+	var rows, cols, boxes [9]uint16
+	for i, v := range cells {
+		if v == 0 { continue }
+		bit := uint16(1) << v
+		r, c, b := i/9, i%9, i/27*3+i%9/3
+		if rows[r]&bit != 0 || cols[c]&bit != 0 || boxes[b]&bit != 0 { return 0 }
+		rows[r] |= bit
+		cols[c] |= bit
+		boxes[b] |= bit
+	}
+	found := 0
+	var walk func() bool
+	walk = func() bool {
+		// pick the empty cell with the fewest candidates
+		best, bestMask, bestN := -1, uint16(0), 10
+		for i, v := range cells {
+			if v != 0 { continue }
+			r, c, b := i/9, i%9, i/27*3+i%9/3
+			m := sdkAll &^ (rows[r] | cols[c] | boxes[b])
+			if n := bits.OnesCount16(m); n < bestN {
+				best, bestMask, bestN = i, m, n
+				if n <= 1 { break }
+			}
+		}
+		if best < 0 {
+			if found == 0 && first != nil { *first = cells }
+			found++
+			return found >= limit
+		}
+		digits := make([]int, 0, 9)
+		for d := 1; d <= 9; d++ {
+			if bestMask&(1<<d) != 0 { digits = append(digits, d) }
+		}
+		if rng != nil { rng.Shuffle(len(digits), func(a, b int) { digits[a], digits[b] = digits[b], digits[a] }) }
+		r, c, b := best/9, best%9, best/27*3+best%9/3
+		for _, d := range digits {
+			bit := uint16(1) << d
+			cells[best] = d
+			rows[r] |= bit
+			cols[c] |= bit
+			boxes[b] |= bit
+			stop := walk()
+			rows[r] &^= bit
+			cols[c] &^= bit
+			boxes[b] &^= bit
+			cells[best] = 0
+			if stop { return true }
+		}
+		return false
+	}
+	walk()
+	return found
+}
+
+// sdkGenerate digs a puzzle of the given level (see sdkLevels) out of a
+// random full grid. Cells are removed in symmetric pairs as long as the
+// solution stays unique and the puzzle does not get harder than wanted.
+// Grids are tried until one lands exactly on the level, for at most budget.
+func sdkGenerate(level int, rng *rand.Rand, budget time.Duration) (puzzle, solution [81]int, err error) {
+	start := time.Now()
+	for attempt := 1; ; attempt++ {
+		var full [81]int
+		sdkCount([81]int{}, 1, &full, rng)
+		p := full
+		for _, i := range rng.Perm(41) {
+			j := 80 - i
+			a, b := p[i], p[j]
+			p[i], p[j] = 0, 0
+			if sdkCount(p, 2, nil, nil) != 1 || sdkRate(p) > level { p[i], p[j] = a, b }
+		}
+		if sdkRate(p) == level { return p, full, nil }
+		if time.Since(start) >= budget {
+			log.Printf("[SUDOKU] no %s puzzle in %d attempts", sdkLevels[level], attempt)
+			return puzzle, solution, ErrSudokuBusy
+		}
+	}
+}
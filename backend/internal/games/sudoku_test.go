@@ -0,0 +1,121 @@
+package games
+
+import (
+	"math/rand"
+	"testing"
+)
+
+const (
+	classicPuzzle   = "53..7....6..195....98....6.8...6...34..8.3..17...2...6.6....28....419..5....8..79"
+	classicSolution = "534678912672195348198342567859761423426853791713924856961537284287419635345286179"
+)
+
+func TestSolveSudoku(t *testing.T) {
+	grid, err := ParseSudoku(classicPuzzle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sol, err := SolveSudoku(grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := ParseSudoku(classicSolution)
+	if sol != want {
+		t.Fatalf("wrong solution %v", sol)
+	}
+	if _, err := SolveSudoku([9][9]int{}); err != ErrMultipleSolutions {
+		t.Fatalf("empty grid: got %v", err)
+	}
+	grid[0][2] = 5 // second 5 in the first row
+	if _, err := SolveSudoku(grid); err != ErrNoSolution {
+		t.Fatalf("contradiction: got %v", err)
+	}
+	if _, err := ParseSudoku("123"); err != ErrBadPuzzle {
+		t.Fatalf("short puzzle: got %v", err)
+	}
+}
+
+// TestTechniquesAreSound solves generated puzzles step by step and checks
+// that no technique ever places a wrong digit or drops the right one.
+func TestTechniquesAreSound(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for level := range sdkLevels {
+		for n := 0; n < 5; n++ {
+			puzzle, solution, err := sdkGenerate(level, rng, sdkBudget)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sdkCount(puzzle, 2, nil, nil) != 1 {
+				t.Fatal("generated puzzle is not unique")
+			}
+			rating := sdkRate(puzzle)
+			if rating != level {
+				t.Fatalf("asked for %s, got %s", sdkLevels[level], sdkLevels[rating])
+			}
+			l := newSdkLogic(puzzle)
+			for {
+				tech, _, _, ok := l.step()
+				if !ok {
+					break
+				}
+				for i, v := range l.cells {
+					if (v != 0 && v != solution[i]) || (v == 0 && l.cand[i]&(1<<solution[i]) == 0) {
+						t.Fatalf("%s broke cell %d", tech.name, i)
+					}
+				}
+			}
+			if rating < len(sdkLevels)-1 && !l.solved() {
+				t.Fatalf("level %s puzzle not solved by its techniques", sdkLevels[rating])
+			}
+		}
+	}
+}
+
+func TestSudokuPlay(t *testing.T) {
+	g, err := NewSudokuFrom(classicPuzzle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.Difficulty != "easy" {
+		t.Fatalf("classic puzzle needs singles only, rated %s", g.Difficulty)
+	}
+	if g.Set(0, 0, 1) {
+		t.Fatal("givens cannot change")
+	}
+	g.Mark(0, 2, 4)
+	g.Mark(0, 2, 1)
+	g.Mark(0, 3, 1)
+	g.Mark(0, 3, 6)
+	g.Mark(0, 3, 6)
+	if len(g.Marks[0][2]) != 2 || g.Marks[0][2][0] != 1 || len(g.Marks[0][3]) != 1 {
+		t.Fatalf("marks should toggle and stay sorted: %v %v", g.Marks[0][2], g.Marks[0][3])
+	}
+	g.Set(0, 2, 1)
+	if len(g.Marks[0][2]) != 0 || len(g.Marks[0][3]) != 0 {
+		t.Fatal("entering a digit clears its marks and the peers' marks of that digit")
+	}
+	g.Set(0, 2, 5) // clashes with the given 5 in the same row
+	if len(g.Conflicts) != 2 {
+		t.Fatalf("expected both fives in conflict, got %v", g.Conflicts)
+	}
+	g.Hint()
+	if h := g.LastHint; h.Technique != "mistake" || h.Row != 0 || h.Col != 2 || h.Value != 4 || len(g.Conflicts) != 0 {
+		t.Fatalf("the wrong entry should be corrected first, got %+v", h)
+	}
+	for g.Hint() {
+		if g.LastHint.Technique != "naked single" && g.LastHint.Technique != "hidden single" {
+			t.Fatalf("easy puzzle hinted with %s", g.LastHint.Technique)
+		}
+	}
+	if !g.Solved || g.Hints != 51 {
+		t.Fatalf("hints should finish the puzzle, solved=%v hints=%d", g.Solved, g.Hints)
+	}
+}
+
+func TestSudokuGeneratorGivesUp(t *testing.T) {
+	// a hard puzzle takes a few dozen grids; with no time only the first
+	// one is tried, and that one falls short with this seed
+	if _, _, err := sdkGenerate(2, rand.New(rand.NewSource(1)), 0); err != ErrSudokuBusy {
+		t.Fatalf("expected ErrSudokuBusy, got %v", err)
+	}
+}
@@ -1,9 +1,16 @@
 package games
 
-import "log"
+import (
+	"errors"
+	"log"
+	"math"
+	"math/rand"
+	"sort"
+)
 
-// TicTacToe represents a simple tic tac toe game state.
-// Board has 9 cells indexed 0..8
+// TicTacToe represents an m,n,k game state: Rows x Cols cells and K in a row
+// wins (3x3x3 classic, 15x15x5 Gomoku).
+// Board has Rows*Cols cells indexed row by row from 0
 // CurrentPlayer is either "X" or "O"
 // Winner is "X", "O", or "D" for draw, or "" if ongoing
 type Move struct {
@@ -12,42 +19,97 @@ type Move struct {
 }
 
 type TicTacToe struct {
-	Board         [9]string `json:"board"`
-	CurrentPlayer string    `json:"currentPlayer"`
-	Winner        string    `json:"winner"`
-	WinningLine   [3]int    `json:"winningLine"`
-	Moves         []Move    `json:"moves"`
-	VsAI          bool      `json:"vsAI"`
-	Difficulty    string    `json:"difficulty"` // "easy" or "optimal" (only relevant when VsAI)
+	Board          []string  `json:"board"`
+	Rows           int       `json:"rows"`
+	Cols           int       `json:"cols"`
+	K              int       `json:"k"` // marks in a row needed to win
+	CurrentPlayer  string    `json:"currentPlayer"`
+	Winner         string    `json:"winner"`
+	WinningLine    []int     `json:"winningLine"` // cells of the winning run, ascending
+	Moves          []Move    `json:"moves"`
+	VsAI           bool      `json:"vsAI"`
+	Difficulty     string    `json:"difficulty"` // beginner, easy, medium, hard or optimal (only relevant when VsAI)
+	HumanPlays     string    `json:"humanPlays,omitempty"` // the human's mark against the AI; the AI plays the other
+	Series         *Series   `json:"series,omitempty"`
+	Spectators     int       `json:"spectators"` // live viewers, filled in by the server
+	Clock          *Clock    `json:"clock,omitempty"`
+	TimedOut       string    `json:"timedOut,omitempty"`       // mark that lost on time
+	Correspondence bool      `json:"correspondence,omitempty"` // slow play, players are notified of their turn
+	Quiet          bool      `json:"-"` // no move logs, for bot-vs-bot runs
 }
 
-// NewTicTacToe creates a new game; if vsAI true, player X is human and O is AI.
+// NewTicTacToe creates a new classic 3x3 game; if vsAI true, player X is human and O is AI
+// (see PlayAs to swap). An empty or unknown difficulty means easy; use
+// NewTicTacToeSized to have it checked.
 func NewTicTacToe(vsAI bool, difficulty string) *TicTacToe {
-	if difficulty == "" { difficulty = "easy" }
-	g := &TicTacToe{CurrentPlayer: "X", VsAI: vsAI, WinningLine: [3]int{-1, -1, -1}, Difficulty: difficulty}
-	for i := 0; i < 9; i++ {
-		g.Board[i] = ""
-	}
-	log.Printf("[TTT] New game created vsAI=%v difficulty=%s", vsAI, difficulty)
+	if _, ok := tttTemperature[difficulty]; !ok { difficulty = "easy" }
+	g, _ := NewTicTacToeSized(3, 3, 3, vsAI, difficulty)
 	return g
 }
 
-// Reset the board while keeping mode (VsAI).
+var (
+	ErrBadBoardSize  = errors.New("board needs 3 to 19 rows and columns and a win length between 3 and the longer side")
+	ErrBadDifficulty = errors.New("difficulty must be beginner, easy, medium, hard or optimal")
+	ErrBadMark       = errors.New(`humanPlays must be "X" or "O"`)
+)
+
+// tttTemperature is the softmax temperature of each difficulty: the AI plays
+// each cell with probability proportional to exp(score/T), so the hotter
+// levels blunder into lost positions more often while optimal (0) always
+// plays a best move.
+var tttTemperature = map[string]float64{"beginner": 10, "easy": 4, "medium": 2, "hard": 1, "optimal": 0}
+
+// NewTicTacToeSized creates an m,n,k game with rows x cols cells where k
+// marks in a row win, e.g. 4x4x4 or 15x15x5 (Gomoku). Bad sizes return
+// ErrBadBoardSize and unknown difficulties ErrBadDifficulty.
+func NewTicTacToeSized(rows, cols, k int, vsAI bool, difficulty string) (*TicTacToe, error) {
+	if rows < 3 || cols < 3 || rows > 19 || cols > 19 || k < 3 || (k > rows && k > cols) { return nil, ErrBadBoardSize }
+	if difficulty == "" { difficulty = "easy" }
+	if _, ok := tttTemperature[difficulty]; !ok { return nil, ErrBadDifficulty }
+	g := &TicTacToe{Board: make([]string, rows*cols), Rows: rows, Cols: cols, K: k, CurrentPlayer: "X", VsAI: vsAI, WinningLine: []int{}, Difficulty: difficulty}
+	if vsAI { g.HumanPlays = "X" }
+	log.Printf("[TTT] New %dx%d k=%d game created vsAI=%v difficulty=%s", rows, cols, k, vsAI, difficulty)
+	return g, nil
+}
+
+// PlayAs sets the mark the human plays against the AI and restarts the
+// game, so an AI playing X opens straight away.
+func (g *TicTacToe) PlayAs(mark string) error {
+	if mark != "X" && mark != "O" { return ErrBadMark }
+	if !g.VsAI { return nil }
+	g.HumanPlays = mark
+	g.Reset()
+	return nil
+}
+
+// aiMark is the mark the AI plays in a game against it.
+func (g *TicTacToe) aiMark() string { return opposite(g.HumanPlays) }
+
+// classic reports whether g is the standard 3x3 game solved by minimax.
+func (g *TicTacToe) classic() bool { return g.Rows == 3 && g.Cols == 3 && g.K == 3 }
+
+// logf logs unless the game is quiet.
+func (g *TicTacToe) logf(format string, args ...any) { if !g.Quiet { log.Printf(format, args...) } }
+
+// Reset the board while keeping mode (VsAI) and size.
 func (g *TicTacToe) Reset() {
-	for i := 0; i < 9; i++ { g.Board[i] = "" }
+	g.Board = make([]string, g.Rows*g.Cols)
 	g.CurrentPlayer = "X"
 	g.Winner = ""
 	g.Moves = nil
-	g.WinningLine = [3]int{-1,-1,-1}
-	log.Printf("[TTT] Game reset (vsAI=%v difficulty=%s)", g.VsAI, g.Difficulty)
+	g.WinningLine = []int{}
+	g.TimedOut = ""
+	if g.Clock != nil { g.Clock.Reset() }
+	g.logf("[TTT] Game reset (vsAI=%v difficulty=%s)", g.VsAI, g.Difficulty)
+	if g.VsAI && g.aiMark() == "X" { g.aiMove() } // the AI opens
 }
 
 func (g *TicTacToe) MakeMove(pos int) bool {
-	if pos < 0 || pos >= 9 || g.Board[pos] != "" || g.Winner != "" {
-		log.Printf("[TTT] Reject move pos=%d by %s (winner=%q existing=%q)", pos, g.CurrentPlayer, g.Winner, g.Board[pos])
+	if pos < 0 || pos >= len(g.Board) || g.Board[pos] != "" || g.Winner != "" {
+		g.logf("[TTT] Reject move pos=%d by %s (winner=%q)", pos, g.CurrentPlayer, g.Winner)
 		return false
 	}
-	log.Printf("[TTT] Player %s move at %d", g.CurrentPlayer, pos)
+	g.logf("[TTT] Player %s move at %d", g.CurrentPlayer, pos)
 	g.Board[pos] = g.CurrentPlayer
 	g.Moves = append(g.Moves, Move{Pos: pos, Player: g.CurrentPlayer})
 	g.checkWinner()
@@ -57,11 +119,11 @@ func (g *TicTacToe) MakeMove(pos int) bool {
 		} else {
 			g.CurrentPlayer = "X"
 		}
-		log.Printf("[TTT] Turn switched, now %s", g.CurrentPlayer)
+		g.logf("[TTT] Turn switched, now %s", g.CurrentPlayer)
 	}
 	// If vs AI and it's AI's turn and game still ongoing, make AI move.
-	if g.VsAI && g.CurrentPlayer == "O" && g.Winner == "" {
-		log.Printf("[TTT] Invoking AI move (difficulty=%s)", g.Difficulty)
+	if g.VsAI && g.CurrentPlayer == g.aiMark() && g.Winner == "" {
+		g.logf("[TTT] Invoking AI move (difficulty=%s)", g.Difficulty)
 		g.aiMove()
 	}
 	return true
@@ -69,138 +131,232 @@ func (g *TicTacToe) MakeMove(pos int) bool {
 
 func (g *TicTacToe) aiMove() {
 	if g.Winner != "" { return }
+	mark := g.aiMark()
 	var best int
-	var score int
-	if g.Difficulty == "optimal" {
-		score, best = g.minimax(g.Board, "O", 0)
+	if g.classic() {
+		var score int
+		score, best = g.softmaxMove(mark, g.Difficulty)
+		g.logf("[TTT][AI] %s chose move=%d score=%d", g.Difficulty, best, score)
 	} else {
-		best = g.heuristicMove()
+		best = g.searchMove(mark, g.Difficulty)
 	}
 	if best >= 0 {
-		if g.Difficulty == "optimal" { log.Printf("[TTT][AI] minimax chose move=%d score=%d", best, score) } else { log.Printf("[TTT][AI] heuristic chose move=%d", best) }
-		g.Board[best] = "O"
-		g.Moves = append(g.Moves, Move{Pos: best, Player: "O"})
+		g.Board[best] = mark
+		g.Moves = append(g.Moves, Move{Pos: best, Player: mark})
 		g.checkWinner()
-		if g.Winner == "" { g.CurrentPlayer = "X" }
-		if g.Winner != "" { log.Printf("[TTT][AI] Winner after AI move: %s", g.Winner) } else { log.Printf("[TTT][AI] Move applied, next turn %s", g.CurrentPlayer) }
+		if g.Winner == "" { g.CurrentPlayer = opposite(mark) }
+		if g.Winner != "" { g.logf("[TTT][AI] Winner after AI move: %s", g.Winner) } else { g.logf("[TTT][AI] Move applied, next turn %s", g.CurrentPlayer) }
 	}
 }
 
-func (g *TicTacToe) heuristicMove() int {
+// heuristicFor picks a move for mark: win, block, center, corner, anything.
+func (g *TicTacToe) heuristicFor(mark string) int {
+	other := "X"
+	if mark == "X" { other = "O" }
 	best := -1
 	tryMove := func(p int, mark string) bool {
 		if g.Board[p] != "" { return false }
 		g.Board[p] = mark
-		won := g.isWinning(mark)
+		won := g.runAt(g.Board, p) != nil
 		g.Board[p] = ""
 		return won
 	}
-	for i:=0;i<9;i++ { if tryMove(i,"O") { best = i; break } }
-	if best == -1 { for i:=0;i<9;i++ { if tryMove(i,"X") { best = i; break } } }
+	for i := range g.Board { if tryMove(i,mark) { best = i; break } }
+	if best == -1 { for i := range g.Board { if tryMove(i,other) { best = i; break } } }
+	if best == -1 && !g.classic() {
+		if c := g.candidates(g.Board, mark); len(c) > 0 { best = c[0] } // strongest threat
+	}
 	if best == -1 && g.Board[4] == "" { best = 4 }
 	if best == -1 { for _, c := range []int{0,2,6,8} { if g.Board[c] == "" { best = c; break } } }
-	if best == -1 { for i:=0;i<9;i++ { if g.Board[i] == "" { best = i; break } } }
+	if best == -1 { for i := range g.Board { if g.Board[i] == "" { best = i; break } } }
 	return best
 }
 
-// minimax implements a depth-aware perfect solver. Higher scores favor O (the AI),
-// with faster wins preferred and slower losses chosen to prolong the game.
-func (g *TicTacToe) minimax(board [9]string, player string, depth int) (score int, move int) {
-	// Terminal checks (depth aware scoring)
-	if g.boardWin(board, "O") { return 10 - depth, -1 } // sooner win -> bigger score
-	if g.boardWin(board, "X") { return depth - 10, -1 } // later loss -> higher (less negative) score
-	full := true
-	for _, c := range board { if c == "" { full = false; break } }
-	if full { return 0, -1 }
-
-	if player == "O" { // maximizing player
-		bestScore := -1000
-		bestMove := -1
-		for i := 0; i < 9; i++ {
-			if board[i] == "" {
-				board[i] = "O"
-				sc, _ := g.minimax(board, "X", depth+1)
-				board[i] = ""
-				if sc > bestScore { bestScore = sc; bestMove = i }
-			}
-		}
-		return bestScore, bestMove
-	}
-	// minimizing for X
-	bestScore := 1000
-	bestMove := -1
+// scoredMove is a cell with its minimax score for the side playing it: a
+// win k plies ahead scores 10-k, a loss k-10 and a draw 0.
+type scoredMove struct {
+	Pos   int `json:"pos"`
+	Score int `json:"score"`
+}
+
+// scoreMoves scores every empty cell of a classic board for player, in cell
+// order, using the precomputed engine table. It returns nil when the game is over.
+func (g *TicTacToe) scoreMoves(board []string, player string) []scoredMove {
+	p := tttBitsOf(board)
+	if p.over() { return nil }
+	var out []scoredMove
 	for i := 0; i < 9; i++ {
-		if board[i] == "" {
-			board[i] = "X"
-			sc, _ := g.minimax(board, "O", depth+1)
-			board[i] = ""
-			if sc < bestScore { bestScore = sc; bestMove = i }
-		}
+		if !p.empty(i) { continue }
+		v := -tttValue(p.with(i))
+		// engine values count marks on the final board; make them plies from here
+		if v > 0 { v += p.pieces() } else if v < 0 { v -= p.pieces() }
+		out = append(out, scoredMove{i, v})
+	}
+	return out
+}
+
+// minimax returns the best cell for player on a classic board and its
+// score from player's side, so faster wins and slower losses are preferred.
+// Ties go to the lowest cell.
+func (g *TicTacToe) minimax(board []string, player string) (score int, move int) {
+	switch {
+	case g.boardWon(board, player):
+		return 10, -1
+	case g.boardWon(board, opposite(player)):
+		return -10, -1
+	}
+	best := scoredMove{-1, 0} // a full board is a draw
+	for i, m := range g.scoreMoves(board, player) {
+		if i == 0 || m.Score > best.Score { best = m }
 	}
-	return bestScore, bestMove
+	return best.Score, best.Pos
 }
 
-func (g *TicTacToe) boardWin(board [9]string, mark string) bool {
-	lines := [8][3]int{{0,1,2},{3,4,5},{6,7,8},{0,3,6},{1,4,7},{2,5,8},{0,4,8},{2,4,6}}
-	for _, l := range lines { if board[l[0]]==mark && board[l[1]]==mark && board[l[2]]==mark { return true } }
-	return false
+// boardWon reports whether mark has three in a row on a classic board.
+func (g *TicTacToe) boardWon(board []string, mark string) bool {
+	p := tttBitsOf(board)
+	if mark == "X" { return tttWon(p.x) }
+	return tttWon(p.o)
 }
 
-func (g *TicTacToe) isWinning(mark string) bool {
-	lines := [8][3]int{{0,1,2},{3,4,5},{6,7,8},{0,3,6},{1,4,7},{2,5,8},{0,4,8},{2,4,6}}
-	for _, l := range lines {
-		if g.Board[l[0]] == mark && g.Board[l[1]] == mark && g.Board[l[2]] == mark { return true }
+// softmaxMove picks a move for mark at difficulty and returns it with its
+// score for mark: optimal plays minimax, the other levels sample the scored
+// moves with their softmax temperature.
+func (g *TicTacToe) softmaxMove(mark, difficulty string) (score int, move int) {
+	temp := tttTemperature[difficulty]
+	if temp == 0 { return g.minimax(g.Board, mark) }
+	moves := g.scoreMoves(g.Board, mark)
+	if len(moves) == 0 { return 0, -1 }
+	scores := make([]float64, len(moves))
+	for i, m := range moves { scores[i] = float64(m.Score) }
+	m := moves[softmaxPick(scores, temp)]
+	return m.Score, m.Pos
+}
+
+// softmaxPick samples an index of scores with probability proportional to
+// exp(score/temp).
+func softmaxPick(scores []float64, temp float64) int {
+	top := scores[0]
+	for _, s := range scores { if s > top { top = s } }
+	weights := make([]float64, len(scores))
+	total := 0.0
+	for i, s := range scores {
+		weights[i] = math.Exp((s - top) / temp) // shifted by the top score so exp cannot overflow
+		total += weights[i]
+	}
+	r := rand.Float64() * total
+	for i, w := range weights {
+		if r -= w; r < 0 { return i }
 	}
-	return false
+	return len(scores) - 1
 }
 
-func (g *TicTacToe) checkWinner() {
-	lines := [8][3]int{{0, 1, 2}, {3, 4, 5}, {6, 7, 8}, {0, 3, 6}, {1, 4, 7}, {2, 5, 8}, {0, 4, 8}, {2, 4, 6}}
-	for _, l := range lines {
-		a, b, c := g.Board[l[0]], g.Board[l[1]], g.Board[l[2]]
-		if a != "" && a == b && b == c {
-			g.Winner = a
-			g.WinningLine = l
-			log.Printf("[TTT] Winner detected: %s line=%v", a, l)
-			return
+// SuggestMove returns the cell the AI would play for the side to move at
+// difficulty ("heuristic" for the plain win, block, center, corner rule), or
+// -1 when the game is over or the difficulty is unknown.
+func (g *TicTacToe) SuggestMove(difficulty string) int {
+	if g.Winner != "" { return -1 }
+	if difficulty == "heuristic" { return g.heuristicFor(g.CurrentPlayer) }
+	if _, ok := tttTemperature[difficulty]; !ok { return -1 }
+	if !g.classic() { return g.searchMove(g.CurrentPlayer, difficulty) }
+	_, pos := g.softmaxMove(g.CurrentPlayer, difficulty)
+	return pos
+}
+
+// tttDirs are the line directions as (row, col) steps: across, down and both diagonals.
+var tttDirs = [4][2]int{{0, 1}, {1, 0}, {1, 1}, {1, -1}}
+
+// runAt returns the cells (ascending) of a run of at least K equal marks
+// through pos, or nil. Win detection only looks at lines through the last
+// move, so it costs O(K) instead of scanning every line of the board.
+func (g *TicTacToe) runAt(board []string, pos int) []int {
+	mark := board[pos]
+	if mark == "" { return nil }
+	r0, c0 := pos/g.Cols, pos%g.Cols
+	for _, d := range tttDirs {
+		run := []int{pos}
+		for _, sign := range []int{-1, 1} {
+			r, c := r0+sign*d[0], c0+sign*d[1]
+			for r >= 0 && r < g.Rows && c >= 0 && c < g.Cols && board[r*g.Cols+c] == mark {
+				run = append(run, r*g.Cols+c)
+				r, c = r+sign*d[0], c+sign*d[1]
+			}
+		}
+		if len(run) >= g.K {
+			sort.Ints(run)
+			return run
 		}
 	}
-	full := true
-	for _, cell := range g.Board {
-		if cell == "" {
-			full = false
-			break
+	return nil
+}
+
+func (g *TicTacToe) checkWinner() {
+	if len(g.Moves) > 0 {
+		if run := g.runAt(g.Board, g.Moves[len(g.Moves)-1].Pos); run != nil {
+			g.Winner = g.Board[run[0]]
+			g.WinningLine = run
+			g.logf("[TTT] Winner detected: %s line=%v", g.Winner, run)
+			return
 		}
 	}
-	if full {
+	if len(g.Moves) == len(g.Board) {
 		g.Winner = "D"
-		log.Printf("[TTT] Game draw")
+		g.logf("[TTT] Game draw")
 	}
 }
 
-// Undo reverts last move; if vs AI it reverts AI + previous human move to keep turn with human.
+// Undo reverts last move; if vs AI it reverts the AI reply + previous human move to
+// keep turn with human, whichever mark the AI plays.
 func (g *TicTacToe) Undo() bool {
 	if len(g.Moves) == 0 { return false }
-	log.Printf("[TTT] Undo requested (vsAI=%v moves=%d)", g.VsAI, len(g.Moves))
+	g.logf("[TTT] Undo requested (vsAI=%v moves=%d)", g.VsAI, len(g.Moves))
+	last := g.Moves[len(g.Moves)-1]
+	// If vs AI and last was AI, also remove previous human move to give human chance again
+	pair := g.VsAI && last.Player == g.aiMark()
+	if pair && len(g.Moves) == 1 { return false } // only the AI's opening move, nothing of the human's to take back
 	// If game ended, clear winner so we can resume after undo
 	g.Winner = ""
-	g.WinningLine = [3]int{-1,-1,-1}
+	g.WinningLine = []int{}
 	// Remove last move
-	last := g.Moves[len(g.Moves)-1]
 	g.Board[last.Pos] = ""
 	g.Moves = g.Moves[:len(g.Moves)-1]
-	log.Printf("[TTT] Removed move %s@%d", last.Player, last.Pos)
-	// If vs AI and last was AI, also remove previous human move to give human chance again
-	if g.VsAI && last.Player == "O" && len(g.Moves) > 0 {
+	g.logf("[TTT] Removed move %s@%d", last.Player, last.Pos)
+	if pair {
 		prev := g.Moves[len(g.Moves)-1]
 		g.Board[prev.Pos] = ""
 		g.Moves = g.Moves[:len(g.Moves)-1]
-		log.Printf("[TTT] Also removed paired human move %s@%d", prev.Player, prev.Pos)
+		g.logf("[TTT] Also removed paired human move %s@%d", prev.Player, prev.Pos)
 	}
 	// Determine current player
 	if len(g.Moves) == 0 { g.CurrentPlayer = "X" } else { g.CurrentPlayer = opposite(g.Moves[len(g.Moves)-1].Player) }
-	log.Printf("[TTT] Undo complete; next player %s", g.CurrentPlayer)
+	g.logf("[TTT] Undo complete; next player %s", g.CurrentPlayer)
 	return true
 }
 
 func opposite(p string) string { if p=="X" { return "O" }; return "X" }
+
+// AutoMove plays for the side to move when its clock runs out: a random
+// empty cell, or the heuristic AI's choice.
+func (g *TicTacToe) AutoMove(random bool) bool {
+	if g.Winner != "" { return false }
+	if !random { return g.MakeMove(g.heuristicFor(g.CurrentPlayer)) }
+	empty := g.Legal()
+	if len(empty) == 0 { return false }
+	return g.MakeMove(empty[rand.Intn(len(empty))])
+}
+
+// Legal lists the empty cells while the game is on.
+func (g *TicTacToe) Legal() []int {
+	if g.Winner != "" { return nil }
+	var empty []int
+	for i, c := range g.Board { if c == "" { empty = append(empty, i) } }
+	return empty
+}
+
+// Timeout ends the game with mark losing on time.
+func (g *TicTacToe) Timeout(mark string) {
+	if g.Winner != "" { return }
+	g.Winner, g.TimedOut = "O", mark
+	if mark == "O" { g.Winner = "X" }
+	g.logf("[TTT] %s lost on time", mark)
+}
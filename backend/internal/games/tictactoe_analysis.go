@@ -0,0 +1,93 @@
+package games
+
+import (
+	"errors"
+	"sort"
+)
+
+// ErrNoAnalysis is returned for boards the perfect engine cannot solve.
+var ErrNoAnalysis = errors.New("analysis is only available on the classic 3x3 board")
+
+// MoveEval is a legal move with its outcome under perfect play for the
+// player making it.
+type MoveEval struct {
+	Pos    int    `json:"pos"`
+	Score  int    `json:"score"`           // minimax score, see scoredMove
+	Result string `json:"result"`          // win, draw or loss
+	Plies  int    `json:"plies,omitempty"` // moves by both sides until that win or loss, this one included
+}
+
+// ReviewedMove labels a played move against the best one available then:
+// best, inaccuracy (same result, but a slower win or a faster loss) or
+// blunder (a worse result).
+type ReviewedMove struct {
+	Move
+	Label     string `json:"label"`
+	Score     int    `json:"score"`
+	BestScore int    `json:"bestScore"`
+	Best      []int  `json:"best"` // cells that scored BestScore
+}
+
+// TTTAnalysis is the engine's view of a game: every legal move for the side
+// to move (best first) and, once the game is over, a review of each move.
+type TTTAnalysis struct {
+	ToMove string         `json:"toMove,omitempty"`
+	Moves  []MoveEval     `json:"moves"`
+	Review []ReviewedMove `json:"review,omitempty"`
+}
+
+func evalOf(m scoredMove) MoveEval {
+	e := MoveEval{Pos: m.Pos, Score: m.Score, Result: "draw"}
+	switch {
+	case m.Score > 0:
+		e.Result, e.Plies = "win", 10-m.Score
+	case m.Score < 0:
+		e.Result, e.Plies = "loss", 10+m.Score
+	}
+	return e
+}
+
+// Analyze scores the current position and, for finished games, reviews
+// the moves that led to it.
+func (g *TicTacToe) Analyze() (*TTTAnalysis, error) {
+	if !g.classic() { return nil, ErrNoAnalysis }
+	a := &TTTAnalysis{Moves: []MoveEval{}}
+	if g.Winner == "" {
+		a.ToMove = g.CurrentPlayer
+		for _, m := range g.scoreMoves(g.Board, g.CurrentPlayer) { a.Moves = append(a.Moves, evalOf(m)) }
+		sort.SliceStable(a.Moves, func(i, j int) bool { return a.Moves[i].Score > a.Moves[j].Score })
+		return a, nil
+	}
+	a.Review = g.review()
+	return a, nil
+}
+
+// review replays Moves from an empty board, scoring each against the
+// alternatives the player had at that point.
+func (g *TicTacToe) review() []ReviewedMove {
+	board := make([]string, 9)
+	out := []ReviewedMove{}
+	for _, mv := range g.Moves {
+		r := ReviewedMove{Move: mv, BestScore: -127, Best: []int{}}
+		for _, m := range g.scoreMoves(board, mv.Player) {
+			if m.Pos == mv.Pos { r.Score = m.Score }
+			switch {
+			case m.Score > r.BestScore:
+				r.BestScore, r.Best = m.Score, []int{m.Pos}
+			case m.Score == r.BestScore:
+				r.Best = append(r.Best, m.Pos)
+			}
+		}
+		switch {
+		case r.Score == r.BestScore:
+			r.Label = "best"
+		case evalOf(scoredMove{Score: r.Score}).Result == evalOf(scoredMove{Score: r.BestScore}).Result:
+			r.Label = "inaccuracy"
+		default:
+			r.Label = "blunder"
+		}
+		out = append(out, r)
+		board[mv.Pos] = mv.Player
+	}
+	return out
+}
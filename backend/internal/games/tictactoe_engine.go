@@ -0,0 +1,151 @@
+package games
+
+import "math/bits"
+
+// Perfect play for the classic 3x3 board. Positions are two 9-bit masks
+// (bit i set when cell i holds that mark). Every reachable position is
+// solved once at init by negamax with alpha-beta pruning and a transposition
+// table keyed by the position's canonical form under the board's eight
+// rotations and reflections, so an AI move is nine table lookups.
+
+type tttBits struct{ x, o uint16 }
+
+// tttLines are the eight winning lines as masks.
+var tttLines = [8]uint16{0007, 0070, 0700, 0111, 0222, 0444, 0421, 0124}
+
+// tttOrder tries the center, then corners, then edges, which prunes best.
+var tttOrder = [9]int{4, 0, 2, 6, 8, 1, 3, 5, 7}
+
+// tttSyms[s][i] is where cell i lands under symmetry s.
+var tttSyms [8][9]int
+
+const (
+	tttExact = iota
+	tttLower
+	tttUpper
+)
+
+type tttEntry struct{ value, flag int8 }
+
+// tttTable holds exact values for every reachable position once init has
+// run; it is only read afterwards, so concurrent games can share it.
+var tttTable = map[uint32]tttEntry{}
+
+func init() {
+	for s := range tttSyms {
+		for i := 0; i < 9; i++ {
+			r, c := i/3, i%3
+			for k := 0; k < s%4; k++ { r, c = c, 2-r } // rotate a quarter turn
+			if s >= 4 { c = 2 - c }                   // then mirror
+			tttSyms[s][i] = r*3 + c
+		}
+	}
+	seen := map[uint32]bool{}
+	var walk func(p tttBits)
+	walk = func(p tttBits) {
+		key := p.key()
+		if seen[key] { return }
+		seen[key] = true
+		tttNegamax(tttTable, p, -127, 127) // a full window makes the root entry exact
+		if p.over() { return }
+		for i := 0; i < 9; i++ {
+			if p.empty(i) { walk(p.with(i)) }
+		}
+	}
+	walk(tttBits{})
+}
+
+func tttWon(m uint16) bool {
+	for _, l := range tttLines {
+		if m&l == l { return true }
+	}
+	return false
+}
+
+func tttBitsOf(board []string) tttBits {
+	var p tttBits
+	for i, c := range board {
+		switch c {
+		case "X":
+			p.x |= 1 << i
+		case "O":
+			p.o |= 1 << i
+		}
+	}
+	return p
+}
+
+func (p tttBits) pieces() int        { return bits.OnesCount16(p.x | p.o) }
+func (p tttBits) xToMove() bool      { return bits.OnesCount16(p.x) == bits.OnesCount16(p.o) }
+func (p tttBits) empty(i int) bool   { return (p.x|p.o)&(1<<i) == 0 }
+func (p tttBits) over() bool         { return tttWon(p.x) || tttWon(p.o) || p.pieces() == 9 }
+
+// with places the side to move's mark on cell i.
+func (p tttBits) with(i int) tttBits {
+	if p.xToMove() { p.x |= 1 << i } else { p.o |= 1 << i }
+	return p
+}
+
+// key is the smallest encoding of p over all eight symmetries, so mirrored
+// and rotated positions share one table entry.
+func (p tttBits) key() uint32 {
+	best := ^uint32(0)
+	for s := range tttSyms {
+		var x, o uint32
+		for i := 0; i < 9; i++ {
+			if p.x&(1<<i) != 0 { x |= 1 << tttSyms[s][i] }
+			if p.o&(1<<i) != 0 { o |= 1 << tttSyms[s][i] }
+		}
+		if k := x<<9 | o; k < best { best = k }
+	}
+	return best
+}
+
+// tttNegamax returns the value of p for the side to move: 10 minus the
+// number of marks on the board when the game is won (so quicker wins score
+// higher and slower losses lose less), its negation for a loss, 0 for a draw.
+// Values depend only on the position, which is what lets the table share
+// them between move orders.
+func tttNegamax(table map[uint32]tttEntry, p tttBits, alpha, beta int) int {
+	last := p.o // the side that just moved
+	if !p.xToMove() { last = p.x }
+	if tttWon(last) { return -(10 - p.pieces()) }
+	if p.pieces() == 9 { return 0 }
+	key, alphaOrig := p.key(), alpha
+	if e, ok := table[key]; ok {
+		v := int(e.value)
+		switch {
+		case e.flag == tttExact:
+			return v
+		case e.flag == tttLower && v > alpha:
+			alpha = v
+		case e.flag == tttUpper && v < beta:
+			beta = v
+		}
+		if alpha >= beta { return v }
+	}
+	best := -127
+	for _, i := range tttOrder {
+		if !p.empty(i) { continue }
+		v := -tttNegamax(table, p.with(i), -beta, -alpha)
+		if v > best { best = v }
+		if v > alpha { alpha = v }
+		if alpha >= beta { break }
+	}
+	flag := int8(tttExact)
+	if best <= alphaOrig {
+		flag = tttUpper
+	} else if best >= beta {
+		flag = tttLower
+	}
+	table[key] = tttEntry{value: int8(best), flag: flag}
+	return best
+}
+
+// tttValue is the exact value of p for the side to move. Positions missing
+// from the precomputed table (only unreachable ones) are searched with a
+// private table so the shared one is never written after init.
+func tttValue(p tttBits) int {
+	if e, ok := tttTable[p.key()]; ok && e.flag == tttExact { return int(e.value) }
+	return tttNegamax(map[uint32]tttEntry{}, p, -127, 127)
+}
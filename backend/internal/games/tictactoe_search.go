@@ -0,0 +1,168 @@
+package games
+
+import (
+	"math"
+	"sort"
+)
+
+// Search for boards other than the classic 3x3, which is still solved
+// exactly by minimax. Big boards cannot be searched to the end, so the AI
+// looks a few plies ahead (negamax with alpha-beta), only considers the most
+// threatening cells near existing marks, and scores the leaves by counting
+// open runs.
+
+const (
+	tttWinScore   = 1 << 50
+	tttSearchWide = 10 // candidate moves searched per node
+)
+
+// searchDepth is how many plies the "optimal" AI looks ahead on a board.
+func (g *TicTacToe) searchDepth() int {
+	if len(g.Board) <= 16 { return 6 }
+	return 4
+}
+
+// searchMove picks the AI move for mark on non-classic boards. Optimal plays
+// the best searched move; the other levels score every candidate and sample
+// one with their softmax temperature, like on the classic board.
+func (g *TicTacToe) searchMove(mark, difficulty string) int {
+	board := append([]string(nil), g.Board...)
+	depth := g.searchDepth()
+	temp := tttTemperature[difficulty]
+	best, bestScore := -1, -tttWinScore*2
+	alpha := -tttWinScore * 2
+	cands := g.candidates(board, mark)
+	scores := make([]float64, len(cands))
+	for i, pos := range cands {
+		board[pos] = mark
+		if temp > 0 { alpha = -tttWinScore * 2 } // sampling needs every score, not just a bound
+		score := -g.negamax(board, opposite(mark), pos, depth-1, -tttWinScore*2, -alpha)
+		board[pos] = ""
+		scores[i] = tttUnits(score)
+		if score > bestScore { best, bestScore = pos, score }
+		if score > alpha { alpha = score }
+	}
+	if temp > 0 && len(cands) > 0 { best = cands[softmaxPick(scores, temp)] }
+	g.logf("[TTT][AI] alpha-beta depth=%d %s chose move=%d best score=%d", depth, difficulty, best, bestScore)
+	return best
+}
+
+// tttUnits puts a search score on the scale of the classic board's scores:
+// the leaf evaluation grows eightfold per extra mark in a window, so its log
+// counts marks and a win is worth about 17.
+func tttUnits(score int) float64 {
+	if score < 0 { return -tttUnits(-score) }
+	return math.Log2(float64(score)+1) / 3
+}
+
+// negamax scores board for toMove; last is the cell the opponent just played.
+func (g *TicTacToe) negamax(board []string, toMove string, last, depth, alpha, beta int) int {
+	if g.runAt(board, last) != nil { return -(tttWinScore + depth) } // the opponent completed a run
+	cands := g.candidates(board, toMove)
+	if len(cands) == 0 { return 0 }
+	if depth == 0 { return g.evaluate(board, toMove) }
+	for _, pos := range cands {
+		board[pos] = toMove
+		score := -g.negamax(board, opposite(toMove), pos, depth-1, -beta, -alpha)
+		board[pos] = ""
+		if score > alpha { alpha = score }
+		if alpha >= beta { break }
+	}
+	return alpha
+}
+
+// candidates returns the empty cells worth playing for mark, most
+// threatening first and at most tttSearchWide of them. On big boards only
+// cells within two steps of a mark are considered.
+func (g *TicTacToe) candidates(board []string, mark string) []int {
+	type cand struct{ pos, score int }
+	var cs []cand
+	empty := true
+	for _, c := range board { if c != "" { empty = false; break } }
+	if empty { return []int{(g.Rows/2)*g.Cols + g.Cols/2} }
+	near := len(board) > 16
+	for pos, c := range board {
+		if c != "" || (near && !g.nearMark(board, pos)) { continue }
+		cs = append(cs, cand{pos, g.threat(board, pos, mark)})
+	}
+	sort.SliceStable(cs, func(i, j int) bool { return cs[i].score > cs[j].score })
+	if len(cs) > tttSearchWide { cs = cs[:tttSearchWide] }
+	out := make([]int, len(cs))
+	for i, c := range cs { out[i] = c.pos }
+	return out
+}
+
+func (g *TicTacToe) nearMark(board []string, pos int) bool {
+	r0, c0 := pos/g.Cols, pos%g.Cols
+	for r := r0 - 2; r <= r0+2; r++ {
+		for c := c0 - 2; c <= c0+2; c++ {
+			if r >= 0 && r < g.Rows && c >= 0 && c < g.Cols && board[r*g.Cols+c] != "" { return true }
+		}
+	}
+	return false
+}
+
+// threat rates the empty cell pos for mark: completing a run wins, blocking
+// the opponent's winning cell comes next, then longer runs with more open
+// ends for either side.
+func (g *TicTacToe) threat(board []string, pos int, mark string) int {
+	score := 0
+	for _, m := range []string{mark, opposite(mark)} {
+		for _, d := range tttDirs {
+			run, open := g.extent(board, pos, d, m)
+			switch {
+			case run+1 >= g.K && m == mark:
+				score += 1 << 40
+			case run+1 >= g.K:
+				score += 1 << 36
+			case open > 0:
+				score += open << (3 * minInt(run, 10))
+			}
+		}
+	}
+	return score
+}
+
+// extent counts m's marks adjacent to pos along d (both ways) and how many
+// of the two ends beyond them are empty.
+func (g *TicTacToe) extent(board []string, pos int, d [2]int, m string) (run, open int) {
+	r0, c0 := pos/g.Cols, pos%g.Cols
+	for _, sign := range []int{-1, 1} {
+		r, c := r0+sign*d[0], c0+sign*d[1]
+		for r >= 0 && r < g.Rows && c >= 0 && c < g.Cols && board[r*g.Cols+c] == m {
+			run++
+			r, c = r+sign*d[0], c+sign*d[1]
+		}
+		if r >= 0 && r < g.Rows && c >= 0 && c < g.Cols && board[r*g.Cols+c] == "" { open++ }
+	}
+	return run, open
+}
+
+// evaluate sums every window of K cells for mark: windows holding only one
+// side's marks count for that side, growing steeply with the number of marks.
+func (g *TicTacToe) evaluate(board []string, mark string) int {
+	score := 0
+	for r := 0; r < g.Rows; r++ {
+		for c := 0; c < g.Cols; c++ {
+			for _, d := range tttDirs {
+				er, ec := r+(g.K-1)*d[0], c+(g.K-1)*d[1]
+				if er >= g.Rows || ec < 0 || ec >= g.Cols { continue }
+				mine, theirs := 0, 0
+				for i := 0; i < g.K; i++ {
+					switch board[(r+i*d[0])*g.Cols+c+i*d[1]] {
+					case "":
+					case mark:
+						mine++
+					default:
+						theirs++
+					}
+				}
+				if theirs == 0 && mine > 0 { score += 1 << (3 * minInt(mine, 10)) }
+				if mine == 0 && theirs > 0 { score -= 1 << (3 * minInt(theirs, 10)) }
+			}
+		}
+	}
+	return score
+}
+
+func minInt(a, b int) int { if a < b { return a }; return b }
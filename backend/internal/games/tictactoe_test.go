@@ -24,3 +24,208 @@ func TestOptimalAIMoveGeneration(t *testing.T) {
 	if g.Board[4] == "" { t.Fatalf("expected AI to take center optimally, board: %+v", g.Board) }
 	if g.Winner != "" { t.Fatalf("game should not be over early, winner=%s", g.Winner) }
 }
+
+func TestSizedBoardsComputeWins(t *testing.T) {
+	g, err := NewTicTacToeSized(4, 4, 4, false, "")
+	if err != nil { t.Fatal(err) }
+	for _, m := range []int{0, 1, 5, 2, 10, 3, 15} { // X down the main diagonal
+		if !g.MakeMove(m) { t.Fatalf("move %d rejected", m) }
+	}
+	if g.Winner != "X" || len(g.WinningLine) != 4 || g.WinningLine[3] != 15 {
+		t.Fatalf("expected X diagonal win, got %q %v", g.Winner, g.WinningLine)
+	}
+	gm, _ := NewTicTacToeSized(15, 15, 5, false, "")
+	for i := 0; i < 4; i++ {
+		gm.MakeMove(7*15 + 3 + i) // X across row 7
+		gm.MakeMove(0 + i)        // O along the top
+	}
+	if gm.Winner != "" { t.Fatal("four in a row must not win Gomoku") }
+	gm.MakeMove(7*15 + 7)
+	if gm.Winner != "X" || len(gm.WinningLine) != 5 { t.Fatalf("expected five in a row, got %q %v", gm.Winner, gm.WinningLine) }
+	for _, size := range [][3]int{{2, 3, 3}, {3, 3, 4}, {20, 20, 5}, {3, 3, 2}} {
+		if _, err := NewTicTacToeSized(size[0], size[1], size[2], false, ""); err == nil { t.Fatalf("size %v should be rejected", size) }
+	}
+}
+
+func TestSearchAIBlocksAndWinsOnLargeBoards(t *testing.T) {
+	g, _ := NewTicTacToeSized(15, 15, 5, true, "optimal")
+	// X builds an open four on row 7; the AI must block one end
+	for _, m := range []int{7*15 + 5, 7*15 + 6, 7*15 + 7} {
+		if !g.MakeMove(m) { t.Fatalf("move %d rejected", m) }
+	}
+	if g.Board[7*15+4] != "O" && g.Board[7*15+8] != "O" {
+		t.Fatal("AI should have capped the open three by now")
+	}
+	w, _ := NewTicTacToeSized(4, 4, 4, false, "optimal")
+	for _, m := range []int{0, 4, 1, 5, 2, 6} {
+		w.MakeMove(m)
+	}
+	if pos := w.searchMove("X", "optimal"); pos != 3 {
+		t.Fatalf("X should complete the top row at 3, got %d", pos)
+	}
+	if pos := w.searchMove("O", "optimal"); pos != 7 && pos != 3 {
+		t.Fatalf("O must win at 7 or block at 3, got %d", pos)
+	}
+}
+
+// referenceMinimax is the original exhaustive search (no pruning, no table),
+// kept to check the engine against and to benchmark it.
+func referenceMinimax(board []string, player string, depth int) (score int, move int) {
+	won := func(m string) bool {
+		for _, l := range [8][3]int{{0, 1, 2}, {3, 4, 5}, {6, 7, 8}, {0, 3, 6}, {1, 4, 7}, {2, 5, 8}, {0, 4, 8}, {2, 4, 6}} {
+			if board[l[0]] == m && board[l[1]] == m && board[l[2]] == m { return true }
+		}
+		return false
+	}
+	if won("O") { return 10 - depth, -1 }
+	if won("X") { return depth - 10, -1 }
+	best, move := 1000, -1
+	if player == "O" { best = -1000 }
+	for i := 0; i < 9; i++ {
+		if board[i] != "" { continue }
+		board[i] = player
+		sc, _ := referenceMinimax(board, opposite(player), depth+1)
+		board[i] = ""
+		if (player == "O" && sc > best) || (player == "X" && sc < best) { best, move = sc, i }
+	}
+	if move == -1 { return 0, -1 }
+	return best, move
+}
+
+func TestEngineMatchesReferenceMinimax(t *testing.T) {
+	g := NewTicTacToe(false, "optimal")
+	checked := 0
+	var walk func(board []string, player string)
+	walk = func(board []string, player string) {
+		want, wantMove := referenceMinimax(board, player, 0)
+		got, gotMove := g.minimax(board, player)
+		if player == "X" { got = -got } // the reference always scores for O
+		if got != want || gotMove != wantMove {
+			t.Fatalf("board %q (%s to move): engine %d@%d, reference %d@%d", board, player, got, gotMove, want, wantMove)
+		}
+		checked++
+		if wantMove == -1 { return }
+		for i := 0; i < 9; i++ {
+			if board[i] != "" { continue }
+			board[i] = player
+			walk(board, opposite(player))
+			board[i] = ""
+		}
+	}
+	walk(make([]string, 9), "X")
+	if checked < 5000 { t.Fatalf("expected to visit every reachable position, checked %d", checked) }
+}
+
+func BenchmarkTicTacToeAIMove(b *testing.B) {
+	g := NewTicTacToe(false, "optimal")
+	board := make([]string, 9)
+	for i := 0; i < b.N; i++ {
+		g.minimax(board, "X")
+	}
+}
+
+func BenchmarkTicTacToeReferenceMinimax(b *testing.B) {
+	board := make([]string, 9)
+	for i := 0; i < b.N; i++ {
+		referenceMinimax(board, "X", 0)
+	}
+}
+
+func TestDifficultyValidated(t *testing.T) {
+	for _, d := range []string{"", "beginner", "easy", "medium", "hard", "optimal"} {
+		if _, err := NewTicTacToeSized(3, 3, 3, true, d); err != nil { t.Fatalf("%q should be accepted: %v", d, err) }
+	}
+	if _, err := NewTicTacToeSized(3, 3, 3, true, "impossible"); err != ErrBadDifficulty { t.Fatalf("expected ErrBadDifficulty, got %v", err) }
+}
+
+func TestDifficultyBlunderRateFallsWithLevel(t *testing.T) {
+	// X took a corner; only the center keeps O from losing
+	blunders := map[string]int{}
+	for _, d := range []string{"beginner", "easy", "medium", "hard", "optimal"} {
+		g := NewTicTacToe(false, d)
+		g.MakeMove(0)
+		for i := 0; i < 2000; i++ {
+			if _, pos := g.softmaxMove("O", d); pos != 4 { blunders[d]++ }
+		}
+	}
+	if blunders["optimal"] != 0 { t.Fatalf("optimal must never blunder, did %d times", blunders["optimal"]) }
+	levels := []string{"beginner", "easy", "medium", "hard", "optimal"}
+	for i := 1; i < len(levels); i++ {
+		if blunders[levels[i]] >= blunders[levels[i-1]] {
+			t.Fatalf("%s should blunder less than %s: %v", levels[i], levels[i-1], blunders)
+		}
+	}
+}
+
+func TestWinRateRisesWithLevelOnBigBoards(t *testing.T) {
+	// 5x5, four in a row, against the plain heuristic, alternating who opens
+	score := map[string]float64{}
+	levels := []string{"beginner", "easy", "optimal"}
+	for _, d := range levels {
+		for i := 0; i < 60; i++ {
+			g, _ := NewTicTacToeSized(5, 5, 4, false, "")
+			me := "X"
+			if i%2 == 1 { me = "O" }
+			for g.Winner == "" {
+				if g.CurrentPlayer == me { g.MakeMove(g.SuggestMove(d)) } else { g.MakeMove(g.SuggestMove("heuristic")) }
+			}
+			if g.Winner == me { score[d]++ } else if g.Winner == "D" { score[d] += 0.5 }
+		}
+	}
+	for i := 1; i < len(levels); i++ {
+		if score[levels[i]] <= score[levels[i-1]] {
+			t.Fatalf("%s should score more than %s: %v", levels[i], levels[i-1], score)
+		}
+	}
+}
+
+func TestAnalysisScoresMovesAndReviewsGame(t *testing.T) {
+	g := NewTicTacToe(false, "")
+	for _, m := range []int{0, 1} { g.MakeMove(m) } // O's edge reply loses
+	a, err := g.Analyze()
+	if err != nil { t.Fatal(err) }
+	if a.ToMove != "X" || len(a.Moves) != 7 || a.Moves[0].Result != "win" {
+		t.Fatalf("X should have a forced win, got %+v", a)
+	}
+	for _, m := range []int{4, 2, 8} { g.MakeMove(m) } // X wins on the diagonal
+	a, _ = g.Analyze()
+	if len(a.Moves) != 0 || len(a.Review) != 5 {
+		t.Fatalf("finished games get a review of every move, got %+v", a)
+	}
+	labels := []string{}
+	for _, r := range a.Review { labels = append(labels, r.Label) }
+	// O's 2 was lost anyway, but blocking at 8 would have lasted longer
+	want := []string{"best", "blunder", "best", "inaccuracy", "best"}
+	for i := range want {
+		if labels[i] != want[i] { t.Fatalf("labels %v, want %v", labels, want) }
+	}
+	big, _ := NewTicTacToeSized(4, 4, 4, false, "")
+	if _, err := big.Analyze(); err != ErrNoAnalysis { t.Fatalf("expected ErrNoAnalysis, got %v", err) }
+}
+
+func TestAIOpensWhenHumanPlaysO(t *testing.T) {
+	g := NewTicTacToe(true, "optimal")
+	if err := g.PlayAs("Z"); err != ErrBadMark { t.Fatalf("expected ErrBadMark, got %v", err) }
+	if err := g.PlayAs("O"); err != nil { t.Fatal(err) }
+	if len(g.Moves) != 1 || g.Moves[0].Player != "X" || g.CurrentPlayer != "O" {
+		t.Fatalf("AI should open as X, got %+v", g.Moves)
+	}
+	if g.Undo() { t.Fatal("the AI's opening move alone cannot be undone") }
+	open := g.Moves[0].Pos
+	pos := 0
+	for g.Board[pos] != "" { pos++ }
+	if !g.MakeMove(pos) || len(g.Moves) != 3 || g.Moves[2].Player != "X" {
+		t.Fatalf("AI should answer as X, got %+v", g.Moves)
+	}
+	if !g.Undo() || len(g.Moves) != 1 || g.Moves[0].Pos != open || g.CurrentPlayer != "O" {
+		t.Fatalf("undo should take back the human move and the AI reply, got %+v", g.Moves)
+	}
+	g.Reset()
+	if len(g.Moves) != 1 || g.Board[g.Moves[0].Pos] != "X" { t.Fatalf("AI should open again after reset, got %+v", g.Moves) }
+	// optimal play from both sides is a draw
+	for g.Winner == "" {
+		_, m := g.minimax(g.Board, "O")
+		g.MakeMove(m)
+	}
+	if g.Winner != "D" { t.Fatalf("perfect play should draw, got %q", g.Winner) }
+}
@@ -0,0 +1,225 @@
+package games
+
+import (
+	"log"
+	"math"
+	"math/rand"
+)
+
+// Ultimate is Ultimate Tic Tac Toe: nine 3x3 sub-boards laid out as a big
+// 3x3 board. Playing cell c sends the opponent to sub-board c; when that
+// board is already closed (won or full) they may play anywhere. Winning
+// three sub-boards in a row wins the game, and the game is drawn when every
+// sub-board is closed without such a line.
+type Ultimate struct {
+	Boards        [9][9]string `json:"boards"`        // Boards[b][c] is cell c of sub-board b
+	BoardWinners  [9]string    `json:"boardWinners"`  // "X", "O", "D" or "" while the sub-board is open
+	Active        int          `json:"active"`        // sub-board to play in next, -1 for any
+	CurrentPlayer string       `json:"currentPlayer"`
+	Winner        string       `json:"winner"`
+	WinningLine   []int        `json:"winningLine"` // sub-boards of the winning line
+	Moves         []UltMove    `json:"moves"`
+	VsAI          bool         `json:"vsAI"`
+	Difficulty    string       `json:"difficulty"` // easy, medium or hard (MCTS playouts per move)
+	Series        *Series      `json:"series,omitempty"`
+	Spectators    int          `json:"spectators"`
+	Quiet         bool         `json:"-"` // no move logs, for bot-vs-bot runs
+}
+
+// UltMove is one mark placed on a sub-board. Prev is the Active value before
+// the move so Undo can restore it.
+type UltMove struct {
+	Board  int    `json:"board"`
+	Cell   int    `json:"cell"`
+	Player string `json:"player"`
+	Prev   int    `json:"-"`
+}
+
+// ultIterations is the MCTS playout budget per difficulty.
+var ultIterations = map[string]int{"easy": 300, "medium": 2000, "hard": 10000}
+
+// ultBoard carries the classic 3x3 dimensions so sub-boards and the big
+// board share TicTacToe's win detection.
+var ultBoard = &TicTacToe{Rows: 3, Cols: 3, K: 3}
+
+func NewUltimate(vsAI bool, difficulty string) *Ultimate {
+	if _, ok := ultIterations[difficulty]; !ok { difficulty = "medium" }
+	g := &Ultimate{VsAI: vsAI, Difficulty: difficulty}
+	g.Reset()
+	log.Printf("[UTTT] New game created vsAI=%v difficulty=%s", vsAI, difficulty)
+	return g
+}
+
+// logf logs unless the game is quiet.
+func (g *Ultimate) logf(format string, args ...any) { if !g.Quiet { log.Printf(format, args...) } }
+
+// Reset clears every sub-board keeping mode and difficulty.
+func (g *Ultimate) Reset() {
+	g.Boards, g.BoardWinners = [9][9]string{}, [9]string{}
+	g.Active, g.CurrentPlayer, g.Winner = -1, "X", ""
+	g.WinningLine, g.Moves = []int{}, []UltMove{}
+}
+
+// Play marks cell of board for the current player and lets the AI answer
+// when it is its turn. It returns false for illegal moves.
+func (g *Ultimate) Play(board, cell int) bool {
+	if !g.play(board, cell) { return false }
+	if g.VsAI && g.CurrentPlayer == "O" && g.Winner == "" { g.aiMove() }
+	if g.Winner != "" { g.logf("[UTTT] Game over, winner %s after %d moves", g.Winner, len(g.Moves)) }
+	return true
+}
+
+// play applies a move without AI reply or logging; the search uses it too.
+func (g *Ultimate) play(board, cell int) bool {
+	if g.Winner != "" || board < 0 || board > 8 || cell < 0 || cell > 8 { return false }
+	if (g.Active >= 0 && board != g.Active) || g.BoardWinners[board] != "" || g.Boards[board][cell] != "" { return false }
+	g.Boards[board][cell] = g.CurrentPlayer
+	g.Moves = append(g.Moves, UltMove{Board: board, Cell: cell, Player: g.CurrentPlayer, Prev: g.Active})
+	if ultBoard.runAt(g.Boards[board][:], cell) != nil {
+		g.BoardWinners[board] = g.CurrentPlayer
+		if line := ultBoard.runAt(g.BoardWinners[:], board); line != nil {
+			g.Winner, g.WinningLine = g.CurrentPlayer, line
+			return true
+		}
+	} else if ultFull(g.Boards[board][:]) {
+		g.BoardWinners[board] = "D"
+	}
+	if ultFull(g.BoardWinners[:]) {
+		g.Winner = "D"
+		return true
+	}
+	g.Active = cell
+	if g.BoardWinners[cell] != "" { g.Active = -1 }
+	g.CurrentPlayer = opposite(g.CurrentPlayer)
+	return true
+}
+
+func ultFull(cells []string) bool {
+	for _, c := range cells { if c == "" { return false } }
+	return true
+}
+
+// Legal lists the playable [board, cell] pairs for the side to move.
+func (g *Ultimate) Legal() [][2]int {
+	if g.Winner != "" { return nil }
+	var out [][2]int
+	for b := 0; b < 9; b++ {
+		if (g.Active >= 0 && b != g.Active) || g.BoardWinners[b] != "" { continue }
+		for c := 0; c < 9; c++ {
+			if g.Boards[b][c] == "" { out = append(out, [2]int{b, c}) }
+		}
+	}
+	return out
+}
+
+// Undo takes back the last move; against the AI it also takes back the
+// human move before an AI reply so it is the human's turn again.
+func (g *Ultimate) Undo() bool {
+	if len(g.Moves) == 0 { return false }
+	g.undoOne()
+	if g.VsAI && g.CurrentPlayer == "O" && len(g.Moves) > 0 { g.undoOne() }
+	return true
+}
+
+func (g *Ultimate) undoOne() {
+	last := g.Moves[len(g.Moves)-1]
+	g.Boards[last.Board][last.Cell] = ""
+	g.BoardWinners[last.Board] = "" // only this move could have closed it
+	g.Moves = g.Moves[:len(g.Moves)-1]
+	g.Winner, g.WinningLine = "", []int{}
+	g.Active, g.CurrentPlayer = last.Prev, last.Player
+}
+
+func (g *Ultimate) aiMove() {
+	n := ultIterations[g.Difficulty]
+	m := g.BestMove(n)
+	g.logf("[UTTT][AI] mcts playouts=%d chose board=%d cell=%d", n, m[0], m[1])
+	g.play(m[0], m[1])
+}
+
+// SuggestMove returns the [board, cell] the AI would play for the side to
+// move at difficulty, or {-1, -1} when the game is over or the difficulty is unknown.
+func (g *Ultimate) SuggestMove(difficulty string) [2]int {
+	n, ok := ultIterations[difficulty]
+	if !ok { return [2]int{-1, -1} }
+	return g.BestMove(n)
+}
+
+// ultNode is a Monte Carlo search tree node; wins are counted for the
+// player who made move.
+type ultNode struct {
+	move     [2]int
+	player   string
+	parent   *ultNode
+	children []*ultNode
+	untried  [][2]int
+	visits   float64
+	wins     float64
+}
+
+// BestMove runs iterations rounds of Monte Carlo tree search (UCT) for the
+// side to move and returns the most visited move, or {-1, -1} when the game
+// is over. Each round walks the tree, expands one move, finishes the game
+// with random moves and credits the result back up the path.
+func (g *Ultimate) BestMove(iterations int) [2]int {
+	legal := g.Legal()
+	if len(legal) == 0 { return [2]int{-1, -1} }
+	root := &ultNode{untried: legal}
+	for i := 0; i < iterations; i++ {
+		s := g.searchCopy()
+		n := root
+		for len(n.untried) == 0 && len(n.children) > 0 {
+			n = n.selectChild()
+			s.play(n.move[0], n.move[1])
+		}
+		if len(n.untried) > 0 {
+			k := rand.Intn(len(n.untried))
+			m := n.untried[k]
+			n.untried = append(n.untried[:k], n.untried[k+1:]...)
+			player := s.CurrentPlayer
+			s.play(m[0], m[1])
+			child := &ultNode{move: m, player: player, parent: n, untried: s.Legal()}
+			n.children = append(n.children, child)
+			n = child
+		}
+		for s.Winner == "" {
+			moves := s.Legal()
+			m := moves[rand.Intn(len(moves))]
+			s.play(m[0], m[1])
+		}
+		for ; n != nil; n = n.parent {
+			n.visits++
+			switch s.Winner {
+			case n.player:
+				n.wins++
+			case "D":
+				n.wins += 0.5
+			}
+		}
+	}
+	best := root.children[0]
+	for _, c := range root.children[1:] {
+		if c.visits > best.visits { best = c }
+	}
+	return best.move
+}
+
+// selectChild picks the child with the highest UCB1 score.
+func (n *ultNode) selectChild() *ultNode {
+	var best *ultNode
+	bestScore := math.Inf(-1)
+	logN := math.Log(n.visits)
+	for _, c := range n.children {
+		score := c.wins/c.visits + 1.4*math.Sqrt(logN/c.visits)
+		if score > bestScore { best, bestScore = c, score }
+	}
+	return best
+}
+
+// searchCopy returns a copy the search can play on without touching g.
+func (g *Ultimate) searchCopy() *Ultimate {
+	s := *g
+	s.Moves = nil
+	s.VsAI = false
+	return &s
+}
@@ -0,0 +1,83 @@
+package games
+
+import "testing"
+
+func playAll(t *testing.T, g *Ultimate, moves ...[2]int) {
+	t.Helper()
+	for _, m := range moves {
+		if !g.Play(m[0], m[1]) { t.Fatalf("move %v rejected", m) }
+	}
+}
+
+func TestUltimateSendsToBoard(t *testing.T) {
+	g := NewUltimate(false, "")
+	playAll(t, g, [2]int{4, 2})
+	if g.Active != 2 || g.CurrentPlayer != "O" { t.Fatalf("O should be sent to board 2, got %d %s", g.Active, g.CurrentPlayer) }
+	if g.Play(4, 0) { t.Fatal("O must play in board 2") }
+	playAll(t, g, [2]int{2, 4})
+	if g.Active != 4 { t.Fatalf("X should be sent to board 4, got %d", g.Active) }
+	if g.Play(4, 2) { t.Fatal("occupied cells must be rejected") }
+}
+
+func TestUltimateClosedBoardFreesChoice(t *testing.T) {
+	g := NewUltimate(false, "")
+	// X takes the top row of board 0 while O keeps answering from board 1
+	playAll(t, g, [2]int{0, 1}, [2]int{1, 0}, [2]int{0, 2}, [2]int{2, 0}, [2]int{0, 0})
+	if g.BoardWinners[0] != "X" { t.Fatalf("X should win board 0, got %q", g.BoardWinners[0]) }
+	// O is sent to board 0, which is closed, so any open board is allowed
+	if g.Active != -1 { t.Fatalf("expected free choice, got board %d", g.Active) }
+	if g.Play(0, 5) { t.Fatal("closed boards must be rejected") }
+	playAll(t, g, [2]int{8, 8})
+}
+
+func TestUltimateWinAndUndo(t *testing.T) {
+	g := NewUltimate(false, "")
+	g.BoardWinners = [9]string{"X", "X", "", "O", "O", "", "", "", ""}
+	g.Boards[2] = [9]string{"X", "X", "", "O", "O", "", "", "", ""}
+	g.Active = 2
+	playAll(t, g, [2]int{2, 2})
+	if g.Winner != "X" || len(g.WinningLine) != 3 || g.WinningLine[2] != 2 {
+		t.Fatalf("X should win across the top, got %q %v", g.Winner, g.WinningLine)
+	}
+	if g.Play(5, 0) { t.Fatal("moves after the win must be rejected") }
+	if !g.Undo() || g.Winner != "" || g.BoardWinners[2] != "" || g.Active != 2 || g.CurrentPlayer != "X" {
+		t.Fatalf("undo should reopen board 2 for X, got %+v", g)
+	}
+}
+
+func TestUltimateAIMovesAndUndoesInPairs(t *testing.T) {
+	g := NewUltimate(true, "easy")
+	playAll(t, g, [2]int{4, 4})
+	if len(g.Moves) != 2 || g.Moves[1].Player != "O" || g.Moves[1].Board != 4 || g.CurrentPlayer != "X" {
+		t.Fatalf("AI should answer inside board 4, got %+v", g.Moves)
+	}
+	if !g.Undo() || len(g.Moves) != 0 || g.Active != -1 { t.Fatalf("undo should remove both moves, got %+v", g.Moves) }
+}
+
+func TestUltimateMCTSTakesTheWin(t *testing.T) {
+	g := NewUltimate(false, "")
+	g.BoardWinners = [9]string{"O", "", "", "", "O", "", "X", "X", ""}
+	g.Boards[8] = [9]string{"O", "", "", "", "O", "", "X", "X", ""}
+	g.CurrentPlayer, g.Active = "O", -1
+	if m := g.BestMove(ultIterations["medium"]); m != [2]int{8, 8} {
+		t.Fatalf("O should win the game at board 8 cell 8, chose %v", m)
+	}
+}
+
+func TestUltimateMCTSBeatsRandom(t *testing.T) {
+	wins := 0
+	for i := 0; i < 4; i++ {
+		g := NewUltimate(false, "")
+		for g.Winner == "" {
+			if g.CurrentPlayer == "X" {
+				m := g.BestMove(ultIterations["easy"])
+				g.play(m[0], m[1])
+				continue
+			}
+			m := g.BestMove(1)
+			g.play(m[0], m[1])
+		}
+		if g.Winner == "X" { wins++ }
+	}
+	if wins < 3 { t.Fatalf("MCTS should beat a one-playout opponent, won %d of 4", wins) }
+}
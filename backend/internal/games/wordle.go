@@ -0,0 +1,141 @@
+package games
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// Letter scores of a Wordle guess.
+const (
+	WordleGreen  = "green"  // right letter, right place
+	WordleYellow = "yellow" // in the word, elsewhere
+	WordleGray   = "gray"   // not in the word (or no more copies of it)
+)
+
+// Wordle is the five-letter (by default) guessing game: every guess must be
+// a dictionary word of the answer's length and is scored letter by letter.
+// In hard mode later guesses must use every hint revealed so far. Letters
+// holds the best score seen for each letter, for the on-screen keyboard.
+type Wordle struct {
+	Answer      string            `json:"-"`
+	Length      int               `json:"length"`
+	MaxAttempts int               `json:"maxAttempts"`
+	HardMode    bool              `json:"hardMode"`
+	Guesses     []WordleGuess     `json:"guesses"`
+	Letters     map[string]string `json:"letters"`
+	Finished    bool              `json:"finished"`
+	Won         bool              `json:"won"`
+	Solution    string            `json:"solution,omitempty"` // the answer, once the game is over
+	Series      *Series           `json:"series,omitempty"`
+	Spectators  int               `json:"spectators"`
+}
+
+// WordleGuess is one scored guess; Marks[i] scores Word[i].
+type WordleGuess struct {
+	Word  string   `json:"word"`
+	Marks []string `json:"marks"`
+}
+
+var (
+	ErrWordleSetup  = errors.New("wordle words have 4-7 letters and 1-10 attempts")
+	ErrNoWords      = errors.New("dictionary has no words of that length")
+	ErrGameOver     = errors.New("game is over")
+	ErrWrongLength  = errors.New("guess has the wrong number of letters")
+	ErrNotAWord     = errors.New("not in the word list")
+	ErrHardModeHint = errors.New("hard mode: guesses must use every revealed hint")
+)
+
+// NewWordle picks an answer of length letters (5 by default) from the shared
+// dictionary; maxAttempts defaults to 6.
+func NewWordle(length, maxAttempts int, hard bool) (*Wordle, error) {
+	if length == 0 { length = 5 }
+	if maxAttempts == 0 { maxAttempts = 6 }
+	if length < 4 || length > 7 || maxAttempts < 1 || maxAttempts > 10 { return nil, ErrWordleSetup }
+	g := &Wordle{Length: length, MaxAttempts: maxAttempts, HardMode: hard}
+	if err := g.Reset(); err != nil { return nil, err }
+	log.Printf("[WORDLE] New game length=%d attempts=%d hard=%v", length, maxAttempts, hard)
+	return g, nil
+}
+
+// Reset starts over with a new answer of the same length.
+func (g *Wordle) Reset() error {
+	answer := Words().Random(g.Length, g.Length)
+	if answer == "" { return ErrNoWords }
+	g.Answer, g.Solution = answer, ""
+	g.Guesses, g.Letters = []WordleGuess{}, map[string]string{}
+	g.Finished, g.Won = false, false
+	return nil
+}
+
+// ScoreWordle scores guess against answer (same length, lowercase). Greens
+// are taken first; each remaining copy of a letter in the answer then turns
+// at most one more guess letter yellow, left to right.
+func ScoreWordle(answer, guess string) []string {
+	marks := make([]string, len(guess))
+	left := map[byte]int{} // answer letters not matched green
+	for i := range guess {
+		if guess[i] == answer[i] {
+			marks[i] = WordleGreen
+		} else {
+			left[answer[i]]++
+		}
+	}
+	for i := range guess {
+		if marks[i] != "" { continue }
+		marks[i] = WordleGray
+		if left[guess[i]] > 0 {
+			marks[i] = WordleYellow
+			left[guess[i]]--
+		}
+	}
+	return marks
+}
+
+// Guess scores word. Words of the wrong length, unknown words and, in hard
+// mode, guesses ignoring a hint are rejected without using an attempt.
+func (g *Wordle) Guess(word string) error {
+	if g.Finished { return ErrGameOver }
+	word = strings.ToLower(strings.TrimSpace(word))
+	if len(word) != g.Length { return ErrWrongLength }
+	if !Words().Contains(word) && word != g.Answer { return ErrNotAWord }
+	if g.HardMode {
+		if err := g.checkHints(word); err != nil { return err }
+	}
+	marks := ScoreWordle(g.Answer, word)
+	g.Guesses = append(g.Guesses, WordleGuess{Word: word, Marks: marks})
+	rank := map[string]int{"": 0, WordleGray: 1, WordleYellow: 2, WordleGreen: 3}
+	for i, m := range marks {
+		l := word[i : i+1]
+		if rank[m] > rank[g.Letters[l]] { g.Letters[l] = m }
+	}
+	g.Won = word == g.Answer
+	g.Finished = g.Won || len(g.Guesses) >= g.MaxAttempts
+	if g.Finished {
+		g.Solution = g.Answer
+		log.Printf("[WORDLE] Game over won=%v after %d guesses", g.Won, len(g.Guesses))
+	}
+	return nil
+}
+
+// checkHints enforces hard mode: greens stay in place and every yellow or
+// green letter is used at least as often as the hints showed it.
+func (g *Wordle) checkHints(word string) error {
+	for _, prev := range g.Guesses {
+		need := map[byte]int{}
+		for i, m := range prev.Marks {
+			switch m {
+			case WordleGreen:
+				if word[i] != prev.Word[i] { return fmt.Errorf("%w: letter %d must be %s", ErrHardModeHint, i+1, strings.ToUpper(prev.Word[i:i+1])) }
+				need[prev.Word[i]]++
+			case WordleYellow:
+				need[prev.Word[i]]++
+			}
+		}
+		for letter, n := range need {
+			if strings.Count(word, string(letter)) < n { return fmt.Errorf("%w: guess must contain %s", ErrHardModeHint, strings.ToUpper(string(letter))) }
+		}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package games
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestScoreWordleRepeatedLetters(t *testing.T) {
+	g, y, x := WordleGreen, WordleYellow, WordleGray
+	for _, tc := range []struct {
+		answer, guess string
+		want          []string
+	}{
+		{"crane", "crane", []string{g, g, g, g, g}},
+		{"abbey", "kebab", []string{x, y, g, y, y}},
+		{"robot", "floor", []string{x, x, y, g, y}},
+		{"apple", "papal", []string{y, y, g, x, y}},
+		// two Es in the answer: one is green, the other turns only the first extra E yellow
+		{"theme", "eerie", []string{y, x, x, x, g}},
+		{"sleep", "eerie", []string{y, y, x, x, x}},
+	} {
+		if got := ScoreWordle(tc.answer, tc.guess); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s vs %s: got %v want %v", tc.guess, tc.answer, got, tc.want)
+		}
+	}
+}
+
+func TestWordleGuessValidation(t *testing.T) {
+	g, err := NewWordle(0, 0, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.Answer = "crane"
+	if err := g.Guess("cranes"); err != ErrWrongLength {
+		t.Fatalf("expected wrong length, got %v", err)
+	}
+	if err := g.Guess("zzzzz"); err != ErrNotAWord {
+		t.Fatalf("expected unknown word, got %v", err)
+	}
+	if len(g.Guesses) != 0 {
+		t.Fatal("rejected guesses must not use attempts")
+	}
+	b, _ := json.Marshal(g)
+	if strings.Contains(string(b), "crane") {
+		t.Fatalf("answer leaked: %s", b)
+	}
+	for _, w := range []string{"stone", "house", "light", "money", "paper"} {
+		if err := g.Guess(w); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if g.Finished || g.Letters["e"] != WordleGreen || g.Letters["s"] != WordleGray {
+		t.Fatalf("unexpected keyboard %v", g.Letters)
+	}
+	g.Guess("crane")
+	if !g.Won || !g.Finished || g.Solution != "crane" {
+		t.Fatal("sixth guess should win")
+	}
+	if err := g.Guess("crane"); err != ErrGameOver {
+		t.Fatalf("expected game over, got %v", err)
+	}
+	if _, err := NewWordle(3, 6, false); err != ErrWordleSetup {
+		t.Fatalf("expected setup error, got %v", err)
+	}
+}
+
+func TestWordleHardMode(t *testing.T) {
+	g, _ := NewWordle(5, 6, true)
+	g.Answer = "crane"
+	if err := g.Guess("grace"); err != nil { // R, A and E green, C yellow
+		t.Fatal(err)
+	}
+	if err := g.Guess("stone"); !errors.Is(err, ErrHardModeHint) || !strings.Contains(err.Error(), "letter 2 must be R") {
+		t.Fatalf("expected the green R to be enforced, got %v", err)
+	}
+	if err := g.Guess("bread"); !errors.Is(err, ErrHardModeHint) {
+		t.Fatalf("expected the green A to be enforced, got %v", err)
+	}
+	if err := g.Guess("grade"); !errors.Is(err, ErrHardModeHint) || !strings.Contains(err.Error(), "contain C") {
+		t.Fatalf("expected the yellow C to be required, got %v", err)
+	}
+	if err := g.Guess("brace"); err != nil {
+		t.Fatalf("brace uses every hint: %v", err)
+	}
+}
+
+func TestDictionarySharedWithHangman(t *testing.T) {
+	d, err := LoadDictionary(strings.NewReader("Apple\nbanana\n\nit's\napple\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Len() != 2 || !d.Contains("APPLE") || d.Contains("it's") {
+		t.Fatalf("unexpected dictionary %v", d.words)
+	}
+	if _, err := LoadDictionary(strings.NewReader("\n")); err != ErrEmptyDictionary {
+		t.Fatalf("expected empty dictionary error, got %v", err)
+	}
+	old := Words()
+	defer UseWords(old)
+	UseWords(NewDictionary([]string{"fjord", "jazzy"}))
+	if h := NewHangman("easy"); h.Word != "fjord" && h.Word != "jazzy" {
+		t.Fatalf("hangman ignored the shared dictionary: %q", h.Word)
+	}
+	w, _ := NewWordle(5, 6, false)
+	if w.Answer != "fjord" && w.Answer != "jazzy" {
+		t.Fatalf("wordle ignored the shared dictionary: %q", w.Answer)
+	}
+	if _, err := NewWordle(6, 6, false); err != ErrNoWords {
+		t.Fatalf("expected no words of length 6, got %v", err)
+	}
+}
@@ -0,0 +1,15 @@
+package httpapi
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/Manishk5507/gaMerZ/backend/internal/achievements"
+)
+
+func mountAchievements(r chi.Router, en *achievements.Engine) {
+	r.Get("/users/{id}/achievements", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, en.Statuses(chi.URLParam(r, "id")))
+	})
+}
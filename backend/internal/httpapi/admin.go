@@ -0,0 +1,58 @@
+package httpapi
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/Manishk5507/gaMerZ/backend/internal/arena"
+)
+
+// adminOnly lets requests through when they carry ADMIN_TOKEN as a bearer
+// token. Without ADMIN_TOKEN set the admin routes are disabled.
+func adminOnly(next http.Handler) http.Handler {
+	token := os.Getenv("ADMIN_TOKEN")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if token == "" || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			writeErr(w, http.StatusForbidden, "admin token required"); return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// adminMaxGames caps an arena run over HTTP, which plays on the request;
+// bigger runs belong to cmd/arena.
+const adminMaxGames = 200
+
+// mountAdmin adds the /admin routes: GET /admin/arena lists the strategies
+// per game and POST /admin/arena runs a bot-vs-bot comparison.
+func mountAdmin(r chi.Router) {
+	r.Route("/admin", func(r chi.Router) {
+		r.Use(adminOnly)
+		r.Get("/arena", func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, http.StatusOK, arena.Strategies())
+		})
+		r.Post("/arena", func(w http.ResponseWriter, r *http.Request) {
+			var cfg arena.Config
+			if err := json.NewDecoder(r.Body).Decode(&cfg); err != nil { writeErr(w, http.StatusBadRequest, "invalid body"); return }
+			if cfg.Games > adminMaxGames { writeErr(w, http.StatusBadRequest, fmt.Sprintf("at most %d games per request, use cmd/arena for more", adminMaxGames)); return }
+			// the run stops handing out games when the client goes away
+			rep, err := arena.Run(r.Context(), cfg)
+			switch {
+			case errors.Is(err, arena.ErrUnknownGame), errors.Is(err, arena.ErrUnknownStrategy), errors.Is(err, arena.ErrBadGameCount):
+				writeErr(w, http.StatusBadRequest, err.Error())
+			case err != nil:
+				writeErr(w, http.StatusInternalServerError, err.Error())
+			default:
+				writeJSON(w, http.StatusOK, rep)
+			}
+		})
+	})
+}
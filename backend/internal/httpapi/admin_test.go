@@ -0,0 +1,25 @@
+package httpapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestArenaRunsAreCappedOverHTTP(t *testing.T) {
+	t.Setenv("ADMIN_TOKEN", "secret")
+	h := NewRouter()
+	for body, want := range map[string]int{
+		`{"game":"tictactoe","a":"optimal","b":"random","games":4}`:    http.StatusOK,
+		`{"game":"tictactoe","a":"optimal","b":"random","games":1000}`: http.StatusBadRequest,
+	} {
+		req := httptest.NewRequest("POST", "/admin/arena", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer secret")
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != want {
+			t.Errorf("%s: got %d, want %d (%s)", body, rec.Code, want, rec.Body)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/Manishk5507/gaMerZ/backend/internal/events"
+	"github.com/Manishk5507/gaMerZ/backend/internal/games"
+)
+
+// battleship serves /games/battleship, vs AI or two seats ("p1" fires first,
+// "p2"). Unlike the other games the state is hidden information, so every
+// response is the view of the seat asking and spectators get the view of
+// neither seat. /move places or fires depending on the phase, so bots have a
+// single move endpoint like in the other games.
+type battleship struct{ *table[*games.Battleship] }
+
+func newBattleship(book *matchBook, watch *spectators) *battleship {
+	b := &battleship{}
+	b.table = newTable("battleship", book, watch, tableOps[*games.Battleship]{
+		Series: func(g *games.Battleship) **games.Series { return &g.Series },
+		Reset:  func(g *games.Battleship) error { g.Reset(); return nil },
+		Again:  func(g *games.Battleship) (*games.Battleship, error) { return games.NewBattleship(g.VsAI, g.Difficulty) },
+		Swap:   true, // the other side fires first
+		Result: func(g *games.Battleship) (map[string]string, map[string]any) {
+			if g.Phase != "over" { return nil, nil }
+			return bsOutcomes(g), bsStats(g)
+		},
+		Public: func(g *games.Battleship) any { return g.View("") },
+		State: func(r *http.Request, id string, g *games.Battleship) any {
+			return g.View(b.seatFor(r, id, g, r.URL.Query().Get("seat"), ""))
+		},
+	})
+	return b
+}
+
+// start creates a two player game for matchmaking, rooms and tournaments.
+func (b *battleship) start(seats map[string]string, rated bool) string {
+	b.mu.Lock(); defer b.mu.Unlock()
+	g, _ := games.NewBattleship(false, "")
+	return b.add(g, seats, rated)
+}
+
+// turn lists the seats still placing their fleet, then the seat to fire.
+func (b *battleship) turn(id string) (turnInfo, bool) {
+	b.mu.Lock(); defer b.mu.Unlock()
+	g, ok := b.games[id]
+	if !ok { return turnInfo{}, false }
+	info := turnInfo{ToMove: []string{}, Over: g.Phase == "over"}
+	switch g.Phase {
+	case "placing":
+		for _, seat := range []string{"p1", "p2"} {
+			if !g.Ready(seat) { info.ToMove = append(info.ToMove, seat) }
+		}
+	case "playing":
+		info.ToMove = []string{g.Turn}
+	}
+	return info, true
+}
+
+// legal offers a random fleet while placing and the squares not yet shot
+// at once playing, in the body format of /move.
+func (b *battleship) legal(id string) []any {
+	b.mu.Lock(); defer b.mu.Unlock()
+	out := []any{}
+	g, ok := b.games[id]
+	if !ok { return out }
+	if g.Phase == "placing" { return append(out, map[string]bool{"random": true}) }
+	for _, c := range g.Legal() { out = append(out, map[string]int{"row": c[0], "col": c[1]}) }
+	return out
+}
+
+// bsMove is the body of /place, /fire and /move.
+type bsMove struct {
+	Seat   string                `json:"seat"`
+	Ships  []games.ShipPlacement `json:"ships"`
+	Random bool                  `json:"random"`
+	Row    int                   `json:"row"`
+	Col    int                   `json:"col"`
+}
+
+func bsOutcomes(g *games.Battleship) map[string]string {
+	if g.Winner == "p1" { return map[string]string{"p1": events.Win, "p2": events.Loss} }
+	return map[string]string{"p1": events.Loss, "p2": events.Win}
+}
+
+func bsStats(g *games.Battleship) map[string]any {
+	return map[string]any{"vsAI": g.VsAI, "difficulty": g.Difficulty, "shots": len(g.Shots)}
+}
+
+// seatFor picks the seat a request speaks for: the one asked for if the
+// user may play it, else the one they hold, else p1 against the AI and
+// fallback (the seat to move) on a shared device. The AI's seat is never
+// given out.
+func (b *battleship) seatFor(r *http.Request, id string, g *games.Battleship, asked, fallback string) string {
+	user := userID(r)
+	if g.VsAI && asked != "" && asked != "p1" { return "" }
+	if asked != "" {
+		if b.book.canAct(id, asked, user) { return asked }
+		return ""
+	}
+	if seat := b.book.seatOf(id, user); seat != "" { return seat }
+	if g.VsAI { fallback = "p1" }
+	if fallback != "" && b.book.canAct(id, fallback, user) { return fallback }
+	return ""
+}
+
+func (b *battleship) mount(r chi.Router) {
+	b.table.mount(r)
+	book, watch, seatFor := b.book, b.watch, b.seatFor
+	// view answers with the state as seen from seat.
+	view := func(w http.ResponseWriter, status int, id string, g *games.Battleship, seat string, extra map[string]any) {
+		v := g.View(seat)
+		watch.setCount("battleship", id, v)
+		if extra == nil { writeJSON(w, status, v); return }
+		extra["state"] = v
+		writeJSON(w, status, extra)
+	}
+	r.Post("/battleship/new", func(w http.ResponseWriter, r *http.Request) {
+		b.mu.Lock(); defer b.mu.Unlock()
+		var body struct {
+			VsAI       bool              `json:"vsAI"`
+			Difficulty string            `json:"difficulty"` // easy, medium or hard (default)
+			Players    map[string]string `json:"players"`    // optional seat (p1, p2) -> user
+			Rated      bool              `json:"rated"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body) // optional body
+		seats := map[string]string{"p1": body.Players["p1"], "p2": body.Players["p2"]}
+		if body.VsAI { seats = map[string]string{"p1": userID(r), "p2": ""} }
+		if body.Rated && (body.VsAI || seats["p1"] == "" || seats["p2"] == "" || seats["p1"] == seats["p2"]) {
+			writeErr(w, http.StatusBadRequest, "rated games need two distinct human players"); return
+		}
+		g, err := games.NewBattleship(body.VsAI, body.Difficulty)
+		if err != nil { writeErr(w, http.StatusBadRequest, err.Error()); return }
+		id := b.add(g, seats, body.Rated)
+		view(w, http.StatusCreated, id, g, seatFor(r, id, g, "", "p1"), map[string]any{"gameId": id})
+	})
+	// place and fire play body for the request; callers hold b.mu.
+	place := func(w http.ResponseWriter, r *http.Request, id string, g *games.Battleship, body bsMove) {
+		seat := seatFor(r, id, g, body.Seat, "")
+		if seat == "" { writeErr(w, http.StatusForbidden, "not your seat"); return }
+		var err error
+		if body.Random {
+			err = g.PlaceRandom(seat)
+		} else {
+			err = g.Place(seat, body.Ships)
+		}
+		if err != nil { writeErr(w, http.StatusBadRequest, err.Error()); return }
+		b.publish(id, g)
+		view(w, http.StatusOK, id, g, seat, nil)
+	}
+	fire := func(w http.ResponseWriter, r *http.Request, id string, g *games.Battleship, body bsMove) {
+		seat := seatFor(r, id, g, body.Seat, g.Turn)
+		if seat == "" || seat != g.Turn { writeErr(w, http.StatusForbidden, "not your turn"); return }
+		shot, err := g.Fire(seat, body.Row, body.Col)
+		if errors.Is(err, games.ErrNotYourTurn) { writeErr(w, http.StatusForbidden, err.Error()); return }
+		if err != nil { writeErr(w, http.StatusBadRequest, err.Error()); return }
+		if g.Phase == "over" { book.finished(id, bsOutcomes(g), bsStats(g)) }
+		b.publish(id, g)
+		view(w, http.StatusOK, id, g, seat, map[string]any{"shot": shot})
+	}
+	// withBody decodes the body of a place, fire or move request for play.
+	withBody := func(play func(http.ResponseWriter, *http.Request, string, *games.Battleship, bsMove)) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			b.mu.Lock(); defer b.mu.Unlock()
+			id, g, ok := b.lookup(w, r)
+			if !ok || !book.writable(w, id) { return }
+			var body bsMove
+			if !decode(w, r, &body) { return }
+			play(w, r, id, g, body)
+		}
+	}
+	r.Post("/battleship/{id}/place", withBody(place))
+	r.Post("/battleship/{id}/fire", withBody(fire))
+	r.Post("/battleship/{id}/move", withBody(func(w http.ResponseWriter, r *http.Request, id string, g *games.Battleship, body bsMove) {
+		if g.Phase == "placing" { place(w, r, id, g, body); return }
+		fire(w, r, id, g, body)
+	}))
+}
@@ -0,0 +1,42 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// call sends a JSON request as user and decodes the JSON answer, if any.
+func call(t *testing.T, h http.Handler, method, path, user, body string) (int, map[string]any) {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("X-User-ID", user)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	var out map[string]any
+	_ = json.Unmarshal(rec.Body.Bytes(), &out)
+	return rec.Code, out
+}
+
+func TestBattleshipHidesTheAIFleet(t *testing.T) {
+	h := NewRouter()
+	code, out := call(t, h, "POST", "/games/battleship/new", "alice", `{"vsAI":true}`)
+	if code != http.StatusCreated {
+		t.Fatalf("new game: %d %v", code, out)
+	}
+	id := out["gameId"].(string)
+	for _, user := range []string{"alice", "mallory"} {
+		code, out = call(t, h, "GET", "/games/battleship/"+id+"?seat=p2", user, "")
+		if code == http.StatusOK && out["fleet"] != nil {
+			t.Errorf("%s got the AI fleet: %v", user, out["fleet"])
+		}
+	}
+	for _, path := range []string{"/place", "/move"} {
+		code, _ = call(t, h, "POST", "/games/battleship/"+id+path, "alice", `{"seat":"p2","random":true}`)
+		if code != http.StatusForbidden {
+			t.Errorf("%s as p2: got %d, want 403", path, code)
+		}
+	}
+}
@@ -0,0 +1,295 @@
+package httpapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"math/rand"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/Manishk5507/gaMerZ/backend/internal/bots"
+)
+
+// botOffline is how long a bot may go without any request before it counts
+// as crashed; its turns are then played by the server straight away.
+const botOffline = 30 * time.Second
+
+// botMoveTimeout reads BOT_MOVE_TIMEOUT (default 10s): how long a connected
+// bot has for a move before the server plays a random legal one for it.
+func botMoveTimeout() time.Duration {
+	v := os.Getenv("BOT_MOVE_TIMEOUT")
+	if v == "" { return 10 * time.Second }
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		log.Printf("[BOT] ignoring invalid BOT_MOVE_TIMEOUT %q", v)
+		return 10 * time.Second
+	}
+	return d
+}
+
+// legalFunc lists the moves of the side to move in game id, each in the body
+// format of that game's move endpoint, while holding its type's lock.
+type legalFunc func(id string) []any
+
+// botMovePaths is the move endpoint of every game bots can play.
+var botMovePaths = map[string]string{"tictactoe": "move", "connectfour": "move", "ultimate": "move", "checkers": "move", "reversi": "move", "chess": "move", "battleship": "move", "rps": "play"}
+
+// internalKey marks requests the server makes to itself on behalf of a bot;
+// they already carry the bot's identity.
+type internalKey struct{}
+
+// botTurn is what GET /bot/turn hands a bot: everything it needs to move.
+type botTurn struct {
+	GameType   string          `json:"gameType"`
+	GameID     string          `json:"gameId"`
+	Seat       string          `json:"seat"`
+	State      json.RawMessage `json:"state"`
+	LegalMoves []any           `json:"legalMoves"`
+	Deadline   time.Time       `json:"deadline"`
+}
+
+// pendingTurn is a turn the server is waiting on, keyed by game and seat;
+// version is the game's publish count when the turn was first seen.
+type pendingTurn struct {
+	version int
+	since   time.Time
+}
+
+// botAPI lets user-written bots play: accounts with tokens, a long-poll for
+// their next turn, a move endpoint and a watchdog that moves for bots that
+// are too slow or gone.
+type botAPI struct {
+	reg     *bots.Registry
+	timeout time.Duration
+	root    http.Handler
+	book    *matchBook
+	watch   *spectators
+	turns   map[string]turnFunc
+	legal   map[string]legalFunc
+
+	mu      sync.Mutex
+	pending map[string]pendingTurn
+}
+
+func newBotAPI(reg *bots.Registry) *botAPI {
+	return &botAPI{reg: reg, timeout: botMoveTimeout(), pending: map[string]pendingTurn{}}
+}
+
+// identity turns "Authorization: Bot <token>" into the bot's user id and
+// refuses bot ids presented without a token.
+func (b *botAPI) identity(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Context().Value(internalKey{}) != nil { next.ServeHTTP(w, r); return }
+		auth := r.Header.Get("Authorization")
+		if token := strings.TrimPrefix(auth, "Bot "); token != auth {
+			bot, ok := b.reg.Authenticate(token)
+			if !ok { writeErr(w, http.StatusUnauthorized, "invalid bot token"); return }
+			r.Header.Set("X-User-ID", bot.ID)
+			q := r.URL.Query()
+			q.Del("user")
+			r.URL.RawQuery = q.Encode()
+		} else if bots.IsBot(userID(r)) {
+			writeErr(w, http.StatusUnauthorized, "bot ids need a bot token"); return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// turnOf finds a game where bot is to move, the one due first.
+func (b *botAPI) turnOf(bot string) (botTurn, bool) {
+	var found []botTurn
+	for _, s := range b.book.gamesOf(bot) {
+		turn, ok := b.turns[s.Type]
+		if !ok || b.legal[s.Type] == nil { continue }
+		info, ok := turn(s.ID)
+		if !ok || info.Over || !hasSeat(info.ToMove, s.Seat) { continue }
+		deadline := b.due(s.Type, s.ID, s.Seat).Add(b.timeout)
+		if info.Deadline != nil && info.Deadline.Before(deadline) { deadline = *info.Deadline }
+		found = append(found, botTurn{GameType: s.Type, GameID: s.ID, Seat: s.Seat, Deadline: deadline})
+	}
+	if len(found) == 0 { return botTurn{}, false }
+	sort.Slice(found, func(i, j int) bool { return found[i].Deadline.Before(found[j].Deadline) })
+	t := found[0]
+	t.State, _ = b.watch.snapshots[t.GameType](t.GameID)
+	t.LegalMoves = b.legal[t.GameType](t.GameID)
+	return t, true
+}
+
+func hasSeat(seats []string, seat string) bool {
+	for _, s := range seats { if s == seat { return true } }
+	return false
+}
+
+// due returns when the current turn of seat in game id began.
+func (b *botAPI) due(game, id, seat string) time.Time {
+	v := b.watch.version(game, id)
+	key := id + ":" + seat
+	b.mu.Lock(); defer b.mu.Unlock()
+	p, ok := b.pending[key]
+	if !ok || p.version != v {
+		p = pendingTurn{version: v, since: time.Now()}
+		b.pending[key] = p
+	}
+	return p.since
+}
+
+// captured records the response of an internal request.
+type captured struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (c *captured) Header() http.Header         { return c.header }
+func (c *captured) Write(p []byte) (int, error) { return c.body.Write(p) }
+func (c *captured) WriteHeader(status int)      { c.status = status }
+
+// play sends move to game id's move endpoint as bot, so bots go through the
+// same checks, clocks and events as humans.
+func (b *botAPI) play(bot, game, id string, move json.RawMessage) *captured {
+	ctx := context.WithValue(context.Background(), internalKey{}, true)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "/games/"+game+"/"+id+"/"+botMovePaths[game], bytes.NewReader(move))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-User-ID", bot)
+	rec := &captured{header: http.Header{}, status: http.StatusOK}
+	b.root.ServeHTTP(rec, req)
+	return rec
+}
+
+// sweep plays a random legal move for every bot that let its turn run past
+// the timeout, or that is offline.
+func (b *botAPI) sweep() {
+	live := map[string]bool{}
+	// revoked bots keep their seats, so look at who sits in games rather
+	// than at the registry
+	for _, bot := range b.book.seatedUsers() {
+		if !bots.IsBot(bot) { continue }
+		online := b.reg.Online(bot, botOffline)
+		for _, s := range b.book.gamesOf(bot) {
+			turn, ok := b.turns[s.Type]
+			if !ok || b.legal[s.Type] == nil { continue }
+			info, ok := turn(s.ID)
+			if !ok || info.Over || !hasSeat(info.ToMove, s.Seat) { continue }
+			live[s.ID+":"+s.Seat] = true
+			if online && time.Since(b.due(s.Type, s.ID, s.Seat)) < b.timeout { continue }
+			moves := b.legal[s.Type](s.ID)
+			if len(moves) == 0 { continue }
+			move, _ := json.Marshal(moves[rand.Intn(len(moves))])
+			res := b.play(bot, s.Type, s.ID, move)
+			log.Printf("[BOT] %s missed its move in %s %s (online=%v), played %s -> %d", bot, s.Type, s.ID, online, move, res.status)
+			if res.status < 300 { b.reg.AutoMoved(bot) }
+		}
+	}
+	b.mu.Lock()
+	for key := range b.pending {
+		if !live[key] { delete(b.pending, key) }
+	}
+	b.mu.Unlock()
+}
+
+type botView struct {
+	bots.Bot
+	Online bool `json:"online"`
+}
+
+// mount adds /bots (accounts, for their human owners) and /bot (the bot
+// protocol), and starts the timeout watchdog.
+func (b *botAPI) mount(r chi.Router, root http.Handler, book *matchBook, watch *spectators, turns map[string]turnFunc, legal map[string]legalFunc) {
+	b.root, b.book, b.watch, b.turns, b.legal = root, book, watch, turns, legal
+	go func() {
+		for range time.Tick(250 * time.Millisecond) { b.sweep() }
+	}()
+
+	r.Route("/bots", func(r chi.Router) {
+		r.Post("/", func(w http.ResponseWriter, r *http.Request) {
+			var body struct { Name string `json:"name"` }
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil { writeErr(w, http.StatusBadRequest, "invalid body"); return }
+			bot, token, err := b.reg.Create(userID(r), body.Name)
+			switch {
+			case errors.Is(err, bots.ErrBadOwner):
+				writeErr(w, http.StatusForbidden, err.Error())
+			case errors.Is(err, bots.ErrNameTaken):
+				writeErr(w, http.StatusConflict, err.Error())
+			case errors.Is(err, bots.ErrBadName), errors.Is(err, bots.ErrTooMany):
+				writeErr(w, http.StatusBadRequest, err.Error())
+			case err != nil:
+				writeErr(w, http.StatusInternalServerError, err.Error())
+			default:
+				log.Printf("[BOT] %s created by %s", bot.ID, bot.Owner)
+				writeJSON(w, http.StatusCreated, map[string]any{"bot": bot, "token": token})
+			}
+		})
+		r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+			user := userID(r)
+			if user == "" || bots.IsBot(user) { writeErr(w, http.StatusUnauthorized, "missing user"); return }
+			out := []botView{}
+			for _, bot := range b.reg.List(user) { out = append(out, botView{bot, b.reg.Online(bot.ID, botOffline)}) }
+			writeJSON(w, http.StatusOK, out)
+		})
+		r.Delete("/{name}", func(w http.ResponseWriter, r *http.Request) {
+			user := userID(r)
+			if user == "" || bots.IsBot(user) { writeErr(w, http.StatusUnauthorized, "missing user"); return }
+			if err := b.reg.Revoke(user, chi.URLParam(r, "name")); err != nil { writeErr(w, http.StatusNotFound, err.Error()); return }
+			w.WriteHeader(http.StatusNoContent)
+		})
+	})
+
+	r.Route("/bot", func(r chi.Router) {
+		r.Use(func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if !bots.IsBot(userID(r)) { writeErr(w, http.StatusUnauthorized, "bot token required"); return }
+				next.ServeHTTP(w, r)
+			})
+		})
+		// GET /bot/turn?wait=30s blocks until the bot is to move somewhere
+		// (at most a minute) and answers 204 when nothing came up.
+		r.Get("/turn", func(w http.ResponseWriter, r *http.Request) {
+			wait := 30 * time.Second
+			if v := r.URL.Query().Get("wait"); v != "" {
+				d, err := time.ParseDuration(v)
+				if err != nil || d < 0 { writeErr(w, http.StatusBadRequest, "wait must be a duration such as 30s"); return }
+				wait = d
+			}
+			if wait > time.Minute { wait = time.Minute }
+			bot := userID(r)
+			timer := time.NewTimer(wait)
+			defer timer.Stop()
+			for {
+				changed := watch.changed() // before looking, so no publish is missed
+				if t, ok := b.turnOf(bot); ok { writeJSON(w, http.StatusOK, t); return }
+				select {
+				case <-changed:
+				case <-timer.C:
+					w.WriteHeader(http.StatusNoContent); return
+				case <-r.Context().Done():
+					return
+				}
+			}
+		})
+		// POST /bot/move { gameId, move } plays move, in the body format of the
+		// game's own move endpoint, and answers with that endpoint's response.
+		r.Post("/move", func(w http.ResponseWriter, r *http.Request) {
+			var body struct {
+				GameID string          `json:"gameId"`
+				Move   json.RawMessage `json:"move"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil || len(body.Move) == 0 { writeErr(w, http.StatusBadRequest, "invalid body"); return }
+			m, ok := book.get(body.GameID)
+			if !ok { http.NotFound(w, r); return }
+			if _, ok := botMovePaths[m.Type]; !ok { writeErr(w, http.StatusBadRequest, "bots cannot play "+m.Type); return }
+			res := b.play(userID(r), m.Type, body.GameID, body.Move)
+			for k, v := range res.header { w.Header()[k] = v }
+			w.WriteHeader(res.status)
+			w.Write(res.body.Bytes())
+		})
+	})
+}
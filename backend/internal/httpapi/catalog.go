@@ -0,0 +1,37 @@
+package httpapi
+
+// gameInfo describes one game type served by the hub. Seats lists the
+// playing positions, in turn order, of games that humans can play against
+// each other; single player games have none.
+type gameInfo struct {
+	ID    string   `json:"id"`
+	Name  string   `json:"name"`
+	Seats []string `json:"seats,omitempty"`
+}
+
+var catalog = []gameInfo{
+	{ID: "tictactoe", Name: "Tic Tac Toe", Seats: []string{"X", "O"}},
+	{ID: "numberguess", Name: "Number Guess"},
+	{ID: "rps", Name: "Rock Paper Scissors", Seats: []string{"p1", "p2"}},
+	{ID: "hangman", Name: "Hangman"},
+	{ID: "connectfour", Name: "Connect Four", Seats: []string{"R", "Y"}},
+	{ID: "ultimate", Name: "Ultimate Tic Tac Toe", Seats: []string{"X", "O"}},
+	{ID: "minesweeper", Name: "Minesweeper"},
+	{ID: "sudoku", Name: "Sudoku"},
+	{ID: "wordle", Name: "Wordle"},
+	{ID: "battleship", Name: "Battleship", Seats: []string{"p1", "p2"}},
+	{ID: "checkers", Name: "Checkers", Seats: []string{"B", "W"}},
+	{ID: "reversi", Name: "Reversi", Seats: []string{"B", "W"}},
+	{ID: "chess", Name: "Chess", Seats: []string{"W", "B"}},
+}
+
+// multiplayerSeats returns the seat names of every multiplayer game type.
+func multiplayerSeats() map[string][]string {
+	out := map[string][]string{}
+	for _, g := range catalog {
+		if len(g.Seats) > 0 {
+			out[g.ID] = g.Seats
+		}
+	}
+	return out
+}
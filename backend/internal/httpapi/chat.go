@@ -0,0 +1,95 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/Manishk5507/gaMerZ/backend/internal/chat"
+	"github.com/Manishk5507/gaMerZ/backend/internal/lobby"
+	"github.com/Manishk5507/gaMerZ/backend/internal/realtime"
+)
+
+// chatConfig applies CHAT_BLOCKLIST (comma separated words replacing the
+// built-in list) and CHAT_FILTER (mask, reject or off) on top of the defaults.
+func chatConfig() chat.Config {
+	cfg := chat.DefaultConfig
+	words := chat.DefaultBlocklist
+	if bl := os.Getenv("CHAT_BLOCKLIST"); bl != "" { words = strings.Split(bl, ",") }
+	switch os.Getenv("CHAT_FILTER") {
+	case "off":
+		cfg.Filter = nil
+	case "reject":
+		cfg.Filter = chat.NewFilter(words, chat.Reject)
+	default:
+		cfg.Filter = chat.NewFilter(words, chat.Mask)
+	}
+	return cfg
+}
+
+func chatErr(w http.ResponseWriter, err error) {
+	status := http.StatusBadRequest
+	switch {
+	case errors.Is(err, chat.ErrRateLimited):
+		status = http.StatusTooManyRequests
+	case errors.Is(err, chat.ErrMuted):
+		status = http.StatusForbidden
+	}
+	writeErr(w, status, err.Error())
+}
+
+// mountChat adds per room chat below /rooms. Messages are pushed as "chat"
+// events on the room's event stream.
+func mountChat(r chi.Router, hub *realtime.Hub, lob *lobby.Lobby, chats *chat.Service) {
+	// hostAction loads the room and checks the caller is its host.
+	hostAction := func(w http.ResponseWriter, r *http.Request) (lobby.Room, bool) {
+		room, err := lob.Get(chi.URLParam(r, "id"))
+		if err != nil { lobbyErr(w, err); return room, false }
+		if room.Host != userID(r) { lobbyErr(w, lobby.ErrNotHost); return room, false }
+		return room, true
+	}
+	announce := func(roomID, text string) {
+		hub.Publish(roomTopic(roomID), realtime.Message{Event: "chat", Data: chats.Announce(roomID, text)})
+	}
+
+	r.Get("/{id}/chat", func(w http.ResponseWriter, r *http.Request) {
+		room, ok := readable(w, r, lob)
+		if !ok { return }
+		writeJSON(w, http.StatusOK, chats.History(room.ID))
+	})
+	r.Post("/{id}/chat", func(w http.ResponseWriter, r *http.Request) {
+		id, user := chi.URLParam(r, "id"), userID(r)
+		room, err := lob.Get(id)
+		if err != nil { lobbyErr(w, err); return }
+		if !room.IsMember(user) { lobbyErr(w, lobby.ErrNotMember); return }
+		var body struct { Text string `json:"text"` }
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil { writeErr(w, http.StatusBadRequest, "invalid body"); return }
+		msg, err := chats.Post(id, user, body.Text)
+		if err != nil { chatErr(w, err); return }
+		hub.Publish(roomTopic(id), realtime.Message{Event: "chat", Data: msg})
+		writeJSON(w, http.StatusCreated, msg)
+	})
+	r.Post("/{id}/mute", func(w http.ResponseWriter, r *http.Request) {
+		room, ok := hostAction(w, r)
+		if !ok { return }
+		body := struct { User string `json:"user"`; Muted bool `json:"muted"` }{Muted: true}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.User == "" { writeErr(w, http.StatusBadRequest, "invalid body"); return }
+		chats.SetMuted(room.ID, body.User, body.Muted)
+		if body.Muted { announce(room.ID, body.User+" was muted by the host") } else { announce(room.ID, body.User+" was unmuted") }
+		w.WriteHeader(http.StatusNoContent)
+	})
+	r.Post("/{id}/kick", func(w http.ResponseWriter, r *http.Request) {
+		room, ok := hostAction(w, r)
+		if !ok { return }
+		var body struct { User string `json:"user"` }
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.User == "" { writeErr(w, http.StatusBadRequest, "invalid body"); return }
+		room, err := lob.Kick(room.ID, userID(r), body.User)
+		if err != nil { lobbyErr(w, err); return }
+		announce(room.ID, body.User+" was removed by the host")
+		writeJSON(w, http.StatusOK, room)
+	})
+}
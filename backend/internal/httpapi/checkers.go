@@ -0,0 +1,102 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/Manishk5507/gaMerZ/backend/internal/events"
+	"github.com/Manishk5507/gaMerZ/backend/internal/games"
+)
+
+// checkers serves /games/checkers with the same shape as Connect Four: vs AI
+// or two seats ("B" opens, "W"), rated play, undo, reset and rematch. Moves
+// are in standard notation, e.g. "11-15" or "15x24x31".
+type checkers struct{ *table[*games.Checkers] }
+
+func newCheckers(book *matchBook, watch *spectators) *checkers {
+	return &checkers{newTable("checkers", book, watch, tableOps[*games.Checkers]{
+		Series: func(g *games.Checkers) **games.Series { return &g.Series },
+		Reset:  func(g *games.Checkers) error { g.Reset(); return nil },
+		Undo:   (*games.Checkers).Undo,
+		Again:  func(g *games.Checkers) (*games.Checkers, error) { return games.NewCheckers(g.VsAI, g.Difficulty) },
+		Swap:   true,
+		Result: func(g *games.Checkers) (map[string]string, map[string]any) {
+			if g.Winner == "" { return nil, nil }
+			return ckOutcomes(g), ckStats(g)
+		},
+	})}
+}
+
+// start creates a two player game for matchmaking, rooms and tournaments.
+func (c *checkers) start(seats map[string]string, rated bool) string {
+	c.mu.Lock(); defer c.mu.Unlock()
+	g, _ := games.NewCheckers(false, "")
+	return c.add(g, seats, rated)
+}
+
+func (c *checkers) turn(id string) (turnInfo, bool) {
+	c.mu.Lock(); defer c.mu.Unlock()
+	g, ok := c.games[id]
+	if !ok { return turnInfo{}, false }
+	return turnInfo{ToMove: []string{g.CurrentPlayer}, Over: g.Winner != ""}, true
+}
+
+func (c *checkers) legal(id string) []any {
+	c.mu.Lock(); defer c.mu.Unlock()
+	out := []any{}
+	if g, ok := c.games[id]; ok {
+		for _, m := range g.Legal { out = append(out, map[string]string{"move": m.Notation}) }
+	}
+	return out
+}
+
+// ckOutcomes maps a finished board to per seat outcomes.
+func ckOutcomes(g *games.Checkers) map[string]string {
+	switch g.Winner {
+	case "B":
+		return map[string]string{"B": events.Win, "W": events.Loss}
+	case "W":
+		return map[string]string{"B": events.Loss, "W": events.Win}
+	}
+	return map[string]string{"B": events.Draw, "W": events.Draw}
+}
+
+func ckStats(g *games.Checkers) map[string]any {
+	return map[string]any{"vsAI": g.VsAI, "difficulty": g.Difficulty, "moves": len(g.Moves), "drawReason": g.DrawReason}
+}
+
+func (c *checkers) mount(r chi.Router) {
+	c.table.mount(r)
+	r.Post("/checkers/new", func(w http.ResponseWriter, r *http.Request) {
+		c.mu.Lock(); defer c.mu.Unlock()
+		var body struct {
+			VsAI       bool              `json:"vsAI"`
+			Difficulty string            `json:"difficulty"` // easy, medium (default) or hard
+			Players    map[string]string `json:"players"`    // optional seat (B, W) -> user
+			Rated      bool              `json:"rated"`
+			Position   string            `json:"position"` // optional start in PDN FEN, unrated only
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body) // optional body
+		seats := map[string]string{"B": body.Players["B"], "W": body.Players["W"]}
+		if body.VsAI { seats = map[string]string{"B": userID(r), "W": ""} }
+		if body.Rated && (body.VsAI || seats["B"] == "" || seats["W"] == "" || seats["B"] == seats["W"] || body.Position != "") {
+			writeErr(w, http.StatusBadRequest, "rated games need two distinct human players and the initial position"); return
+		}
+		g, err := games.NewCheckers(body.VsAI, body.Difficulty)
+		if err != nil { writeErr(w, http.StatusBadRequest, err.Error()); return }
+		if body.Position != "" {
+			if err := g.SetPosition(body.Position); err != nil { writeErr(w, http.StatusBadRequest, err.Error()); return }
+		}
+		id := c.add(g, seats, body.Rated)
+		c.respond(w, r, http.StatusCreated, id, g, map[string]any{"gameId": id})
+	})
+	r.Post("/checkers/{id}/move", c.handle(func(w http.ResponseWriter, r *http.Request, id string, g *games.Checkers) bool {
+		var body struct { Move string `json:"move"` }
+		if !decode(w, r, &body) { return false }
+		if !c.book.canAct(id, g.CurrentPlayer, userID(r)) { writeErr(w, http.StatusForbidden, "not your turn"); return false }
+		if err := g.Move(body.Move); err != nil { writeErr(w, http.StatusBadRequest, err.Error()); return false }
+		return true
+	}))
+}
@@ -0,0 +1,122 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/Manishk5507/gaMerZ/backend/internal/events"
+	"github.com/Manishk5507/gaMerZ/backend/internal/games"
+)
+
+// chess serves /games/chess like Checkers: vs AI with a choice of colour or
+// two seats ("W" opens, "B"), rated play, undo, reset and rematch, plus the
+// game as PGN. Moves are in UCI ("e2e4") or SAN ("Nf3").
+type chess struct{ *table[*games.Chess] }
+
+func newChess(book *matchBook, watch *spectators) *chess {
+	return &chess{newTable("chess", book, watch, tableOps[*games.Chess]{
+		Series: func(g *games.Chess) **games.Series { return &g.Series },
+		Reset:  func(g *games.Chess) error { g.Reset(); return nil },
+		Undo:   (*games.Chess).Undo,
+		Again:  func(g *games.Chess) (*games.Chess, error) {
+			ng, err := games.NewChess(g.VsAI, g.Difficulty)
+			if err == nil && g.VsAI { err = ng.PlayAs(g.HumanPlays) }
+			return ng, err
+		},
+		Swap:   true,
+		Result: func(g *games.Chess) (map[string]string, map[string]any) {
+			if g.Winner == "" { return nil, nil }
+			return chOutcomes(g), chStats(g)
+		},
+	})}
+}
+
+// start creates a two player game for matchmaking, rooms and tournaments.
+func (c *chess) start(seats map[string]string, rated bool) string {
+	c.mu.Lock(); defer c.mu.Unlock()
+	g, _ := games.NewChess(false, "")
+	return c.add(g, seats, rated)
+}
+
+func (c *chess) turn(id string) (turnInfo, bool) {
+	c.mu.Lock(); defer c.mu.Unlock()
+	g, ok := c.games[id]
+	if !ok { return turnInfo{}, false }
+	return turnInfo{ToMove: []string{g.CurrentPlayer}, Over: g.Winner != ""}, true
+}
+
+func (c *chess) legal(id string) []any {
+	c.mu.Lock(); defer c.mu.Unlock()
+	out := []any{}
+	if g, ok := c.games[id]; ok {
+		for _, m := range g.Legal { out = append(out, map[string]string{"move": m.UCI}) }
+	}
+	return out
+}
+
+// chOutcomes maps a finished board to per seat outcomes.
+func chOutcomes(g *games.Chess) map[string]string {
+	switch g.Winner {
+	case "W":
+		return map[string]string{"W": events.Win, "B": events.Loss}
+	case "B":
+		return map[string]string{"W": events.Loss, "B": events.Win}
+	}
+	return map[string]string{"W": events.Draw, "B": events.Draw}
+}
+
+func chStats(g *games.Chess) map[string]any {
+	return map[string]any{"vsAI": g.VsAI, "difficulty": g.Difficulty, "moves": len(g.Moves), "drawReason": g.DrawReason}
+}
+
+func (c *chess) mount(r chi.Router) {
+	c.table.mount(r)
+	r.Post("/chess/new", func(w http.ResponseWriter, r *http.Request) {
+		c.mu.Lock(); defer c.mu.Unlock()
+		var body struct {
+			VsAI       bool              `json:"vsAI"`
+			Difficulty string            `json:"difficulty"` // easy, medium (default) or hard
+			HumanPlays string            `json:"humanPlays"` // W (default) or B against the AI
+			Players    map[string]string `json:"players"`    // optional seat (W, B) -> user
+			Rated      bool              `json:"rated"`
+			Position   string            `json:"position"` // optional start in FEN, unrated only
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body) // optional body
+		if body.HumanPlays == "" { body.HumanPlays = "W" }
+		seats := map[string]string{"W": body.Players["W"], "B": body.Players["B"]}
+		if body.VsAI { seats = map[string]string{"W": "", "B": ""}; seats[body.HumanPlays] = userID(r) }
+		if body.Rated && (body.VsAI || seats["W"] == "" || seats["B"] == "" || seats["W"] == seats["B"] || body.Position != "") {
+			writeErr(w, http.StatusBadRequest, "rated games need two distinct human players and the initial position"); return
+		}
+		g, err := games.NewChess(body.VsAI, body.Difficulty)
+		if err == nil { err = g.PlayAs(body.HumanPlays) }
+		if err == nil && body.Position != "" { err = g.SetPosition(body.Position) } // an AI to move answers here
+		if err != nil { writeErr(w, http.StatusBadRequest, err.Error()); return }
+		id := c.add(g, seats, body.Rated)
+		c.respond(w, r, http.StatusCreated, id, g, map[string]any{"gameId": id})
+	})
+	// the game in PGN, players named by user id ("AI" for the engine)
+	r.Get("/chess/{id}/pgn", func(w http.ResponseWriter, r *http.Request) {
+		c.mu.Lock(); defer c.mu.Unlock()
+		id, g, ok := c.lookup(w, r)
+		if !ok { return }
+		if c.watch.outsider("chess", id, userID(r)) { writeErr(w, http.StatusForbidden, "the PGN of a live game is for its players"); return }
+		m, _ := c.book.get(id)
+		name := func(seat string) string {
+			if g.VsAI && seat != g.HumanPlays { return "AI" }
+			return m.Seats[seat]
+		}
+		w.Header().Set("Content-Type", "application/x-chess-pgn")
+		w.Header().Set("Content-Disposition", `attachment; filename="`+id+`.pgn"`)
+		_, _ = w.Write([]byte(g.PGN(name("W"), name("B"))))
+	})
+	r.Post("/chess/{id}/move", c.handle(func(w http.ResponseWriter, r *http.Request, id string, g *games.Chess) bool {
+		var body struct { Move string `json:"move"` }
+		if !decode(w, r, &body) { return false }
+		if !c.book.canAct(id, g.CurrentPlayer, userID(r)) { writeErr(w, http.StatusForbidden, "not your turn"); return false }
+		if err := g.Move(body.Move); err != nil { writeErr(w, http.StatusBadRequest, err.Error()); return false }
+		return true
+	}))
+}
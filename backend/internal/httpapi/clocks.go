@@ -0,0 +1,48 @@
+package httpapi
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Manishk5507/gaMerZ/backend/internal/games"
+)
+
+// clocks enforces time controls: every timed game has at most one pending
+// timer firing at its clock's next deadline, so a stalling player is flagged
+// even if nobody sends another request.
+type clocks struct {
+	mu     sync.Mutex
+	timers map[string]*time.Timer
+}
+
+func newClocks() *clocks { return &clocks{timers: map[string]*time.Timer{}} }
+
+// arm replaces the timer of game id with one for clock's next deadline, or
+// just cancels it when no seat is on the clock. Callers hold the game lock;
+// expire must take it itself.
+func (c *clocks) arm(id string, clock *games.Clock, expire func(id string)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if t := c.timers[id]; t != nil {
+		t.Stop()
+		delete(c.timers, id)
+	}
+	if clock == nil { return }
+	if d, ok := clock.Deadline(); ok {
+		c.timers[id] = time.AfterFunc(time.Until(d), func() { expire(id) })
+	}
+}
+
+// timeoutSeat decides what to do with an expired seat: it loses when the
+// policy says so or when its whole bank is gone; otherwise its time is
+// charged and the caller plays a move for it (auto is true).
+func timeoutSeat(clock *games.Clock, now time.Time) (seat string, auto bool, ok bool) {
+	seat, ok = clock.Expired(now)
+	if !ok { return "", false, false }
+	if clock.Control.OnTimeout == games.TimeoutLoss || clock.OutOfBank(seat, now) {
+		clock.Flag(seat, now)
+		return seat, false, true
+	}
+	clock.Moved(seat, now)
+	return seat, true, true
+}
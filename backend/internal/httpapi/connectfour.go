@@ -0,0 +1,95 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/Manishk5507/gaMerZ/backend/internal/events"
+	"github.com/Manishk5507/gaMerZ/backend/internal/games"
+)
+
+// connectFour serves /games/connectfour with the same shape as TicTacToe:
+// vs AI or two seats ("R" opens, "Y"), rated play, undo, reset and rematch.
+type connectFour struct{ *table[*games.ConnectFour] }
+
+func newConnectFour(book *matchBook, watch *spectators) *connectFour {
+	return &connectFour{newTable("connectfour", book, watch, tableOps[*games.ConnectFour]{
+		Series: func(g *games.ConnectFour) **games.Series { return &g.Series },
+		Reset:  func(g *games.ConnectFour) error { g.Reset(); return nil },
+		Undo:   (*games.ConnectFour).Undo,
+		Again:  func(g *games.ConnectFour) (*games.ConnectFour, error) { return games.NewConnectFour(g.VsAI, g.Difficulty), nil },
+		Swap:   true,
+		Result: func(g *games.ConnectFour) (map[string]string, map[string]any) {
+			if g.Winner == "" { return nil, nil }
+			return c4Outcomes(g), c4Stats(g)
+		},
+	})}
+}
+
+// start creates a two player game for matchmaking, rooms and tournaments.
+func (c *connectFour) start(seats map[string]string, rated bool) string {
+	c.mu.Lock(); defer c.mu.Unlock()
+	return c.add(games.NewConnectFour(false, ""), seats, rated)
+}
+
+func (c *connectFour) turn(id string) (turnInfo, bool) {
+	c.mu.Lock(); defer c.mu.Unlock()
+	g, ok := c.games[id]
+	if !ok { return turnInfo{}, false }
+	return turnInfo{ToMove: []string{g.CurrentPlayer}, Over: g.Winner != ""}, true
+}
+
+func (c *connectFour) legal(id string) []any {
+	c.mu.Lock(); defer c.mu.Unlock()
+	out := []any{}
+	if g, ok := c.games[id]; ok {
+		for _, col := range g.Legal() { out = append(out, map[string]int{"col": col}) }
+	}
+	return out
+}
+
+// c4Outcomes maps a finished board to per seat outcomes.
+func c4Outcomes(g *games.ConnectFour) map[string]string {
+	switch g.Winner {
+	case "R":
+		return map[string]string{"R": events.Win, "Y": events.Loss}
+	case "Y":
+		return map[string]string{"R": events.Loss, "Y": events.Win}
+	}
+	return map[string]string{"R": events.Draw, "Y": events.Draw}
+}
+
+func c4Stats(g *games.ConnectFour) map[string]any {
+	return map[string]any{"vsAI": g.VsAI, "difficulty": g.Difficulty, "moves": len(g.Moves)}
+}
+
+func (c *connectFour) mount(r chi.Router) {
+	c.table.mount(r)
+	r.Post("/connectfour/new", func(w http.ResponseWriter, r *http.Request) {
+		c.mu.Lock(); defer c.mu.Unlock()
+		var body struct {
+			VsAI       bool              `json:"vsAI"`
+			Difficulty string            `json:"difficulty"` // easy, medium (default) or hard
+			Players    map[string]string `json:"players"`    // optional seat (R, Y) -> user
+			Rated      bool              `json:"rated"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body) // optional body
+		seats := map[string]string{"R": body.Players["R"], "Y": body.Players["Y"]}
+		if body.VsAI { seats = map[string]string{"R": userID(r), "Y": ""} }
+		if body.Rated && (body.VsAI || seats["R"] == "" || seats["Y"] == "" || seats["R"] == seats["Y"]) {
+			writeErr(w, http.StatusBadRequest, "rated games need two distinct human players"); return
+		}
+		g := games.NewConnectFour(body.VsAI, body.Difficulty)
+		id := c.add(g, seats, body.Rated)
+		c.respond(w, r, http.StatusCreated, id, g, map[string]any{"gameId": id})
+	})
+	r.Post("/connectfour/{id}/move", c.handle(func(w http.ResponseWriter, r *http.Request, id string, g *games.ConnectFour) bool {
+		var body struct { Col int `json:"col"` }
+		if !decode(w, r, &body) { return false }
+		if !c.book.canAct(id, g.CurrentPlayer, userID(r)) { writeErr(w, http.StatusForbidden, "not your turn"); return false }
+		if !g.Drop(body.Col) { writeErr(w, http.StatusBadRequest, "invalid move"); return false }
+		return true
+	}))
+}
@@ -0,0 +1,331 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/Manishk5507/gaMerZ/backend/internal/events"
+	"github.com/Manishk5507/gaMerZ/backend/internal/games"
+)
+
+// userID identifies the caller. There are no accounts yet, so clients simply
+// send a stable id in the X-User-ID header (not for production). EventSource
+// cannot set headers, so a "user" query parameter is accepted as well.
+func userID(r *http.Request) string {
+	if u := r.Header.Get("X-User-ID"); u != "" {
+		return u
+	}
+	return r.URL.Query().Get("user")
+}
+
+// match is the bookkeeping kept next to a game: who sits where and whether
+// the result counts for ratings. Seats with an empty user are the AI or an
+// unclaimed hot-seat side.
+type match struct {
+	Type     string
+	Seats    map[string]string // seat -> user
+	Rated    bool
+	round    int
+	reported bool
+	result   map[string]string // seat -> outcome of the last finished round
+	archived bool              // replaced by a rematch, read only from now on
+	assisted bool              // undo or live analysis was used this round
+	series   *games.Series
+}
+
+var (
+	errNotFinished = errors.New("game is not finished yet")
+	errArchived    = errors.New("game was archived by a rematch")
+	errNotSeated   = errors.New("only players of this game can ask for a rematch")
+)
+
+// participant is how a seat is identified in a series: its user, or the seat
+// name itself when nobody claimed it.
+func participant(seat, user string) string {
+	if user != "" {
+		return user
+	}
+	return seat
+}
+
+// matchBook tracks matches by game id and publishes a GameFinished event the
+// first time a game is seen in a final state.
+type matchBook struct {
+	mu  sync.Mutex
+	m   map[string]*match
+	bus *events.Bus
+
+	onArchive func(gameType, id string) // called with the lock held
+}
+
+func newMatchBook(bus *events.Bus) *matchBook {
+	return &matchBook{m: map[string]*match{}, bus: bus}
+}
+
+func (b *matchBook) register(id, gameType string, seats map[string]string, rated bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if seats == nil {
+		seats = map[string]string{}
+	}
+	b.m[id] = &match{Type: gameType, Seats: seats, Rated: rated}
+}
+
+func (b *matchBook) get(id string) (match, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	m, ok := b.m[id]
+	if !ok {
+		return match{}, false
+	}
+	return *m, true
+}
+
+// canAct reports whether user may play for seat. Unassigned seats are open
+// to anyone so anonymous single-device play keeps working.
+func (b *matchBook) canAct(id, seat, user string) bool {
+	m, ok := b.get(id)
+	if !ok {
+		return true
+	}
+	owner := m.Seats[seat]
+	return owner == "" || owner == user
+}
+
+func seriesWinner(m *match) string {
+	for seat, out := range m.result {
+		if out == events.Win {
+			return participant(seat, m.Seats[seat])
+		}
+	}
+	return ""
+}
+
+func (b *matchBook) archived(id string) bool {
+	m, ok := b.get(id)
+	return ok && m.archived
+}
+
+// writable rejects changes to games replaced by a rematch.
+func (b *matchBook) writable(w http.ResponseWriter, id string) bool {
+	if b.archived(id) {
+		writeErr(w, http.StatusConflict, errArchived.Error())
+		return false
+	}
+	return true
+}
+
+// rematchBody is the optional body of every /rematch endpoint.
+type rematchBody struct {
+	BestOf int `json:"bestOf"`
+}
+
+// rematch archives the finished game id and registers newID with the same
+// type, rating mode and series. Once any seat is claimed only a user holding
+// one may ask for it. Human seats are swapped when swap is set so the other
+// player moves first. A series is started (best of bestOf) on the first
+// rematch, or again once the previous one is decided.
+func (b *matchBook) rematch(id, newID, user string, bestOf int, swap bool) (*games.Series, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	m, ok := b.m[id]
+	if !ok || !m.reported {
+		return nil, errNotFinished
+	}
+	if m.archived {
+		return nil, errArchived
+	}
+	claimed, seated := false, false
+	for _, u := range m.Seats {
+		claimed = claimed || u != ""
+		seated = seated || (u != "" && u == user)
+	}
+	if claimed && !seated {
+		return nil, errNotSeated
+	}
+	m.archived = true
+	if b.onArchive != nil {
+		b.onArchive(m.Type, id)
+	}
+	seats := map[string]string{}
+	for s, u := range m.Seats {
+		seats[s] = u
+	}
+	if swap && len(seats) == 2 {
+		var names []string
+		for s := range seats {
+			names = append(names, s)
+		}
+		a, c := seats[names[0]], seats[names[1]]
+		if a != "" && c != "" && a != c {
+			seats[names[0]], seats[names[1]] = c, a
+		}
+	}
+	series := m.series
+	if series == nil || series.Finished {
+		if series != nil && bestOf < 1 {
+			bestOf = series.BestOf
+		}
+		var ps []string
+		for s, u := range m.Seats {
+			ps = append(ps, participant(s, u))
+		}
+		series = games.NewSeries(bestOf, ps...)
+		if m.series == nil {
+			series.Record(id, seriesWinner(m))
+			m.series = series
+		}
+	}
+	b.m[newID] = &match{Type: m.Type, Seats: seats, Rated: m.Rated, series: series}
+	return series, nil
+}
+
+// swapSeats exchanges the users of the two seats of id. Rematches against
+// the AI use it when the human changes sides.
+func (b *matchBook) swapSeats(id string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	m, ok := b.m[id]
+	if !ok || len(m.Seats) != 2 {
+		return
+	}
+	var names []string
+	for s := range m.Seats {
+		names = append(names, s)
+	}
+	m.Seats[names[0]], m.Seats[names[1]] = m.Seats[names[1]], m.Seats[names[0]]
+}
+
+// rematchErr answers a failed rematch: 403 for outsiders, 409 otherwise.
+func rematchErr(w http.ResponseWriter, err error) {
+	if errors.Is(err, errNotSeated) {
+		writeErr(w, http.StatusForbidden, err.Error())
+		return
+	}
+	writeErr(w, http.StatusConflict, err.Error())
+}
+
+// seatOf returns the seat held by user, or "" if they hold none.
+func (b *matchBook) seatOf(id, user string) string {
+	m, ok := b.get(id)
+	if !ok || user == "" {
+		return ""
+	}
+	for seat, u := range m.Seats {
+		if u == user {
+			return seat
+		}
+	}
+	return ""
+}
+
+// rearm starts a new round so a reset or undone game can report its next
+// result.
+func (b *matchBook) rearm(id string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	m, ok := b.m[id]
+	if !ok {
+		return
+	}
+	m.assisted = false
+	if m.reported {
+		m.reported = false
+		m.round++
+	}
+}
+
+// assist marks the current round as played with undo or analysis; its result
+// is published with the "assisted" stat so achievements skip it.
+func (b *matchBook) assist(id string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if m, ok := b.m[id]; ok {
+		m.assisted = true
+	}
+}
+
+// finished publishes the event built from outcomes (seat -> outcome) once per
+// game. stats carries game specific facts for subscribers.
+func (b *matchBook) finished(id string, outcomes map[string]string, stats map[string]any) {
+	b.mu.Lock()
+	m, ok := b.m[id]
+	if !ok || m.reported {
+		b.mu.Unlock()
+		return
+	}
+	m.reported = true
+	m.result = outcomes
+	if m.series != nil {
+		m.series.Record(id, seriesWinner(m))
+	}
+	if m.assisted {
+		if stats == nil {
+			stats = map[string]any{}
+		}
+		stats["assisted"] = true
+	}
+	e := events.GameFinished{GameType: m.Type, GameID: id, Round: m.round, Rated: m.Rated, Stats: stats}
+	for seat, out := range outcomes {
+		e.Players = append(e.Players, events.PlayerResult{User: m.Seats[seat], Seat: seat, Outcome: out})
+	}
+	b.mu.Unlock()
+	sort.Slice(e.Players, func(i, j int) bool { return e.Players[i].Seat < e.Players[j].Seat })
+	b.bus.Publish(e)
+}
+
+// seated is one game a user holds a seat in.
+type seated struct {
+	ID    string
+	Type  string
+	Seat  string
+	Seats map[string]string
+}
+
+// gamesOf lists the live (not archived) games where user holds a seat.
+func (b *matchBook) gamesOf(user string) []seated {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var out []seated
+	if user == "" {
+		return out
+	}
+	for id, m := range b.m {
+		if m.archived {
+			continue
+		}
+		for seat, u := range m.Seats {
+			if u == user {
+				seats := map[string]string{}
+				for s, u := range m.Seats {
+					seats[s] = u
+				}
+				out = append(out, seated{ID: id, Type: m.Type, Seat: seat, Seats: seats})
+				break
+			}
+		}
+	}
+	return out
+}
+
+// seatedUsers lists every user holding a seat in a live game.
+func (b *matchBook) seatedUsers() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	seen := map[string]bool{}
+	var out []string
+	for _, m := range b.m {
+		if m.archived {
+			continue
+		}
+		for _, u := range m.Seats {
+			if u != "" && !seen[u] {
+				seen[u] = true
+				out = append(out, u)
+			}
+		}
+	}
+	return out
+}
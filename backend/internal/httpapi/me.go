@@ -0,0 +1,100 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"log"
+	"net"
+	"net/http"
+	"net/smtp"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/Manishk5507/gaMerZ/backend/internal/notify"
+)
+
+// notifiers builds the notification channels: webhooks always (limited to
+// public addresses unless WEBHOOK_ALLOW_PRIVATE=1), email when SMTP_ADDR
+// (host:port) is set, with SMTP_FROM and optional SMTP_USER / SMTP_PASSWORD.
+func notifiers() []notify.Notifier {
+	out := []notify.Notifier{notify.Webhook{AllowPrivate: os.Getenv("WEBHOOK_ALLOW_PRIVATE") == "1"}}
+	addr := os.Getenv("SMTP_ADDR")
+	if addr == "" { return out }
+	mailer := notify.SMTP{Addr: addr, From: os.Getenv("SMTP_FROM")}
+	if mailer.From == "" { mailer.From = "gamerz@localhost" }
+	if user := os.Getenv("SMTP_USER"); user != "" {
+		host, _, _ := net.SplitHostPort(addr)
+		mailer.Auth = smtp.PlainAuth("", user, os.Getenv("SMTP_PASSWORD"), host)
+	}
+	log.Printf("[NOTIFY] email via %s", addr)
+	return append(out, mailer)
+}
+
+// turnInfo is the progress of a game as seen by /me/games.
+type turnInfo struct {
+	ToMove         []string   // seats expected to move
+	Over           bool
+	Correspondence bool
+	Deadline       *time.Time // when the side to move runs out of time
+}
+
+// turnFunc reports the progress of game id while holding its type's lock.
+type turnFunc func(id string) (turnInfo, bool)
+
+type myGame struct {
+	GameID         string            `json:"gameId"`
+	Type           string            `json:"type"`
+	Seat           string            `json:"seat"`
+	Players        map[string]string `json:"players"`
+	MyTurn         bool              `json:"myTurn"`
+	Correspondence bool              `json:"correspondence"`
+	Deadline       *time.Time        `json:"deadline,omitempty"`
+}
+
+func mountMe(r chi.Router, book *matchBook, turns map[string]turnFunc, notes *notify.Service) {
+	r.Route("/me", func(r chi.Router) {
+		// GET /me/games?turn=mine|theirs lists unfinished games the caller sits in,
+		// games waiting for the caller first, then by deadline.
+		r.Get("/games", func(w http.ResponseWriter, r *http.Request) {
+			user := userID(r)
+			if user == "" { writeErr(w, http.StatusUnauthorized, "missing user"); return }
+			filter := r.URL.Query().Get("turn")
+			if filter != "" && filter != "mine" && filter != "theirs" { writeErr(w, http.StatusBadRequest, "turn must be mine or theirs"); return }
+			out := []myGame{}
+			for _, s := range book.gamesOf(user) {
+				turn, ok := turns[s.Type]
+				if !ok { continue }
+				info, ok := turn(s.ID)
+				if !ok || info.Over { continue }
+				g := myGame{GameID: s.ID, Type: s.Type, Seat: s.Seat, Players: s.Seats, Correspondence: info.Correspondence, Deadline: info.Deadline}
+				for _, seat := range info.ToMove { if seat == s.Seat { g.MyTurn = true } }
+				if (filter == "mine" && !g.MyTurn) || (filter == "theirs" && g.MyTurn) { continue }
+				out = append(out, g)
+			}
+			sort.Slice(out, func(i, j int) bool {
+				a, b := out[i], out[j]
+				if a.MyTurn != b.MyTurn { return a.MyTurn }
+				if (a.Deadline == nil) != (b.Deadline == nil) { return a.Deadline != nil }
+				if a.Deadline != nil && !a.Deadline.Equal(*b.Deadline) { return a.Deadline.Before(*b.Deadline) }
+				return a.GameID < b.GameID
+			})
+			writeJSON(w, http.StatusOK, out)
+		})
+		r.Get("/notifications", func(w http.ResponseWriter, r *http.Request) {
+			user := userID(r)
+			if user == "" { writeErr(w, http.StatusUnauthorized, "missing user"); return }
+			writeJSON(w, http.StatusOK, notes.Contact(user))
+		})
+		// PUT /me/notifications { webhook?, email? }; an empty body turns notifications off.
+		r.Put("/notifications", func(w http.ResponseWriter, r *http.Request) {
+			user := userID(r)
+			if user == "" { writeErr(w, http.StatusUnauthorized, "missing user"); return }
+			var c notify.Contact
+			if err := json.NewDecoder(r.Body).Decode(&c); err != nil { writeErr(w, http.StatusBadRequest, "invalid body"); return }
+			if err := notes.SetContact(user, c); err != nil { writeErr(w, http.StatusBadRequest, err.Error()); return }
+			writeJSON(w, http.StatusOK, c)
+		})
+	})
+}
@@ -0,0 +1,70 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/Manishk5507/gaMerZ/backend/internal/events"
+	"github.com/Manishk5507/gaMerZ/backend/internal/games"
+)
+
+// minesweeper serves /games/minesweeper, a single player game like Hangman:
+// reveal, flag and chord cells by row and column, reset and rematch.
+type minesweeper struct{ *table[*games.Minesweeper] }
+
+func newMinesweeper(book *matchBook, watch *spectators) *minesweeper {
+	return &minesweeper{newTable("minesweeper", book, watch, tableOps[*games.Minesweeper]{
+		Series: func(g *games.Minesweeper) **games.Series { return &g.Series },
+		Reset:  func(g *games.Minesweeper) error { g.Reset(); return nil },
+		Again:  func(g *games.Minesweeper) (*games.Minesweeper, error) { return g.Again(), nil },
+		Result: func(g *games.Minesweeper) (map[string]string, map[string]any) {
+			if !g.Over() { return nil, nil }
+			out := events.Loss
+			if g.Status == "won" { out = events.Win }
+			return map[string]string{"player": out}, mineStats(g)
+		},
+	})}
+}
+
+func mineStats(g *games.Minesweeper) map[string]any {
+	return map[string]any{"difficulty": g.Difficulty, "noGuess": g.NoGuess, "seconds": g.Seconds, "moves": g.Moves, "mines": g.Mines}
+}
+
+func (m *minesweeper) mount(r chi.Router) {
+	m.table.mount(r)
+	r.Post("/minesweeper/new", func(w http.ResponseWriter, r *http.Request) {
+		m.mu.Lock(); defer m.mu.Unlock()
+		var body struct {
+			Difficulty string `json:"difficulty"` // beginner (default), intermediate or expert
+			Width      int    `json:"width"`      // custom field when difficulty is empty
+			Height     int    `json:"height"`
+			Mines      int    `json:"mines"`
+			NoGuess    bool   `json:"noGuess"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body) // optional body
+		g, err := games.NewMinesweeper(body.Difficulty, body.Width, body.Height, body.Mines, body.NoGuess)
+		if err != nil { writeErr(w, http.StatusBadRequest, err.Error()); return }
+		id := m.add(g, map[string]string{"player": userID(r)}, false)
+		m.respond(w, r, http.StatusCreated, id, g, map[string]any{"gameId": id})
+	})
+	actions := map[string]func(g *games.Minesweeper, row, col int) bool{
+		"reveal": (*games.Minesweeper).Reveal,
+		"flag":   (*games.Minesweeper).Flag,
+		"chord":  (*games.Minesweeper).Chord,
+	}
+	for name, act := range actions {
+		act := act
+		r.Post("/minesweeper/{id}/"+name, m.handle(func(w http.ResponseWriter, r *http.Request, id string, g *games.Minesweeper) bool {
+			var body struct {
+				Row int `json:"row"`
+				Col int `json:"col"`
+			}
+			if !decode(w, r, &body) { return false }
+			if !m.book.canAct(id, "player", userID(r)) { writeErr(w, http.StatusForbidden, "not your game"); return false }
+			if !act(g, body.Row, body.Col) { writeErr(w, http.StatusBadRequest, "invalid move"); return false }
+			return true
+		}))
+	}
+}
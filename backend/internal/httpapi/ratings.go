@@ -0,0 +1,154 @@
+package httpapi
+
+import (
+	"log"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/Manishk5507/gaMerZ/backend/internal/events"
+	"github.com/Manishk5507/gaMerZ/backend/internal/rating"
+)
+
+// starter creates a two player game and returns its id. seats maps the
+// game's seat names to users.
+type starter func(seats map[string]string, rated bool) string
+
+// matchmaking owns one rating aware queue per game type and remembers which
+// game each paired user was sent to until they pick it up.
+type matchmaking struct {
+	mu       sync.Mutex
+	ratings  *rating.Store
+	queues   map[string]*rating.Matchmaker
+	starters map[string]starter
+	found    map[string]map[string]assignment // game type -> user -> assignment
+}
+
+type assignment struct {
+	GameID string `json:"gameId"`
+	Seat   string `json:"seat"`
+}
+
+func newMatchmaking(ratings *rating.Store) *matchmaking {
+	return &matchmaking{
+		ratings:  ratings,
+		queues:   map[string]*rating.Matchmaker{},
+		starters: map[string]starter{},
+		found:    map[string]map[string]assignment{},
+	}
+}
+
+func (mm *matchmaking) register(gameType string, start starter) {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+	mm.queues[gameType] = rating.NewMatchmaker(rating.DefaultWindow)
+	mm.starters[gameType] = start
+	mm.found[gameType] = map[string]assignment{}
+}
+
+// tick pairs whoever fits inside their rating windows and starts their games.
+func (mm *matchmaking) tick(gameType string) {
+	mm.mu.Lock()
+	q, start := mm.queues[gameType], mm.starters[gameType]
+	mm.mu.Unlock()
+	for _, p := range q.Pair(time.Now()) {
+		users := []string{p.A.User, p.B.User}
+		if rand.Intn(2) == 0 {
+			users[0], users[1] = users[1], users[0]
+		}
+		seats := map[string]string{}
+		for i, s := range multiplayerSeats()[gameType] {
+			seats[s] = users[i]
+		}
+		id := start(seats, true)
+		log.Printf("[MM] paired %s (%.0f) vs %s (%.0f) in %s game %s", p.A.User, p.A.Rating, p.B.User, p.B.Rating, gameType, id)
+		mm.mu.Lock()
+		for s, u := range seats {
+			mm.found[gameType][u] = assignment{GameID: id, Seat: s}
+		}
+		mm.mu.Unlock()
+	}
+}
+
+// status reports the queue state for user, consuming a found assignment.
+func (mm *matchmaking) status(gameType, user string) map[string]any {
+	mm.tick(gameType)
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+	if a, ok := mm.found[gameType][user]; ok {
+		delete(mm.found[gameType], user)
+		return map[string]any{"status": "matched", "gameId": a.GameID, "seat": a.Seat}
+	}
+	if t, ok := mm.queues[gameType].Waiting(user); ok {
+		waited := time.Since(t.JoinedAt)
+		return map[string]any{"status": "queued", "rating": t.Rating, "waitedSeconds": int(waited.Seconds()), "window": rating.DefaultWindow.Width(waited)}
+	}
+	return map[string]any{"status": "idle"}
+}
+
+// recordRated feeds finished rated two player games into the rating store.
+func recordRated(store *rating.Store) func(events.GameFinished) {
+	return func(e events.GameFinished) {
+		if !e.Rated || len(e.Players) != 2 {
+			return
+		}
+		a, b := e.Players[0], e.Players[1]
+		if a.User == "" || b.User == "" || a.User == b.User {
+			return
+		}
+		score := 0.5
+		switch a.Outcome {
+		case events.Win:
+			score = 1
+		case events.Loss:
+			score = 0
+		}
+		ra, rb := store.RecordMatch(e.GameType, e.GameID, a.User, b.User, score)
+		log.Printf("[RATING] %s game %s: %s -> %.0f, %s -> %.0f", e.GameType, e.GameID, a.User, ra.Rating.Rating, b.User, rb.Rating.Rating)
+	}
+}
+
+func mountRatings(r chi.Router, store *rating.Store, mm *matchmaking) {
+	r.Get("/users/{id}/ratings", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, store.All(chi.URLParam(r, "id")))
+	})
+	r.Get("/users/{id}/ratings/{game}", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, store.Get(chi.URLParam(r, "game"), chi.URLParam(r, "id")))
+	})
+	r.Get("/users/{id}/ratings/{game}/history", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, store.History(chi.URLParam(r, "game"), chi.URLParam(r, "id")))
+	})
+
+	r.Route("/matchmaking/{game}", func(r chi.Router) {
+		// queue resolves the game type and caller, writing the error response itself.
+		queue := func(w http.ResponseWriter, r *http.Request) (string, string, bool) {
+			game, user := chi.URLParam(r, "game"), userID(r)
+			mm.mu.Lock()
+			_, ok := mm.queues[game]
+			mm.mu.Unlock()
+			if !ok { writeErr(w, http.StatusNotFound, "matchmaking not available for "+game); return "", "", false }
+			if user == "" { writeErr(w, http.StatusUnauthorized, "missing X-User-ID"); return "", "", false }
+			return game, user, true
+		}
+		r.Post("/", func(w http.ResponseWriter, r *http.Request) {
+			game, user, ok := queue(w, r)
+			if !ok { return }
+			mm.queues[game].Join(user, store.Get(game, user).Rating.Rating, time.Now())
+			writeJSON(w, http.StatusOK, mm.status(game, user))
+		})
+		r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+			game, user, ok := queue(w, r)
+			if !ok { return }
+			writeJSON(w, http.StatusOK, mm.status(game, user))
+		})
+		r.Delete("/", func(w http.ResponseWriter, r *http.Request) {
+			game, user, ok := queue(w, r)
+			if !ok { return }
+			if !mm.queues[game].Leave(user) { writeErr(w, http.StatusNotFound, "not queued"); return }
+			w.WriteHeader(http.StatusNoContent)
+		})
+	})
+}
@@ -0,0 +1,32 @@
+package httpapi
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestRatedRPSVersus(t *testing.T) {
+	h := NewRouter()
+	if code, _ := call(t, h, "POST", "/games/rps/new", "alice", `{"versus":true,"players":{"p1":"alice"},"rated":true}`); code != http.StatusBadRequest {
+		t.Fatalf("rated match with an open seat: got %d, want 400", code)
+	}
+	code, out := call(t, h, "POST", "/games/rps/new", "alice", `{"versus":true,"players":{"p1":"alice","p2":"bob"},"rated":true}`)
+	if code != http.StatusCreated {
+		t.Fatalf("new match: %d %v", code, out)
+	}
+	base := "/games/rps/" + out["gameId"].(string)
+	for i := 0; i < 10; i++ {
+		call(t, h, "POST", base+"/play", "alice", `{"move":"rock"}`)
+		if _, out = call(t, h, "POST", base+"/play", "bob", `{"move":"scissors"}`); out["finished"] == true {
+			break
+		}
+	}
+	if out["finished"] != true {
+		t.Fatalf("match did not finish: %v", out)
+	}
+	for _, user := range []string{"alice", "bob"} {
+		if _, r := call(t, h, "GET", "/users/"+user+"/ratings/rps", user, ""); r["games"] != 1.0 {
+			t.Errorf("%s was not rated: %v", user, r)
+		}
+	}
+}
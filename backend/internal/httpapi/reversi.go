@@ -0,0 +1,106 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/Manishk5507/gaMerZ/backend/internal/events"
+	"github.com/Manishk5507/gaMerZ/backend/internal/games"
+)
+
+// reversi serves /games/reversi like TicTacToe: vs AI with a choice of
+// colour or two seats ("B" opens, "W"), rated play, reset and rematch.
+type reversi struct{ *table[*games.Reversi] }
+
+func newReversi(book *matchBook, watch *spectators) *reversi {
+	return &reversi{newTable("reversi", book, watch, tableOps[*games.Reversi]{
+		Series: func(g *games.Reversi) **games.Series { return &g.Series },
+		Reset:  func(g *games.Reversi) error { g.Reset(); return nil },
+		Again:  func(g *games.Reversi) (*games.Reversi, error) {
+			ng, err := games.NewReversi(g.VsAI, g.Difficulty)
+			if err == nil && g.VsAI { err = ng.PlayAs(g.HumanPlays) }
+			return ng, err
+		},
+		Swap:   true,
+		Result: func(g *games.Reversi) (map[string]string, map[string]any) {
+			if g.Winner == "" { return nil, nil }
+			return rvOutcomes(g), rvStats(g)
+		},
+	})}
+}
+
+// start creates a two player game for matchmaking, rooms and tournaments.
+func (v *reversi) start(seats map[string]string, rated bool) string {
+	v.mu.Lock(); defer v.mu.Unlock()
+	g, _ := games.NewReversi(false, "")
+	return v.add(g, seats, rated)
+}
+
+func (v *reversi) turn(id string) (turnInfo, bool) {
+	v.mu.Lock(); defer v.mu.Unlock()
+	g, ok := v.games[id]
+	if !ok { return turnInfo{}, false }
+	return turnInfo{ToMove: []string{g.CurrentPlayer}, Over: g.Winner != ""}, true
+}
+
+func (v *reversi) legal(id string) []any {
+	v.mu.Lock(); defer v.mu.Unlock()
+	out := []any{}
+	if g, ok := v.games[id]; ok {
+		for _, c := range g.Legal { out = append(out, map[string]int{"row": c[0], "col": c[1]}) }
+	}
+	return out
+}
+
+// rvOutcomes maps a finished board to per seat outcomes.
+func rvOutcomes(g *games.Reversi) map[string]string {
+	switch g.Winner {
+	case "B":
+		return map[string]string{"B": events.Win, "W": events.Loss}
+	case "W":
+		return map[string]string{"B": events.Loss, "W": events.Win}
+	}
+	return map[string]string{"B": events.Draw, "W": events.Draw}
+}
+
+func rvStats(g *games.Reversi) map[string]any {
+	return map[string]any{"vsAI": g.VsAI, "difficulty": g.Difficulty, "black": g.Black, "white": g.White}
+}
+
+func (v *reversi) mount(r chi.Router) {
+	v.table.mount(r)
+	r.Post("/reversi/new", func(w http.ResponseWriter, r *http.Request) {
+		v.mu.Lock(); defer v.mu.Unlock()
+		var body struct {
+			VsAI       bool              `json:"vsAI"`
+			Difficulty string            `json:"difficulty"` // easy (default), medium, hard or expert
+			HumanPlays string            `json:"humanPlays"` // B (default) or W against the AI
+			Players    map[string]string `json:"players"`    // optional seat (B, W) -> user
+			Rated      bool              `json:"rated"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body) // optional body
+		if body.HumanPlays == "" { body.HumanPlays = "B" }
+		seats := map[string]string{"B": body.Players["B"], "W": body.Players["W"]}
+		if body.VsAI { seats = map[string]string{"B": "", "W": ""}; seats[body.HumanPlays] = userID(r) }
+		if body.Rated && (body.VsAI || seats["B"] == "" || seats["W"] == "" || seats["B"] == seats["W"]) {
+			writeErr(w, http.StatusBadRequest, "rated games need two distinct human players"); return
+		}
+		g, err := games.NewReversi(body.VsAI, body.Difficulty)
+		if err == nil { err = g.PlayAs(body.HumanPlays) } // an AI playing Black opens here
+		if err != nil { writeErr(w, http.StatusBadRequest, err.Error()); return }
+		id := v.add(g, seats, body.Rated)
+		v.respond(w, r, http.StatusCreated, id, g, map[string]any{"gameId": id})
+	})
+	r.Post("/reversi/{id}/move", v.handle(func(w http.ResponseWriter, r *http.Request, id string, g *games.Reversi) bool {
+		var body struct {
+			Row int `json:"row"`
+			Col int `json:"col"`
+		}
+		if !decode(w, r, &body) { return false }
+		if !v.book.canAct(id, g.CurrentPlayer, userID(r)) { writeErr(w, http.StatusForbidden, "not your turn"); return false }
+		if !g.Play(body.Row, body.Col) { writeErr(w, http.StatusBadRequest, "invalid move"); return false }
+		return true
+	}))
+}
@@ -0,0 +1,109 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/Manishk5507/gaMerZ/backend/internal/chat"
+	"github.com/Manishk5507/gaMerZ/backend/internal/lobby"
+	"github.com/Manishk5507/gaMerZ/backend/internal/realtime"
+)
+
+func roomTopic(id string) string { return "room:" + id }
+
+const lobbyTopic = "lobby"
+
+// lobbyErr maps lobby errors onto HTTP statuses.
+func lobbyErr(w http.ResponseWriter, err error) {
+	status := http.StatusBadRequest
+	switch {
+	case errors.Is(err, lobby.ErrNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, lobby.ErrMissingUser):
+		status = http.StatusUnauthorized
+	case errors.Is(err, lobby.ErrBadPasscode), errors.Is(err, lobby.ErrNotMember), errors.Is(err, lobby.ErrNotHost), errors.Is(err, lobby.ErrBanned):
+		status = http.StatusForbidden
+	case errors.Is(err, lobby.ErrFull), errors.Is(err, lobby.ErrSeatTaken), errors.Is(err, lobby.ErrAlreadyIn), errors.Is(err, lobby.ErrStarted):
+		status = http.StatusConflict
+	}
+	writeErr(w, status, err.Error())
+}
+
+// newLobby wires a lobby whose games are created through starters and whose
+// events are pushed to the room topic and the lobby-wide topic. Chat history
+// is dropped together with its room.
+func newLobby(hub *realtime.Hub, starters map[string]starter, chats *chat.Service) *lobby.Lobby {
+	start := func(game string, seats map[string]string) (string, error) {
+		s, ok := starters[game]
+		if !ok { return "", lobby.ErrUnknownGame }
+		return s(seats, false), nil
+	}
+	return lobby.New(multiplayerSeats(), start, func(e lobby.Event) {
+		if e.Type == lobby.RoomClosed { chats.Close(e.Room.ID) }
+		msg := realtime.Message{Event: e.Type, Data: e}
+		hub.Publish(roomTopic(e.Room.ID), msg)
+		hub.Publish(lobbyTopic, msg)
+	})
+}
+
+// readable loads a room for its event stream or chat history: anyone may
+// follow an open room, only its members a locked one.
+func readable(w http.ResponseWriter, r *http.Request, lob *lobby.Lobby) (lobby.Room, bool) {
+	room, err := lob.Get(chi.URLParam(r, "id"))
+	if err != nil { lobbyErr(w, err); return room, false }
+	if room.Locked && !room.IsMember(userID(r)) { lobbyErr(w, lobby.ErrNotMember); return room, false }
+	return room, true
+}
+
+func mountRooms(r chi.Router, hub *realtime.Hub, lob *lobby.Lobby, chats *chat.Service) {
+	r.Get("/lobby/events", func(w http.ResponseWriter, r *http.Request) {
+		ch, cancel := hub.Subscribe(lobbyTopic)
+		defer cancel()
+		realtime.ServeSSE(w, r, ch, realtime.Message{Event: "rooms", Data: lob.List()})
+	})
+
+	r.Route("/rooms", func(r chi.Router) {
+		r.Get("/", func(w http.ResponseWriter, r *http.Request) { writeJSON(w, http.StatusOK, lob.List()) })
+		r.Post("/", func(w http.ResponseWriter, r *http.Request) {
+			var body struct { Name string `json:"name"`; Game string `json:"game"`; Passcode string `json:"passcode"` }
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil { writeErr(w, http.StatusBadRequest, "invalid body"); return }
+			room, err := lob.Create(userID(r), body.Name, body.Game, body.Passcode)
+			if err != nil { lobbyErr(w, err); return }
+			writeJSON(w, http.StatusCreated, room)
+		})
+		r.Get("/{id}", func(w http.ResponseWriter, r *http.Request) {
+			room, err := lob.Get(chi.URLParam(r, "id"))
+			if err != nil { lobbyErr(w, err); return }
+			writeJSON(w, http.StatusOK, room)
+		})
+		r.Post("/{id}/join", func(w http.ResponseWriter, r *http.Request) {
+			var body struct { Passcode string `json:"passcode"`; Seat string `json:"seat"` }
+			_ = json.NewDecoder(r.Body).Decode(&body) // optional body
+			room, err := lob.Join(chi.URLParam(r, "id"), userID(r), body.Passcode, body.Seat)
+			if err != nil { lobbyErr(w, err); return }
+			writeJSON(w, http.StatusOK, room)
+		})
+		r.Post("/{id}/leave", func(w http.ResponseWriter, r *http.Request) {
+			if err := lob.Leave(chi.URLParam(r, "id"), userID(r)); err != nil { lobbyErr(w, err); return }
+			w.WriteHeader(http.StatusNoContent)
+		})
+		r.Post("/{id}/ready", func(w http.ResponseWriter, r *http.Request) {
+			body := struct { Ready bool `json:"ready"` }{Ready: true}
+			_ = json.NewDecoder(r.Body).Decode(&body) // optional body, defaults to ready
+			room, err := lob.SetReady(chi.URLParam(r, "id"), userID(r), body.Ready)
+			if err != nil { lobbyErr(w, err); return }
+			writeJSON(w, http.StatusOK, room)
+		})
+		r.Get("/{id}/events", func(w http.ResponseWriter, r *http.Request) {
+			room, ok := readable(w, r, lob)
+			if !ok { return }
+			ch, cancel := hub.Subscribe(roomTopic(room.ID))
+			defer cancel()
+			realtime.ServeSSE(w, r, ch, realtime.Message{Event: "room", Data: room})
+		})
+		mountChat(r, hub, lob, chats)
+	})
+}
@@ -0,0 +1,23 @@
+package httpapi
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestLockedRoomIsPrivate(t *testing.T) {
+	h := NewRouter()
+	code, out := call(t, h, "POST", "/rooms/", "alice", `{"name":"private","game":"tictactoe","passcode":"1234"}`)
+	if code != http.StatusCreated {
+		t.Fatalf("create room: %d %v", code, out)
+	}
+	id := out["id"].(string)
+	for _, path := range []string{"/chat", "/events"} {
+		if code, _ = call(t, h, "GET", "/rooms/"+id+path, "mallory", ""); code != http.StatusForbidden {
+			t.Errorf("%s for a non-member: got %d, want 403", path, code)
+		}
+	}
+	if code, _ = call(t, h, "GET", "/rooms/"+id+"/chat", "alice", ""); code != http.StatusOK {
+		t.Errorf("chat for the host: got %d", code)
+	}
+}
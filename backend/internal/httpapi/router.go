@@ -10,11 +10,20 @@ import (
 
 	"github.com/go-chi/chi/v5"
 
+	"github.com/Manishk5507/gaMerZ/backend/internal/achievements"
+	"github.com/Manishk5507/gaMerZ/backend/internal/bots"
+	"github.com/Manishk5507/gaMerZ/backend/internal/chat"
+	"github.com/Manishk5507/gaMerZ/backend/internal/events"
 	"github.com/Manishk5507/gaMerZ/backend/internal/games"
+	"github.com/Manishk5507/gaMerZ/backend/internal/notify"
+	"github.com/Manishk5507/gaMerZ/backend/internal/rating"
+	"github.com/Manishk5507/gaMerZ/backend/internal/realtime"
 )
 
 func NewRouter() http.Handler {
 	r := chi.NewRouter()
+	botAPI := newBotAPI(bots.NewRegistry())
+	r.Use(botAPI.identity)
 	r.Get("/health", func(w http.ResponseWriter, r *http.Request) { w.Write([]byte("ok")) })
 	// simple in-memory stores (not for production)
 	var (
@@ -27,26 +36,270 @@ func NewRouter() http.Handler {
 		rpsGames  = map[string]*games.RPSGame{}
 		hangGames = map[string]*games.Hangman{}
 	)
+	bus := events.NewBus()
+	book := newMatchBook(bus)
+	ratings := rating.NewStore()
+	bus.Subscribe(recordRated(ratings))
+	hub := realtime.NewHub()
+	watch := newSpectators(hub, spectatorDelay(), book)
+	watch.snapshots["tictactoe"] = func(id string) (json.RawMessage, bool) {
+		muTic.Lock(); defer muTic.Unlock()
+		g, ok := ticGames[id]
+		if !ok { return nil, false }
+		return watch.encode("tictactoe", id, g), true
+	}
+	watch.snapshots["numberguess"] = func(id string) (json.RawMessage, bool) {
+		muNum.Lock(); defer muNum.Unlock()
+		g, ok := numGames[id]
+		if !ok { return nil, false }
+		return watch.encode("numberguess", id, g), true
+	}
+	watch.snapshots["rps"] = func(id string) (json.RawMessage, bool) {
+		muRPS.Lock(); defer muRPS.Unlock()
+		g, ok := rpsGames[id]
+		if !ok { return nil, false }
+		return watch.encode("rps", id, g), true
+	}
+	watch.snapshots["hangman"] = func(id string) (json.RawMessage, bool) {
+		muHang.Lock(); defer muHang.Unlock()
+		g, ok := hangGames[id]
+		if !ok { return nil, false }
+		return watch.encode("hangman", id, g), true
+	}
+	c4 := newConnectFour(book, watch)
+	ult := newUltimate(book, watch)
+	mines := newMinesweeper(book, watch)
+	sdk := newSudoku(book, watch)
+	loadWordList()
+	wdl := newWordle(book, watch)
+	bsh := newBattleship(book, watch)
+	ck := newCheckers(book, watch)
+	rv := newReversi(book, watch)
+	ch := newChess(book, watch)
+	notes := notify.NewService(notifiers()...)
+	// ticNotify tells correspondence players that it is their move, or that
+	// the game is over; callers hold muTic.
+	ticNotify := func(id string, g *games.TicTacToe) {
+		if !g.Correspondence { return }
+		m, _ := book.get(id)
+		if g.Winner == "" {
+			notes.Send(notify.Notification{User: m.Seats[g.CurrentPlayer], Kind: notify.YourTurn, GameType: "tictactoe", GameID: id, Text: "Your move as " + g.CurrentPlayer})
+			return
+		}
+		text := "Game over: draw"
+		if g.Winner != "D" { text = "Game over: " + g.Winner + " wins" }
+		if g.TimedOut != "" { text += " on time" }
+		for _, user := range m.Seats { notes.Send(notify.Notification{User: user, Kind: notify.GameOver, GameType: "tictactoe", GameID: id, Text: text}) }
+	}
+	timers := newClocks()
+	var ticExpire, rpsExpire func(id string)
+	// ticTick moves a TicTacToe clock on after mover ("" for nobody) played
+	// and re-arms its timeout; callers hold muTic.
+	ticTick := func(id string, g *games.TicTacToe, mover string) {
+		if g.Clock == nil { return }
+		now := time.Now()
+		if mover != "" { g.Clock.Moved(mover, now) }
+		if g.Winner != "" { g.Clock.Stop(now) } else { g.Clock.Wait(now, g.CurrentPlayer) }
+		timers.arm(id, g.Clock, ticExpire)
+	}
+	ticExpire = func(id string) {
+		muTic.Lock(); defer muTic.Unlock()
+		g, ok := ticGames[id]
+		if !ok || g.Clock == nil || g.Winner != "" { return }
+		seat, auto, ok := timeoutSeat(g.Clock, time.Now())
+		if !ok { timers.arm(id, g.Clock, ticExpire); return } // woke up early
+		if auto { g.AutoMove(g.Clock.Control.OnTimeout == games.TimeoutRandom) } else { g.Timeout(seat) }
+		if g.Winner != "" { book.finished(id, ticOutcomes(g), ticStats(g)) }
+		ticTick(id, g, "")
+		ticNotify(id, g)
+		watch.publish("tictactoe", id, g)
+	}
+	// rpsTick is ticTick for versus RPS, where both seats can be on the clock.
+	rpsTick := func(id string, g *games.RPSGame, mover string) {
+		if g.Clock == nil { return }
+		now := time.Now()
+		if mover != "" { g.Clock.Moved(mover, now) }
+		if g.Finished { g.Clock.Stop(now) } else { g.Clock.Wait(now, g.Waiting...) }
+		timers.arm(id, g.Clock, rpsExpire)
+	}
+	rpsExpire = func(id string) {
+		muRPS.Lock(); defer muRPS.Unlock()
+		g, ok := rpsGames[id]
+		if !ok || g.Clock == nil || g.Finished { return }
+		seat, auto, ok := timeoutSeat(g.Clock, time.Now())
+		if !ok { timers.arm(id, g.Clock, rpsExpire); return }
+		if auto { g.AutoMove(seat) } else { g.Timeout(seat) }
+		if g.Finished { book.finished(id, rpsOutcomes(g), rpsStats(g)) }
+		rpsTick(id, g, "")
+		watch.publish("rps", id, g)
+	}
+	turns := map[string]turnFunc{
+		"tictactoe": func(id string) (turnInfo, bool) {
+			muTic.Lock(); defer muTic.Unlock()
+			g, ok := ticGames[id]
+			if !ok { return turnInfo{}, false }
+			info := turnInfo{ToMove: []string{g.CurrentPlayer}, Over: g.Winner != "", Correspondence: g.Correspondence}
+			if g.Clock != nil { if d, ok := g.Clock.Deadline(); ok { info.Deadline = &d } }
+			return info, true
+		},
+		"rps": func(id string) (turnInfo, bool) {
+			muRPS.Lock(); defer muRPS.Unlock()
+			g, ok := rpsGames[id]
+			if !ok || !g.Versus { return turnInfo{}, false }
+			info := turnInfo{ToMove: append([]string{}, g.Waiting...), Over: g.Finished}
+			if g.Clock != nil { if d, ok := g.Clock.Deadline(); ok { info.Deadline = &d } }
+			return info, true
+		},
+	}
+	turns["connectfour"] = c4.turn
+	turns["ultimate"] = ult.turn
+	turns["battleship"] = bsh.turn
+	turns["checkers"] = ck.turn
+	turns["reversi"] = rv.turn
+	turns["chess"] = ch.turn
+	mountMe(r, book, turns, notes)
+	// starters create two player games for matchmaking and rooms
+	starters := map[string]starter{
+		"tictactoe": func(seats map[string]string, rated bool) string {
+			muTic.Lock(); defer muTic.Unlock()
+			id := randID()
+			ticGames[id] = games.NewTicTacToe(false, "")
+			book.register(id, "tictactoe", seats, rated)
+			watch.publish("tictactoe", id, ticGames[id])
+			return id
+		},
+		"rps": func(seats map[string]string, rated bool) string {
+			muRPS.Lock(); defer muRPS.Unlock()
+			id := randID()
+			rpsGames[id] = games.NewRPSVersus(0)
+			book.register(id, "rps", seats, rated)
+			watch.publish("rps", id, rpsGames[id])
+			return id
+		},
+	}
+	starters["connectfour"] = c4.start
+	starters["ultimate"] = ult.start
+	starters["battleship"] = bsh.start
+	starters["checkers"] = ck.start
+	starters["reversi"] = rv.start
+	starters["chess"] = ch.start
+	mm := newMatchmaking(ratings)
+	for game, start := range starters { mm.register(game, start) }
+	mountRatings(r, ratings, mm)
+	badges, err := achievements.NewEngine(achievements.Default)
+	if err != nil { panic(err) }
+	bus.Subscribe(badges.Handle)
+	mountAchievements(r, badges)
+	chats := chat.NewService(chatConfig())
+	lob := newLobby(hub, starters, chats)
+	mountRooms(r, hub, lob, chats)
+	tours := newTournaments(hub, starters)
+	bus.Subscribe(reportToTournament(tours))
+	mountTournaments(r, hub, tours)
+	mountAdmin(r)
+	legal := map[string]legalFunc{
+		"tictactoe": func(id string) []any {
+			muTic.Lock(); defer muTic.Unlock()
+			out := []any{}
+			if g, ok := ticGames[id]; ok {
+				for _, pos := range g.Legal() { out = append(out, map[string]int{"pos": pos}) }
+			}
+			return out
+		},
+		"rps": func(id string) []any {
+			return []any{map[string]string{"move": "rock"}, map[string]string{"move": "paper"}, map[string]string{"move": "scissors"}}
+		},
+		"connectfour": c4.legal,
+		"ultimate":    ult.legal,
+		"checkers":    ck.legal,
+		"reversi":     rv.legal,
+		"chess":       ch.legal,
+		"battleship":  bsh.legal,
+	}
+	botAPI.mount(r, r, book, watch, turns, legal)
+
+	writable := func(w http.ResponseWriter, id string) bool { return book.writable(w, id) }
 
 	r.Route("/games", func(r chi.Router) {
 		r.Get("/list", func(w http.ResponseWriter, r *http.Request) {
-			resp := []map[string]string{
-				{"id": "tictactoe", "name": "Tic Tac Toe"},
-				{"id": "numberguess", "name": "Number Guess"},
-				{"id": "rps", "name": "Rock Paper Scissors"},
-				{"id": "hangman", "name": "Hangman"},
-			}
-			writeJSON(w, http.StatusOK, resp)
+			writeJSON(w, http.StatusOK, catalog)
 		})
+		mountSpectate(r, watch)
+		c4.mount(r)
+		ult.mount(r)
+		mines.mount(r)
+		sdk.mount(r)
+		wdl.mount(r)
+		bsh.mount(r)
+		ck.mount(r)
+		rv.mount(r)
+		ch.mount(r)
 
 		// TicTacToe endpoints
 		r.Post("/tictactoe/new", func(w http.ResponseWriter, r *http.Request) {
 			muTic.Lock(); defer muTic.Unlock()
-			var body struct { VsAI bool `json:"vsAI"`; Difficulty string `json:"difficulty"` }
+			var body struct {
+				VsAI        bool               `json:"vsAI"`
+				Difficulty  string             `json:"difficulty"`
+				HumanPlays  string             `json:"humanPlays"` // X (default) or O against the AI
+				Players     map[string]string  `json:"players"` // optional seat -> user for human vs human
+				Rated       bool               `json:"rated"`
+				TimeControl *games.TimeControl `json:"timeControl"`
+				// correspondence games last days: each move may take DaysPerMove (default 3)
+				Correspondence bool `json:"correspondence"`
+				DaysPerMove    int  `json:"daysPerMove"`
+				// board size: rows x cols, k in a row wins (default 3x3x3);
+				// variant "gomoku" is shorthand for 15x15 five in a row
+				Variant string `json:"variant"`
+				Rows    int    `json:"rows"`
+				Cols    int    `json:"cols"`
+				K       int    `json:"k"`
+			}
 			_ = json.NewDecoder(r.Body).Decode(&body) // optional body
+			seats := map[string]string{"X": body.Players["X"], "O": body.Players["O"]}
+			if body.HumanPlays == "" { body.HumanPlays = "X" }
+			if body.VsAI { seats = map[string]string{"X": "", "O": ""}; seats[body.HumanPlays] = userID(r) }
+			if body.Rated && (body.VsAI || seats["X"] == "" || seats["O"] == "" || seats["X"] == seats["O"]) {
+				writeErr(w, http.StatusBadRequest, "rated games need two distinct human players"); return
+			}
+			if body.Correspondence {
+				if body.VsAI || seats["X"] == "" || seats["O"] == "" || seats["X"] == seats["O"] {
+					writeErr(w, http.StatusBadRequest, "correspondence games need two distinct human players"); return
+				}
+				if body.DaysPerMove <= 0 { body.DaysPerMove = 3 }
+				body.TimeControl = &games.TimeControl{PerMove: body.DaysPerMove * 24 * 60 * 60}
+			}
+			if body.TimeControl != nil {
+				if err := body.TimeControl.Validate(); err != nil { writeErr(w, http.StatusBadRequest, err.Error()); return }
+			}
+			switch body.Variant {
+			case "", "classic":
+			case "gomoku":
+				body.Rows, body.Cols, body.K = 15, 15, 5
+			default:
+				writeErr(w, http.StatusBadRequest, "unknown variant"); return
+			}
+			if body.Rows == 0 { body.Rows = 3 }
+			if body.Cols == 0 { body.Cols = body.Rows }
+			if body.K == 0 {
+				// k defaults to the shorter side, capped at five in a row
+				body.K = body.Rows
+				if body.Cols < body.K { body.K = body.Cols }
+				if body.K > 5 { body.K = 5 }
+			}
+			g, err := games.NewTicTacToeSized(body.Rows, body.Cols, body.K, body.VsAI, body.Difficulty)
+			if err == nil { err = g.PlayAs(body.HumanPlays) } // an AI playing X opens here
+			if err != nil { writeErr(w, http.StatusBadRequest, err.Error()); return }
 			id := randID()
-			g := games.NewTicTacToe(body.VsAI, body.Difficulty)
+			g.Correspondence = body.Correspondence
 			ticGames[id] = g
+			book.register(id, "tictactoe", seats, body.Rated)
+			if body.TimeControl != nil {
+				g.Clock = games.NewClock(*body.TimeControl, "X", "O")
+				ticTick(id, g, "")
+			}
+			ticNotify(id, g)
+			watch.publish("tictactoe", id, g)
 			writeJSON(w, http.StatusCreated, map[string]any{"gameId": id, "state": g})
 		})
 		r.Get("/tictactoe/{id}", func(w http.ResponseWriter, r *http.Request) {
@@ -54,16 +307,35 @@ func NewRouter() http.Handler {
 			muTic.Lock(); defer muTic.Unlock()
 			g, ok := ticGames[id]
 			if !ok { http.NotFound(w, r); return }
-			writeJSON(w, http.StatusOK, g)
+			watch.view(w, r, "tictactoe", id, g)
+		})
+		r.Get("/tictactoe/{id}/analysis", func(w http.ResponseWriter, r *http.Request) {
+			id := chi.URLParam(r, "id")
+			muTic.Lock(); defer muTic.Unlock()
+			g, ok := ticGames[id]
+			if !ok { http.NotFound(w, r); return }
+			// hints would decide rated games, so those only get the post-game review
+			if m, _ := book.get(id); m.Rated && g.Winner == "" { writeErr(w, http.StatusForbidden, "analysis is available once a rated game is over"); return }
+			a, err := g.Analyze()
+			if err != nil { writeErr(w, http.StatusBadRequest, err.Error()); return }
+			if g.Winner == "" { book.assist(id) }
+			writeJSON(w, http.StatusOK, a)
 		})
 		r.Post("/tictactoe/{id}/move", func(w http.ResponseWriter, r *http.Request) {
 			id := chi.URLParam(r, "id")
 			muTic.Lock(); defer muTic.Unlock()
 			g, ok := ticGames[id]
 			if !ok { http.NotFound(w, r); return }
+			if !writable(w, id) { return }
 			var body struct { Pos int `json:"pos"` }
 			if err := json.NewDecoder(r.Body).Decode(&body); err != nil { writeErr(w, http.StatusBadRequest, "invalid body"); return }
+			if !book.canAct(id, g.CurrentPlayer, userID(r)) { writeErr(w, http.StatusForbidden, "not your turn"); return }
+			mover := g.CurrentPlayer
 			if !g.MakeMove(body.Pos) { writeErr(w, http.StatusBadRequest, "invalid move"); return }
+			if g.Winner != "" { book.finished(id, ticOutcomes(g), ticStats(g)) }
+			ticTick(id, g, mover)
+			ticNotify(id, g)
+			watch.publish("tictactoe", id, g)
 			writeJSON(w, http.StatusOK, g)
 		})
 		r.Post("/tictactoe/{id}/reset", func(w http.ResponseWriter, r *http.Request) {
@@ -71,7 +343,14 @@ func NewRouter() http.Handler {
 			muTic.Lock(); defer muTic.Unlock()
 			g, ok := ticGames[id]
 			if !ok { http.NotFound(w, r); return }
+			if !writable(w, id) { return }
+			if g.Series != nil { writeErr(w, http.StatusBadRequest, "use rematch to continue a series"); return }
+			if m, _ := book.get(id); m.Rated { writeErr(w, http.StatusBadRequest, "cannot reset a rated game"); return }
 			g.Reset()
+			book.rearm(id)
+			ticTick(id, g, "")
+			ticNotify(id, g)
+			watch.publish("tictactoe", id, g)
 			writeJSON(w, http.StatusOK, g)
 		})
 		r.Post("/tictactoe/{id}/undo", func(w http.ResponseWriter, r *http.Request) {
@@ -79,9 +358,42 @@ func NewRouter() http.Handler {
 			muTic.Lock(); defer muTic.Unlock()
 			g, ok := ticGames[id]
 			if !ok { http.NotFound(w, r); return }
+			if !writable(w, id) { return }
+			if m, _ := book.get(id); m.Rated { writeErr(w, http.StatusBadRequest, "cannot undo in a rated game"); return }
+			if g.Clock != nil { writeErr(w, http.StatusBadRequest, "cannot undo in a timed game"); return }
 			if !g.Undo() { writeErr(w, http.StatusBadRequest, "cannot undo"); return }
+			book.rearm(id)
+			book.assist(id)
+			watch.publish("tictactoe", id, g)
 			writeJSON(w, http.StatusOK, g)
 		})
+		r.Post("/tictactoe/{id}/rematch", func(w http.ResponseWriter, r *http.Request) {
+			id := chi.URLParam(r, "id")
+			muTic.Lock(); defer muTic.Unlock()
+			g, ok := ticGames[id]
+			if !ok { http.NotFound(w, r); return }
+			var body rematchBody
+			_ = json.NewDecoder(r.Body).Decode(&body) // optional body
+			newID := randID()
+			series, err := book.rematch(id, newID, userID(r), body.BestOf, true) // humans swap marks so the other side opens
+			if err != nil { rematchErr(w, err); return }
+			if g.Series == nil { g.Series = series }
+			ng, _ := games.NewTicTacToeSized(g.Rows, g.Cols, g.K, g.VsAI, g.Difficulty)
+			if g.VsAI { // the human changes sides so the other one opens
+				_ = ng.PlayAs(map[string]string{"X": "O", "O": "X"}[g.HumanPlays])
+				book.swapSeats(newID)
+			}
+			ng.Series = series
+			ng.Correspondence = g.Correspondence
+			ticGames[newID] = ng
+			if g.Clock != nil {
+				ng.Clock = games.NewClock(g.Clock.Control, "X", "O")
+				ticTick(newID, ng, "")
+			}
+			ticNotify(newID, ng)
+			watch.publish("tictactoe", newID, ng)
+			writeJSON(w, http.StatusCreated, map[string]any{"gameId": newID, "previousId": id, "state": ng})
+		})
 
 		// Number Guess endpoints (clean)
 		r.Post("/numberguess/new", func(w http.ResponseWriter, r *http.Request) {
@@ -91,6 +403,8 @@ func NewRouter() http.Handler {
 			id := randID()
 			g := games.NewNumberGuess(body.Difficulty)
 			numGames[id] = g
+			book.register(id, "numberguess", map[string]string{"player": userID(r)}, false)
+			watch.publish("numberguess", id, g)
 			writeJSON(w, http.StatusCreated, map[string]any{"gameId": id, "state": g})
 		})
 		r.Post("/numberguess/{id}/reset", func(w http.ResponseWriter, r *http.Request) {
@@ -98,7 +412,11 @@ func NewRouter() http.Handler {
 			muNum.Lock(); defer muNum.Unlock()
 			g, ok := numGames[id]
 			if !ok { http.NotFound(w, r); return }
+			if !writable(w, id) { return }
+			if g.Series != nil { writeErr(w, http.StatusBadRequest, "use rematch to continue a series"); return }
 			g.Reset()
+			book.rearm(id)
+			watch.publish("numberguess", id, g)
 			writeJSON(w, http.StatusOK, g)
 		})
 		r.Get("/numberguess/{id}", func(w http.ResponseWriter, r *http.Request) {
@@ -106,27 +424,71 @@ func NewRouter() http.Handler {
 			muNum.Lock(); defer muNum.Unlock()
 			g, ok := numGames[id]
 			if !ok { http.NotFound(w, r); return }
-			writeJSON(w, http.StatusOK, g)
+			watch.view(w, r, "numberguess", id, g)
 		})
 		r.Post("/numberguess/{id}/guess", func(w http.ResponseWriter, r *http.Request) {
 			id := chi.URLParam(r, "id")
 			muNum.Lock(); defer muNum.Unlock()
 			g, ok := numGames[id]
 			if !ok { http.NotFound(w, r); return }
+			if !writable(w, id) { return }
 			var body struct { N int `json:"n"` }
 			if err := json.NewDecoder(r.Body).Decode(&body); err != nil { writeErr(w, http.StatusBadRequest, "invalid body"); return }
 			g.Guess(body.N)
+			if g.Won { book.finished(id, map[string]string{"player": events.Win}, map[string]any{"difficulty": g.Difficulty, "tries": g.Tries, "max": g.Max}) }
+			watch.publish("numberguess", id, g)
 			writeJSON(w, http.StatusOK, g)
 		})
+		r.Post("/numberguess/{id}/rematch", func(w http.ResponseWriter, r *http.Request) {
+			id := chi.URLParam(r, "id")
+			muNum.Lock(); defer muNum.Unlock()
+			g, ok := numGames[id]
+			if !ok { http.NotFound(w, r); return }
+			var body rematchBody
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			newID := randID()
+			series, err := book.rematch(id, newID, userID(r), body.BestOf, false)
+			if err != nil { rematchErr(w, err); return }
+			if g.Series == nil { g.Series = series }
+			ng := games.NewNumberGuess(g.Difficulty)
+			ng.Series = series
+			numGames[newID] = ng
+			watch.publish("numberguess", newID, ng)
+			writeJSON(w, http.StatusCreated, map[string]any{"gameId": newID, "previousId": id, "state": ng})
+		})
 
 		// Rock Paper Scissors endpoints (clean)
 		r.Post("/rps/new", func(w http.ResponseWriter, r *http.Request) {
 			muRPS.Lock(); defer muRPS.Unlock()
-			var body struct { Target int `json:"target"` }
+			var body struct {
+				Target      int                `json:"target"`
+				Versus      bool               `json:"versus"`
+				Players     map[string]string  `json:"players"` // versus: optional seat (p1, p2) -> user
+				Rated       bool               `json:"rated"`
+				TimeControl *games.TimeControl `json:"timeControl"` // versus only
+			}
 			_ = json.NewDecoder(r.Body).Decode(&body)
+			if body.Rated && (!body.Versus || body.Players["p1"] == "" || body.Players["p2"] == "" || body.Players["p1"] == body.Players["p2"]) {
+				writeErr(w, http.StatusBadRequest, "rated games need two distinct human players"); return
+			}
+			if body.TimeControl != nil {
+				if !body.Versus { writeErr(w, http.StatusBadRequest, "time controls need a versus match"); return }
+				if err := body.TimeControl.Validate(); err != nil { writeErr(w, http.StatusBadRequest, err.Error()); return }
+			}
 			id := randID()
 			g := games.NewRPS(body.Target)
+			seats := map[string]string{"player": userID(r), "ai": ""}
+			if body.Versus {
+				g = games.NewRPSVersus(body.Target)
+				seats = map[string]string{"p1": body.Players["p1"], "p2": body.Players["p2"]}
+			}
 			rpsGames[id] = g
+			book.register(id, "rps", seats, body.Rated)
+			if body.TimeControl != nil {
+				g.Clock = games.NewClock(*body.TimeControl, "p1", "p2")
+				rpsTick(id, g, "")
+			}
+			watch.publish("rps", id, g)
 			writeJSON(w, http.StatusCreated, map[string]any{"gameId": id, "state": g})
 		})
 		r.Post("/rps/{id}/reset", func(w http.ResponseWriter, r *http.Request) {
@@ -134,7 +496,13 @@ func NewRouter() http.Handler {
 			muRPS.Lock(); defer muRPS.Unlock()
 			g, ok := rpsGames[id]
 			if !ok { http.NotFound(w, r); return }
+			if !writable(w, id) { return }
+			if g.Series != nil { writeErr(w, http.StatusBadRequest, "use rematch to continue a series"); return }
+			if m, _ := book.get(id); m.Rated { writeErr(w, http.StatusBadRequest, "cannot reset a rated game"); return }
 			g.Reset()
+			book.rearm(id)
+			rpsTick(id, g, "")
+			watch.publish("rps", id, g)
 			writeJSON(w, http.StatusOK, g)
 		})
 		r.Get("/rps/{id}", func(w http.ResponseWriter, r *http.Request) {
@@ -142,18 +510,51 @@ func NewRouter() http.Handler {
 			muRPS.Lock(); defer muRPS.Unlock()
 			g, ok := rpsGames[id]
 			if !ok { http.NotFound(w, r); return }
-			writeJSON(w, http.StatusOK, g)
+			watch.view(w, r, "rps", id, g)
 		})
 		r.Post("/rps/{id}/play", func(w http.ResponseWriter, r *http.Request) {
 			id := chi.URLParam(r, "id")
 			muRPS.Lock(); defer muRPS.Unlock()
 			g, ok := rpsGames[id]
 			if !ok { http.NotFound(w, r); return }
-			var body struct { Move string `json:"move"` }
+			if !writable(w, id) { return }
+			var body struct { Move string `json:"move"`; Seat string `json:"seat"` }
 			if err := json.NewDecoder(r.Body).Decode(&body); err != nil { writeErr(w, http.StatusBadRequest, "invalid body"); return }
-			g.Play(body.Move)
+			if g.Versus {
+				seat := book.seatOf(id, userID(r))
+				if seat == "" { seat = body.Seat } // unclaimed hot-seat play names the seat
+				if !book.canAct(id, seat, userID(r)) { writeErr(w, http.StatusForbidden, "not your seat"); return }
+				if !g.PlaySeat(seat, body.Move) { writeErr(w, http.StatusBadRequest, "invalid move"); return }
+				rpsTick(id, g, seat)
+			} else {
+				g.Play(body.Move)
+			}
+			if g.Finished { book.finished(id, rpsOutcomes(g), rpsStats(g)) }
+			watch.publish("rps", id, g)
 			writeJSON(w, http.StatusOK, g)
 		})
+		r.Post("/rps/{id}/rematch", func(w http.ResponseWriter, r *http.Request) {
+			id := chi.URLParam(r, "id")
+			muRPS.Lock(); defer muRPS.Unlock()
+			g, ok := rpsGames[id]
+			if !ok { http.NotFound(w, r); return }
+			var body rematchBody
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			newID := randID()
+			series, err := book.rematch(id, newID, userID(r), body.BestOf, false) // moves are simultaneous, nobody opens
+			if err != nil { rematchErr(w, err); return }
+			if g.Series == nil { g.Series = series }
+			ng := games.NewRPS(g.Target)
+			if g.Versus { ng = games.NewRPSVersus(g.Target) }
+			ng.Series = series
+			rpsGames[newID] = ng
+			if g.Clock != nil {
+				ng.Clock = games.NewClock(g.Clock.Control, "p1", "p2")
+				rpsTick(newID, ng, "")
+			}
+			watch.publish("rps", newID, ng)
+			writeJSON(w, http.StatusCreated, map[string]any{"gameId": newID, "previousId": id, "state": ng})
+		})
 
 		// Hangman endpoints (clean)
 		r.Post("/hangman/new", func(w http.ResponseWriter, r *http.Request) {
@@ -163,6 +564,8 @@ func NewRouter() http.Handler {
 			id := randID()
 			g := games.NewHangman(body.Difficulty)
 			hangGames[id] = g
+			book.register(id, "hangman", map[string]string{"player": userID(r)}, false)
+			watch.publish("hangman", id, g)
 			writeJSON(w, http.StatusCreated, map[string]any{"gameId": id, "state": g})
 		})
 		r.Post("/hangman/{id}/reset", func(w http.ResponseWriter, r *http.Request) {
@@ -170,7 +573,11 @@ func NewRouter() http.Handler {
 			muHang.Lock(); defer muHang.Unlock()
 			g, ok := hangGames[id]
 			if !ok { http.NotFound(w, r); return }
+			if !writable(w, id) { return }
+			if g.Series != nil { writeErr(w, http.StatusBadRequest, "use rematch to continue a series"); return }
 			g.Reset()
+			book.rearm(id)
+			watch.publish("hangman", id, g)
 			writeJSON(w, http.StatusOK, g)
 		})
 		r.Get("/hangman/{id}", func(w http.ResponseWriter, r *http.Request) {
@@ -178,23 +585,83 @@ func NewRouter() http.Handler {
 			muHang.Lock(); defer muHang.Unlock()
 			g, ok := hangGames[id]
 			if !ok { http.NotFound(w, r); return }
-			writeJSON(w, http.StatusOK, g)
+			watch.view(w, r, "hangman", id, g)
 		})
 		r.Post("/hangman/{id}/guess", func(w http.ResponseWriter, r *http.Request) {
 			id := chi.URLParam(r, "id")
 			muHang.Lock(); defer muHang.Unlock()
 			g, ok := hangGames[id]
 			if !ok { http.NotFound(w, r); return }
+			if !writable(w, id) { return }
 			var body struct { Letter string `json:"letter"` }
 			if err := json.NewDecoder(r.Body).Decode(&body); err != nil { writeErr(w, http.StatusBadRequest, "invalid body"); return }
 			g.Guess(body.Letter)
+			if g.Finished {
+				out := events.Loss
+				if g.Won { out = events.Win }
+				book.finished(id, map[string]string{"player": out}, map[string]any{"difficulty": g.Difficulty, "wrong": g.Wrong, "length": len(g.Word)})
+			}
+			watch.publish("hangman", id, g)
 			writeJSON(w, http.StatusOK, g)
 		})
+		r.Post("/hangman/{id}/rematch", func(w http.ResponseWriter, r *http.Request) {
+			id := chi.URLParam(r, "id")
+			muHang.Lock(); defer muHang.Unlock()
+			g, ok := hangGames[id]
+			if !ok { http.NotFound(w, r); return }
+			var body rematchBody
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			newID := randID()
+			series, err := book.rematch(id, newID, userID(r), body.BestOf, false)
+			if err != nil { rematchErr(w, err); return }
+			if g.Series == nil { g.Series = series }
+			ng := games.NewHangman(g.Difficulty)
+			ng.Series = series
+			hangGames[newID] = ng
+			watch.publish("hangman", newID, ng)
+			writeJSON(w, http.StatusCreated, map[string]any{"gameId": newID, "previousId": id, "state": ng})
+		})
     }) // end /games route group
 
 	return r
 }
 
+// ticOutcomes maps a finished TicTacToe board to per seat outcomes.
+func ticOutcomes(g *games.TicTacToe) map[string]string {
+	switch g.Winner {
+	case "X":
+		return map[string]string{"X": events.Win, "O": events.Loss}
+	case "O":
+		return map[string]string{"X": events.Loss, "O": events.Win}
+	}
+	return map[string]string{"X": events.Draw, "O": events.Draw}
+}
+
+// ticStats are the facts published with a finished TicTacToe game.
+func ticStats(g *games.TicTacToe) map[string]any {
+	return map[string]any{"vsAI": g.VsAI, "difficulty": g.Difficulty, "moves": len(g.Moves)}
+}
+
+// rpsOutcomes maps a finished RPS match to per seat outcomes.
+func rpsOutcomes(g *games.RPSGame) map[string]string {
+	a, b := "player", "ai"
+	if g.Versus { a, b = "p1", "p2" }
+	switch g.Winner {
+	case a:
+		return map[string]string{a: events.Win, b: events.Loss}
+	case b:
+		return map[string]string{a: events.Loss, b: events.Win}
+	}
+	return map[string]string{a: events.Draw, b: events.Draw}
+}
+
+// rpsStats are the facts published with a finished RPS match.
+func rpsStats(g *games.RPSGame) map[string]any {
+	hi, lo := g.PlayerScore, g.AIScore
+	if lo > hi { hi, lo = lo, hi }
+	return map[string]any{"playerScore": g.PlayerScore, "aiScore": g.AIScore, "winnerScore": hi, "loserScore": lo, "target": g.Target, "rounds": g.Rounds, "versus": g.Versus}
+}
+
 func randID() string { return strconv.FormatInt(time.Now().UnixNano()+int64(rand.Intn(9999)), 36) }
 
 func writeJSON(w http.ResponseWriter, status int, v any) {
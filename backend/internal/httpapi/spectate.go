@@ -0,0 +1,180 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/Manishk5507/gaMerZ/backend/internal/games"
+	"github.com/Manishk5507/gaMerZ/backend/internal/realtime"
+)
+
+// spectatorDelay reads SPECTATOR_DELAY (e.g. "15s"); spectators see every
+// update that much later than the players so they cannot coach them.
+func spectatorDelay() time.Duration {
+	v := os.Getenv("SPECTATOR_DELAY")
+	if v == "" { return 0 }
+	d, err := time.ParseDuration(v)
+	if err != nil || d < 0 {
+		log.Printf("[SPECTATE] ignoring invalid SPECTATOR_DELAY %q", v)
+		return 0
+	}
+	return d
+}
+
+// snapshotFunc returns the encoded state of a game (with a fresh spectator
+// count) while holding that game type's lock.
+type snapshotFunc func(id string) (json.RawMessage, bool)
+
+// spectators pushes game states to read-only viewers. States are encoded
+// with the same JSON as the player endpoints, so anything hidden from the
+// players (Hangman word, secret number, pending RPS moves) stays hidden.
+//
+// Every publish also bumps a per-game version and wakes whoever waits on
+// changed, undelayed; bot long-polls use that to notice their turn.
+type spectators struct {
+	feed      *realtime.Delayed
+	snapshots map[string]snapshotFunc
+	book      *matchBook
+
+	mu       sync.Mutex
+	versions map[string]int // topic -> publishes so far
+	changes  chan struct{}  // closed and replaced on every publish
+}
+
+func newSpectators(hub *realtime.Hub, delay time.Duration, book *matchBook) *spectators {
+	s := &spectators{feed: realtime.NewDelayed(hub, delay), book: book, snapshots: map[string]snapshotFunc{}, versions: map[string]int{}, changes: make(chan struct{})}
+	book.onArchive = s.forget
+	return s
+}
+
+func spectateTopic(game, id string) string { return "spectate:" + game + ":" + id }
+
+func (s *spectators) count(game, id string) int { return s.feed.Subscribers(spectateTopic(game, id)) }
+
+// setCount stores the current audience on a game state.
+func (s *spectators) setCount(game, id string, state any) {
+	n := s.count(game, id)
+	switch g := state.(type) {
+	case *games.TicTacToe:
+		g.Spectators = n
+	case *games.NumberGuess:
+		g.Spectators = n
+	case *games.RPSGame:
+		g.Spectators = n
+	case *games.Hangman:
+		g.Spectators = n
+	case *games.ConnectFour:
+		g.Spectators = n
+	case *games.Ultimate:
+		g.Spectators = n
+	case *games.Minesweeper:
+		g.Spectators = n
+	case *games.Sudoku:
+		g.Spectators = n
+	case *games.Wordle:
+		g.Spectators = n
+	case *games.BattleshipView:
+		g.Spectators = n
+	case *games.Checkers:
+		g.Spectators = n
+	case *games.Reversi:
+		g.Spectators = n
+	case *games.Chess:
+		g.Spectators = n
+	}
+}
+
+// encode fills in the audience and marshals state; callers hold the game lock.
+func (s *spectators) encode(game, id string, state any) json.RawMessage {
+	s.setCount(game, id, state)
+	b, _ := json.Marshal(state)
+	return b
+}
+
+// publish records a new state for spectators; callers hold the game lock.
+func (s *spectators) publish(game, id string, state any) {
+	topic := spectateTopic(game, id)
+	s.feed.Publish(topic, realtime.Message{Event: "state", Data: s.encode(game, id, state)})
+	s.mu.Lock()
+	s.versions[topic]++
+	close(s.changes)
+	s.changes = make(chan struct{})
+	s.mu.Unlock()
+}
+
+// forget drops the spectator history of an archived game.
+func (s *spectators) forget(game, id string) { s.feed.Forget(spectateTopic(game, id)) }
+
+// outsider reports whether user only gets the delayed view of a game: there
+// is a spectator delay, the game is live and has claimed seats, none of them
+// the user's.
+func (s *spectators) outsider(game, id, user string) bool {
+	if s.feed.Delay() == 0 { return false }
+	m, ok := s.book.get(id)
+	if !ok || m.reported || m.archived { return false }
+	claimed := false
+	for _, u := range m.Seats {
+		if u != "" && u == user { return false }
+		claimed = claimed || u != ""
+	}
+	return claimed
+}
+
+// view writes the state of a game to the caller: the live one for players,
+// what spectators currently see for outsiders. Callers hold the game lock.
+func (s *spectators) view(w http.ResponseWriter, r *http.Request, game, id string, state any) {
+	if !s.outsider(game, id, userID(r)) {
+		s.setCount(game, id, state)
+		writeJSON(w, http.StatusOK, state)
+		return
+	}
+	w.Header().Set("X-Spectator-Delay", s.feed.Delay().String())
+	m, ok := s.feed.Latest(spectateTopic(game, id))
+	if !ok { writeErr(w, http.StatusForbidden, "live game: spectators see it "+s.feed.Delay().String()+" late"); return }
+	writeJSON(w, http.StatusOK, m.Data)
+}
+
+// changed returns a channel that is closed by the next publish of any game.
+func (s *spectators) changed() <-chan struct{} {
+	s.mu.Lock(); defer s.mu.Unlock()
+	return s.changes
+}
+
+// version counts the publishes of a game; it changes whenever the game did.
+func (s *spectators) version(game, id string) int {
+	s.mu.Lock(); defer s.mu.Unlock()
+	return s.versions[spectateTopic(game, id)]
+}
+
+// refresh republishes a game after its audience changed.
+func (s *spectators) refresh(game, id string) {
+	if b, ok := s.snapshots[game](id); ok {
+		s.feed.Publish(spectateTopic(game, id), realtime.Message{Event: "state", Data: b})
+	}
+}
+
+// mountSpectate adds GET /{game}/{id}/spectate (Server-Sent Events) for every
+// game with a registered snapshot func.
+func mountSpectate(r chi.Router, s *spectators) {
+	for game, snap := range s.snapshots {
+		game, snap := game, snap
+		r.Get("/"+game+"/{id}/spectate", func(w http.ResponseWriter, r *http.Request) {
+			id := chi.URLParam(r, "id")
+			if _, ok := snap(id); !ok { http.NotFound(w, r); return }
+			ch, cancel := s.feed.Subscribe(spectateTopic(game, id))
+			s.refresh(game, id)
+			defer func() {
+				cancel()
+				s.refresh(game, id)
+			}()
+			w.Header().Set("X-Spectator-Delay", s.feed.Delay().String())
+			realtime.ServeSSE(w, r, ch)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/Manishk5507/gaMerZ/backend/internal/events"
+	"github.com/Manishk5507/gaMerZ/backend/internal/games"
+)
+
+// sudoku serves /games/sudoku, a single player puzzle: enter digits and
+// pencil marks by row and column, ask for hints, reset and rematch.
+type sudoku struct{ *table[*games.Sudoku] }
+
+func newSudoku(book *matchBook, watch *spectators) *sudoku {
+	return &sudoku{newTable("sudoku", book, watch, tableOps[*games.Sudoku]{
+		Series: func(g *games.Sudoku) **games.Series { return &g.Series },
+		Reset:  func(g *games.Sudoku) error { g.Reset(); return nil },
+		Again:  func(g *games.Sudoku) (*games.Sudoku, error) { return games.NewSudoku(g.Difficulty) }, // a fresh puzzle of the same level
+		Result: func(g *games.Sudoku) (map[string]string, map[string]any) {
+			if !g.Solved { return nil, nil }
+			return map[string]string{"player": events.Win}, sudokuStats(g)
+		},
+	})}
+}
+
+func sudokuStats(g *games.Sudoku) map[string]any {
+	return map[string]any{"difficulty": g.Difficulty, "hints": g.Hints, "moves": g.Moves}
+}
+
+func (s *sudoku) mount(r chi.Router) {
+	s.table.mount(r)
+	r.Post("/sudoku/new", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Difficulty string `json:"difficulty"` // easy, medium (default), hard or expert
+			Puzzle     string `json:"puzzle"`     // optional 81 digits to play instead
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body) // optional body
+		var g *games.Sudoku
+		var err error
+		if body.Puzzle != "" { g, err = games.NewSudokuFrom(body.Puzzle) } else { g, err = games.NewSudoku(body.Difficulty) }
+		switch {
+		case errors.Is(err, games.ErrNoSolution), errors.Is(err, games.ErrMultipleSolutions):
+			writeErr(w, http.StatusUnprocessableEntity, err.Error()); return
+		case errors.Is(err, games.ErrSudokuBusy):
+			writeErr(w, http.StatusServiceUnavailable, err.Error()); return
+		case err != nil:
+			writeErr(w, http.StatusBadRequest, err.Error()); return
+		}
+		s.mu.Lock(); defer s.mu.Unlock()
+		id := s.add(g, map[string]string{"player": userID(r)}, false)
+		s.respond(w, r, http.StatusCreated, id, g, map[string]any{"gameId": id})
+	})
+	actions := map[string]func(g *games.Sudoku, row, col, value int) bool{
+		"set":  (*games.Sudoku).Set,
+		"mark": (*games.Sudoku).Mark,
+	}
+	for name, act := range actions {
+		act := act
+		r.Post("/sudoku/{id}/"+name, s.handle(func(w http.ResponseWriter, r *http.Request, id string, g *games.Sudoku) bool {
+			var body struct {
+				Row   int `json:"row"`
+				Col   int `json:"col"`
+				Value int `json:"value"`
+			}
+			if !decode(w, r, &body) { return false }
+			if !s.book.canAct(id, "player", userID(r)) { writeErr(w, http.StatusForbidden, "not your game"); return false }
+			if !act(g, body.Row, body.Col, body.Value) { writeErr(w, http.StatusBadRequest, "invalid move"); return false }
+			return true
+		}))
+	}
+	r.Post("/sudoku/{id}/hint", s.handle(func(w http.ResponseWriter, r *http.Request, id string, g *games.Sudoku) bool {
+		if !s.book.canAct(id, "player", userID(r)) { writeErr(w, http.StatusForbidden, "not your game"); return false }
+		if !g.Hint() { writeErr(w, http.StatusBadRequest, "puzzle is already solved"); return false }
+		return true
+	}))
+}
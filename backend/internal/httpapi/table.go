@@ -0,0 +1,161 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/Manishk5507/gaMerZ/backend/internal/games"
+)
+
+// table holds the games of one type and serves what they all share: lookup,
+// GET, reset, undo and rematch. The game files add their /new and move
+// endpoints on top.
+type table[G any] struct {
+	name  string
+	mu    sync.Mutex
+	games map[string]G
+	book  *matchBook
+	watch *spectators
+	ops   tableOps[G]
+}
+
+// tableOps are the per game parts of the shared endpoints.
+type tableOps[G any] struct {
+	Series func(G) **games.Series // the game's Series field
+	Reset  func(G) error          // nil when the game has no /reset
+	Undo   func(G) bool           // nil when the game has no /undo
+	Again  func(G) (G, error)     // a new game with the same settings, for /rematch
+	Swap   bool                   // humans swap seats on rematch so the other side opens
+	// Result returns the outcomes per seat and the stats of a finished game,
+	// nil outcomes while it is going on.
+	Result func(G) (map[string]string, map[string]any)
+	// Public is what spectators see of a game and State what a request gets
+	// back; both default to the game itself.
+	Public func(G) any
+	State  func(r *http.Request, id string, g G) any
+}
+
+func newTable[G any](name string, book *matchBook, watch *spectators, ops tableOps[G]) *table[G] {
+	t := &table[G]{name: name, games: map[string]G{}, book: book, watch: watch, ops: ops}
+	if t.ops.Public == nil { t.ops.Public = func(g G) any { return g } }
+	if t.ops.State == nil { t.ops.State = func(_ *http.Request, _ string, g G) any { return g } }
+	watch.snapshots[name] = func(id string) (json.RawMessage, bool) {
+		t.mu.Lock(); defer t.mu.Unlock()
+		g, ok := t.games[id]
+		if !ok { return nil, false }
+		return watch.encode(name, id, t.ops.Public(g)), true
+	}
+	return t
+}
+
+// add registers a new game and publishes it; callers hold t.mu.
+func (t *table[G]) add(g G, seats map[string]string, rated bool) string {
+	id := randID()
+	t.games[id] = g
+	t.book.register(id, t.name, seats, rated)
+	t.publish(id, g)
+	return id
+}
+
+// publish sends the public state of a game to its spectators; callers hold
+// t.mu.
+func (t *table[G]) publish(id string, g G) { t.watch.publish(t.name, id, t.ops.Public(g)) }
+
+// lookup returns the game for the request, answering 404 itself; callers
+// hold t.mu.
+func (t *table[G]) lookup(w http.ResponseWriter, r *http.Request) (string, G, bool) {
+	id := chi.URLParam(r, "id")
+	g, ok := t.games[id]
+	if !ok { http.NotFound(w, r) }
+	return id, g, ok
+}
+
+// respond writes the state of g for the request, inside extra as "state"
+// when extra is given.
+func (t *table[G]) respond(w http.ResponseWriter, r *http.Request, status int, id string, g G, extra map[string]any) {
+	state := t.ops.State(r, id, g)
+	t.watch.setCount(t.name, id, state)
+	if extra == nil { writeJSON(w, status, state); return }
+	extra["state"] = state
+	writeJSON(w, status, extra)
+}
+
+// decode reads a JSON body into v, answering 400 itself.
+func decode(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil { writeErr(w, http.StatusBadRequest, "invalid body"); return false }
+	return true
+}
+
+// handle serves a change to a writable game: play decodes and applies it,
+// answering itself and returning false when it fails. The game is then
+// recorded if over, published and returned.
+func (t *table[G]) handle(play func(w http.ResponseWriter, r *http.Request, id string, g G) bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		t.mu.Lock(); defer t.mu.Unlock()
+		id, g, ok := t.lookup(w, r)
+		if !ok || !t.book.writable(w, id) || !play(w, r, id, g) { return }
+		if out, stats := t.ops.Result(g); out != nil { t.book.finished(id, out, stats) }
+		t.publish(id, g)
+		t.respond(w, r, http.StatusOK, id, g, nil)
+	}
+}
+
+// mount adds GET /{name}/{id}, /reset, /undo and /rematch.
+func (t *table[G]) mount(r chi.Router) {
+	book, name := t.book, t.name
+	r.Get("/"+name+"/{id}", func(w http.ResponseWriter, r *http.Request) {
+		t.mu.Lock(); defer t.mu.Unlock()
+		id, g, ok := t.lookup(w, r)
+		if !ok { return }
+		t.watch.view(w, r, name, id, t.ops.State(r, id, g))
+	})
+	if t.ops.Reset != nil {
+		r.Post("/"+name+"/{id}/reset", func(w http.ResponseWriter, r *http.Request) {
+			t.mu.Lock(); defer t.mu.Unlock()
+			id, g, ok := t.lookup(w, r)
+			if !ok || !book.writable(w, id) { return }
+			if *t.ops.Series(g) != nil { writeErr(w, http.StatusBadRequest, "use rematch to continue a series"); return }
+			if m, _ := book.get(id); m.Rated { writeErr(w, http.StatusBadRequest, "cannot reset a rated game"); return }
+			if err := t.ops.Reset(g); err != nil { writeErr(w, http.StatusInternalServerError, err.Error()); return }
+			book.rearm(id)
+			t.publish(id, g)
+			t.respond(w, r, http.StatusOK, id, g, nil)
+		})
+	}
+	if t.ops.Undo != nil {
+		r.Post("/"+name+"/{id}/undo", func(w http.ResponseWriter, r *http.Request) {
+			t.mu.Lock(); defer t.mu.Unlock()
+			id, g, ok := t.lookup(w, r)
+			if !ok || !book.writable(w, id) { return }
+			if m, _ := book.get(id); m.Rated { writeErr(w, http.StatusBadRequest, "cannot undo in a rated game"); return }
+			if !t.ops.Undo(g) { writeErr(w, http.StatusBadRequest, "cannot undo"); return }
+			book.rearm(id) // an undone result is reported again once replayed
+			book.assist(id)
+			t.publish(id, g)
+			t.respond(w, r, http.StatusOK, id, g, nil)
+		})
+	}
+	r.Post("/"+name+"/{id}/rematch", func(w http.ResponseWriter, r *http.Request) {
+		t.mu.Lock(); defer t.mu.Unlock()
+		id, g, ok := t.lookup(w, r)
+		if !ok { return }
+		var body rematchBody
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if !book.writable(w, id) { return }
+		ng, err := t.ops.Again(g)
+		if errors.Is(err, games.ErrSudokuBusy) { writeErr(w, http.StatusServiceUnavailable, err.Error()); return }
+		if err != nil { writeErr(w, http.StatusInternalServerError, err.Error()); return }
+		newID := randID()
+		series, err := book.rematch(id, newID, userID(r), body.BestOf, t.ops.Swap)
+		if err != nil { rematchErr(w, err); return }
+		if s := t.ops.Series(g); *s == nil { *s = series }
+		*t.ops.Series(ng) = series
+		t.games[newID] = ng
+		t.publish(newID, ng)
+		t.respond(w, r, http.StatusCreated, newID, ng, map[string]any{"gameId": newID, "previousId": id})
+	})
+}
@@ -0,0 +1,38 @@
+package httpapi
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+func TestUndoReopensTheResult(t *testing.T) {
+	h := NewRouter()
+	code, out := call(t, h, "POST", "/games/connectfour/new", "alice", `{"players":{"R":"alice","Y":"bob"}}`)
+	if code != http.StatusCreated {
+		t.Fatalf("new game: %d %v", code, out)
+	}
+	base := "/games/connectfour/" + out["gameId"].(string)
+	move := func(user string, col int) {
+		t.Helper()
+		if code, out := call(t, h, "POST", base+"/move", user, `{"col":`+strconv.Itoa(col)+`}`); code != http.StatusOK {
+			t.Fatalf("%s col %d: %d %v", user, col, code, out)
+		}
+	}
+	// alice wins on column 0
+	for i := 0; i < 3; i++ {
+		move("alice", 0)
+		move("bob", 1)
+	}
+	move("alice", 0)
+	if code, _ = call(t, h, "POST", base+"/undo", "alice", ""); code != http.StatusOK {
+		t.Fatalf("undo: %d", code)
+	}
+	if code, _ = call(t, h, "POST", base+"/rematch", "alice", ""); code != http.StatusConflict {
+		t.Fatalf("rematch of an undone game: got %d, want 409", code)
+	}
+	move("alice", 0)
+	if code, _ = call(t, h, "POST", base+"/rematch", "alice", ""); code != http.StatusCreated {
+		t.Fatalf("the replayed win was not recorded: rematch got %d", code)
+	}
+}
@@ -0,0 +1,110 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/Manishk5507/gaMerZ/backend/internal/events"
+	"github.com/Manishk5507/gaMerZ/backend/internal/realtime"
+	"github.com/Manishk5507/gaMerZ/backend/internal/tournament"
+)
+
+func tournamentTopic(id string) string { return "tournament:" + id }
+
+func tournamentErr(w http.ResponseWriter, err error) {
+	status := http.StatusBadRequest
+	switch {
+	case errors.Is(err, tournament.ErrNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, tournament.ErrMissingUser):
+		status = http.StatusUnauthorized
+	case errors.Is(err, tournament.ErrNotOrganizer), errors.Is(err, tournament.ErrNotJoined):
+		status = http.StatusForbidden
+	case errors.Is(err, tournament.ErrNotRegistering), errors.Is(err, tournament.ErrAlreadyJoined):
+		status = http.StatusConflict
+	}
+	writeErr(w, status, err.Error())
+}
+
+// newTournaments creates matches through the two player starters (first
+// entrant takes the first seat) and pushes every change to the tournament's
+// event stream.
+func newTournaments(hub *realtime.Hub, starters map[string]starter) *tournament.Manager {
+	var types []string
+	for game := range starters { types = append(types, game) }
+	start := func(game, a, b string, rated bool) (string, error) {
+		seats := multiplayerSeats()[game]
+		return starters[game](map[string]string{seats[0]: a, seats[1]: b}, rated), nil
+	}
+	return tournament.NewManager(types, start, func(t tournament.Tournament) {
+		hub.Publish(tournamentTopic(t.ID), realtime.Message{Event: "tournament", Data: t})
+	})
+}
+
+// reportToTournament feeds finished games into the manager. It runs in its
+// own goroutine because publishers hold their game store lock, which the
+// manager needs to create follow-up matches.
+func reportToTournament(mg *tournament.Manager) func(events.GameFinished) {
+	return func(e events.GameFinished) {
+		winner := ""
+		for _, p := range e.Players {
+			if p.Outcome == events.Win { winner = p.User }
+		}
+		go mg.Report(e.GameID, winner)
+	}
+}
+
+func mountTournaments(r chi.Router, hub *realtime.Hub, mg *tournament.Manager) {
+	r.Route("/tournaments", func(r chi.Router) {
+		r.Get("/", func(w http.ResponseWriter, r *http.Request) { writeJSON(w, http.StatusOK, mg.List()) })
+		r.Post("/", func(w http.ResponseWriter, r *http.Request) {
+			var body struct {
+				Name   string            `json:"name"`
+				Game   string            `json:"game"`
+				Format tournament.Format `json:"format"`
+				Rated  bool              `json:"rated"`
+				Rounds int               `json:"rounds"` // swiss only
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil { writeErr(w, http.StatusBadRequest, "invalid body"); return }
+			t, err := mg.Create(userID(r), body.Name, body.Game, body.Format, body.Rated, body.Rounds)
+			if err != nil { tournamentErr(w, err); return }
+			writeJSON(w, http.StatusCreated, t)
+		})
+		r.Get("/{id}", func(w http.ResponseWriter, r *http.Request) {
+			t, err := mg.Get(chi.URLParam(r, "id"))
+			if err != nil { tournamentErr(w, err); return }
+			writeJSON(w, http.StatusOK, t)
+		})
+		r.Get("/{id}/standings", func(w http.ResponseWriter, r *http.Request) {
+			t, err := mg.Get(chi.URLParam(r, "id"))
+			if err != nil { tournamentErr(w, err); return }
+			writeJSON(w, http.StatusOK, t.Standings)
+		})
+		r.Post("/{id}/join", func(w http.ResponseWriter, r *http.Request) {
+			t, err := mg.Join(chi.URLParam(r, "id"), userID(r))
+			if err != nil { tournamentErr(w, err); return }
+			writeJSON(w, http.StatusOK, t)
+		})
+		r.Post("/{id}/leave", func(w http.ResponseWriter, r *http.Request) {
+			t, err := mg.Leave(chi.URLParam(r, "id"), userID(r))
+			if err != nil { tournamentErr(w, err); return }
+			writeJSON(w, http.StatusOK, t)
+		})
+		r.Post("/{id}/start", func(w http.ResponseWriter, r *http.Request) {
+			t, err := mg.Start(chi.URLParam(r, "id"), userID(r))
+			if err != nil { tournamentErr(w, err); return }
+			writeJSON(w, http.StatusOK, t)
+		})
+		r.Get("/{id}/events", func(w http.ResponseWriter, r *http.Request) {
+			id := chi.URLParam(r, "id")
+			t, err := mg.Get(id)
+			if err != nil { tournamentErr(w, err); return }
+			ch, cancel := hub.Subscribe(tournamentTopic(id))
+			defer cancel()
+			realtime.ServeSSE(w, r, ch, realtime.Message{Event: "tournament", Data: t})
+		})
+	})
+}
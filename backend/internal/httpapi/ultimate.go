@@ -0,0 +1,99 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/Manishk5507/gaMerZ/backend/internal/events"
+	"github.com/Manishk5507/gaMerZ/backend/internal/games"
+)
+
+// ultimate serves /games/ultimate (Ultimate Tic Tac Toe) with the same shape
+// as TicTacToe: vs AI or two seats ("X" opens, "O"), rated play, undo, reset
+// and rematch. Moves name a sub-board and a cell in it.
+type ultimate struct{ *table[*games.Ultimate] }
+
+func newUltimate(book *matchBook, watch *spectators) *ultimate {
+	return &ultimate{newTable("ultimate", book, watch, tableOps[*games.Ultimate]{
+		Series: func(g *games.Ultimate) **games.Series { return &g.Series },
+		Reset:  func(g *games.Ultimate) error { g.Reset(); return nil },
+		Undo:   (*games.Ultimate).Undo,
+		Again:  func(g *games.Ultimate) (*games.Ultimate, error) { return games.NewUltimate(g.VsAI, g.Difficulty), nil },
+		Swap:   true,
+		Result: func(g *games.Ultimate) (map[string]string, map[string]any) {
+			if g.Winner == "" { return nil, nil }
+			return ultOutcomes(g), ultStats(g)
+		},
+	})}
+}
+
+// start creates a two player game for matchmaking, rooms and tournaments.
+func (u *ultimate) start(seats map[string]string, rated bool) string {
+	u.mu.Lock(); defer u.mu.Unlock()
+	return u.add(games.NewUltimate(false, ""), seats, rated)
+}
+
+func (u *ultimate) turn(id string) (turnInfo, bool) {
+	u.mu.Lock(); defer u.mu.Unlock()
+	g, ok := u.games[id]
+	if !ok { return turnInfo{}, false }
+	return turnInfo{ToMove: []string{g.CurrentPlayer}, Over: g.Winner != ""}, true
+}
+
+func (u *ultimate) legal(id string) []any {
+	u.mu.Lock(); defer u.mu.Unlock()
+	out := []any{}
+	if g, ok := u.games[id]; ok {
+		for _, m := range g.Legal() { out = append(out, map[string]int{"board": m[0], "cell": m[1]}) }
+	}
+	return out
+}
+
+// ultOutcomes maps a finished board to per seat outcomes.
+func ultOutcomes(g *games.Ultimate) map[string]string {
+	switch g.Winner {
+	case "X":
+		return map[string]string{"X": events.Win, "O": events.Loss}
+	case "O":
+		return map[string]string{"X": events.Loss, "O": events.Win}
+	}
+	return map[string]string{"X": events.Draw, "O": events.Draw}
+}
+
+func ultStats(g *games.Ultimate) map[string]any {
+	return map[string]any{"vsAI": g.VsAI, "difficulty": g.Difficulty, "moves": len(g.Moves)}
+}
+
+func (u *ultimate) mount(r chi.Router) {
+	u.table.mount(r)
+	r.Post("/ultimate/new", func(w http.ResponseWriter, r *http.Request) {
+		u.mu.Lock(); defer u.mu.Unlock()
+		var body struct {
+			VsAI       bool              `json:"vsAI"`
+			Difficulty string            `json:"difficulty"` // easy, medium (default) or hard
+			Players    map[string]string `json:"players"`    // optional seat (X, O) -> user
+			Rated      bool              `json:"rated"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body) // optional body
+		seats := map[string]string{"X": body.Players["X"], "O": body.Players["O"]}
+		if body.VsAI { seats = map[string]string{"X": userID(r), "O": ""} }
+		if body.Rated && (body.VsAI || seats["X"] == "" || seats["O"] == "" || seats["X"] == seats["O"]) {
+			writeErr(w, http.StatusBadRequest, "rated games need two distinct human players"); return
+		}
+		g := games.NewUltimate(body.VsAI, body.Difficulty)
+		id := u.add(g, seats, body.Rated)
+		u.respond(w, r, http.StatusCreated, id, g, map[string]any{"gameId": id})
+	})
+	r.Post("/ultimate/{id}/move", u.handle(func(w http.ResponseWriter, r *http.Request, id string, g *games.Ultimate) bool {
+		var body struct {
+			Board int `json:"board"`
+			Cell  int `json:"cell"`
+		}
+		if !decode(w, r, &body) { return false }
+		if !u.book.canAct(id, g.CurrentPlayer, userID(r)) { writeErr(w, http.StatusForbidden, "not your turn"); return false }
+		if !g.Play(body.Board, body.Cell) { writeErr(w, http.StatusBadRequest, "invalid move"); return false }
+		return true
+	}))
+}
@@ -0,0 +1,75 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"os"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/Manishk5507/gaMerZ/backend/internal/events"
+	"github.com/Manishk5507/gaMerZ/backend/internal/games"
+)
+
+// loadWordList swaps the built-in dictionary Hangman and Wordle share for the
+// file named by WORDLIST (one word per line), if set.
+func loadWordList() {
+	path := os.Getenv("WORDLIST")
+	if path == "" { return }
+	f, err := os.Open(path)
+	if err != nil { log.Printf("[WORDLE] ignoring WORDLIST: %v", err); return }
+	defer f.Close()
+	d, err := games.LoadDictionary(f)
+	if err != nil { log.Printf("[WORDLE] ignoring WORDLIST %q: %v", path, err); return }
+	games.UseWords(d)
+	log.Printf("[WORDLE] Loaded %d words from %s", d.Len(), path)
+}
+
+// wordle serves /games/wordle, a single player game: guess the hidden word
+// within the allowed attempts, reset and rematch.
+type wordle struct{ *table[*games.Wordle] }
+
+func newWordle(book *matchBook, watch *spectators) *wordle {
+	return &wordle{newTable("wordle", book, watch, tableOps[*games.Wordle]{
+		Series: func(g *games.Wordle) **games.Series { return &g.Series },
+		Reset:  (*games.Wordle).Reset,
+		Again:  func(g *games.Wordle) (*games.Wordle, error) { return games.NewWordle(g.Length, g.MaxAttempts, g.HardMode) },
+		Result: func(g *games.Wordle) (map[string]string, map[string]any) {
+			if !g.Finished { return nil, nil }
+			out := events.Loss
+			if g.Won { out = events.Win }
+			return map[string]string{"player": out}, wordleStats(g)
+		},
+	})}
+}
+
+func wordleStats(g *games.Wordle) map[string]any {
+	return map[string]any{"length": g.Length, "guesses": len(g.Guesses), "maxAttempts": g.MaxAttempts, "hardMode": g.HardMode}
+}
+
+func (m *wordle) mount(r chi.Router) {
+	m.table.mount(r)
+	r.Post("/wordle/new", func(w http.ResponseWriter, r *http.Request) {
+		m.mu.Lock(); defer m.mu.Unlock()
+		var body struct {
+			Length      int  `json:"length"`      // 4-7, 5 by default
+			MaxAttempts int  `json:"maxAttempts"` // 1-10, 6 by default
+			HardMode    bool `json:"hardMode"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body) // optional body
+		g, err := games.NewWordle(body.Length, body.MaxAttempts, body.HardMode)
+		if err != nil { writeErr(w, http.StatusBadRequest, err.Error()); return }
+		id := m.add(g, map[string]string{"player": userID(r)}, false)
+		m.respond(w, r, http.StatusCreated, id, g, map[string]any{"gameId": id})
+	})
+	r.Post("/wordle/{id}/guess", m.handle(func(w http.ResponseWriter, r *http.Request, id string, g *games.Wordle) bool {
+		var body struct {
+			Word string `json:"word"`
+		}
+		if !decode(w, r, &body) { return false }
+		if !m.book.canAct(id, "player", userID(r)) { writeErr(w, http.StatusForbidden, "not your game"); return false }
+		if err := g.Guess(body.Word); err != nil { writeErr(w, http.StatusBadRequest, err.Error()); return false }
+		return true
+	}))
+}
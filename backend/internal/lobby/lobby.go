@@ -0,0 +1,358 @@
+package lobby
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"log"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Room statuses.
+const (
+	Waiting = "waiting"
+	Playing = "playing"
+)
+
+// Event types pushed to room and lobby subscribers.
+const (
+	RoomCreated  = "room-created"
+	MemberJoined = "member-joined"
+	MemberLeft   = "member-left"
+	ReadyChanged = "ready-changed"
+	GameStarted  = "game-started"
+	RoomClosed   = "room-closed"
+	MemberKicked = "member-kicked"
+)
+
+var (
+	ErrNotFound     = errors.New("room not found")
+	ErrUnknownGame  = errors.New("game type does not support rooms")
+	ErrBadPasscode  = errors.New("wrong passcode")
+	ErrFull         = errors.New("room is full")
+	ErrSeatTaken    = errors.New("seat is taken")
+	ErrNotMember    = errors.New("not in this room")
+	ErrAlreadyIn    = errors.New("already in this room")
+	ErrStarted      = errors.New("game already started")
+	ErrMissingUser  = errors.New("missing user")
+	ErrMissingTitle = errors.New("room name required")
+	ErrNotHost      = errors.New("only the host can do that")
+	ErrBanned       = errors.New("you were removed from this room")
+)
+
+// Seat is a playing position of the room's game (e.g. "X" and "O").
+type Seat struct {
+	Name  string `json:"name"`
+	User  string `json:"user"`
+	Ready bool   `json:"ready"`
+}
+
+// Room groups players waiting for, or playing, one game. Copies of Room are
+// handed out; the passcode never leaves the package.
+type Room struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Game      string    `json:"game"`
+	Host      string    `json:"host"`
+	Locked    bool      `json:"locked"` // passcode protected
+	Seats     []Seat    `json:"seats"`
+	Status    string    `json:"status"`
+	GameID    string    `json:"gameId,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	passcode  string
+	banned    map[string]bool
+}
+
+// Event describes a membership or lifecycle change of a room.
+type Event struct {
+	Type string `json:"type"`
+	User string `json:"user,omitempty"`
+	Room Room   `json:"room"`
+}
+
+// Starter creates the underlying game once every seat is ready and returns
+// its id. seats maps seat names to users.
+type Starter func(game string, seats map[string]string) (string, error)
+
+// Lobby holds all rooms in memory.
+type Lobby struct {
+	mu     sync.Mutex
+	rooms  map[string]*Room
+	seats  map[string][]string // game type -> seat names
+	start  Starter
+	notify func(Event)
+}
+
+// New creates a lobby for the given game types. notify is called, outside of
+// the lobby lock, for every room event.
+func New(seats map[string][]string, start Starter, notify func(Event)) *Lobby {
+	return &Lobby{rooms: map[string]*Room{}, seats: seats, start: start, notify: notify}
+}
+
+func newRoomID() string {
+	b := make([]byte, 4)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func (r *Room) snapshot() Room {
+	c := *r
+	c.Seats = append([]Seat{}, r.Seats...)
+	c.passcode = ""
+	c.banned = nil
+	return c
+}
+
+func (r *Room) seatOf(user string) int {
+	for i, s := range r.Seats {
+		if s.User == user {
+			return i
+		}
+	}
+	return -1
+}
+
+func (r *Room) empty() bool {
+	for _, s := range r.Seats {
+		if s.User != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// Create opens a room and seats the host in the first seat.
+func (l *Lobby) Create(host, name, game, passcode string) (Room, error) {
+	if host == "" {
+		return Room{}, ErrMissingUser
+	}
+	if name == "" {
+		return Room{}, ErrMissingTitle
+	}
+	names, ok := l.seats[game]
+	if !ok {
+		return Room{}, ErrUnknownGame
+	}
+	r := &Room{ID: newRoomID(), Name: name, Game: game, Host: host, Locked: passcode != "", Status: Waiting, CreatedAt: time.Now(), passcode: passcode}
+	for _, n := range names {
+		r.Seats = append(r.Seats, Seat{Name: n})
+	}
+	r.Seats[0].User = host
+	l.mu.Lock()
+	l.rooms[r.ID] = r
+	snap := r.snapshot()
+	l.mu.Unlock()
+	log.Printf("[LOBBY] %s created room %s (%s locked=%v)", host, r.ID, game, r.Locked)
+	l.emit(Event{Type: RoomCreated, User: host, Room: snap})
+	return snap, nil
+}
+
+// List returns rooms still waiting for players, oldest first.
+func (l *Lobby) List() []Room {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	out := []Room{}
+	for _, r := range l.rooms {
+		if r.Status == Waiting {
+			out = append(out, r.snapshot())
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.Before(out[j].CreatedAt) })
+	return out
+}
+
+// Get returns a room by id.
+func (l *Lobby) Get(id string) (Room, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	r, ok := l.rooms[id]
+	if !ok {
+		return Room{}, ErrNotFound
+	}
+	return r.snapshot(), nil
+}
+
+// Join seats user in the requested seat, or the first free one if seat is empty.
+func (l *Lobby) Join(id, user, passcode, seat string) (Room, error) {
+	if user == "" {
+		return Room{}, ErrMissingUser
+	}
+	l.mu.Lock()
+	r, ok := l.rooms[id]
+	if !ok {
+		l.mu.Unlock()
+		return Room{}, ErrNotFound
+	}
+	if err := l.join(r, user, passcode, seat); err != nil {
+		l.mu.Unlock()
+		return Room{}, err
+	}
+	snap := r.snapshot()
+	l.mu.Unlock()
+	l.emit(Event{Type: MemberJoined, User: user, Room: snap})
+	return snap, nil
+}
+
+func (l *Lobby) join(r *Room, user, passcode, seat string) error {
+	if r.banned[user] {
+		return ErrBanned
+	}
+	if r.passcode != "" && subtle.ConstantTimeCompare([]byte(r.passcode), []byte(passcode)) != 1 {
+		return ErrBadPasscode
+	}
+	if r.Status != Waiting {
+		return ErrStarted
+	}
+	if r.seatOf(user) >= 0 {
+		return ErrAlreadyIn
+	}
+	for i := range r.Seats {
+		s := &r.Seats[i]
+		if seat != "" && s.Name != seat {
+			continue
+		}
+		if s.User != "" {
+			if seat != "" {
+				return ErrSeatTaken
+			}
+			continue
+		}
+		s.User, s.Ready = user, false
+		return nil
+	}
+	if seat != "" {
+		return ErrSeatTaken
+	}
+	return ErrFull
+}
+
+// Leave frees user's seat. The host role passes to the next seated user and
+// empty rooms are closed.
+func (l *Lobby) Leave(id, user string) error {
+	l.mu.Lock()
+	r, ok := l.rooms[id]
+	if !ok {
+		l.mu.Unlock()
+		return ErrNotFound
+	}
+	i := r.seatOf(user)
+	if i < 0 {
+		l.mu.Unlock()
+		return ErrNotMember
+	}
+	r.Seats[i].User, r.Seats[i].Ready = "", false
+	typ := MemberLeft
+	if r.empty() {
+		delete(l.rooms, id)
+		typ = RoomClosed
+	} else if r.Host == user {
+		for _, s := range r.Seats {
+			if s.User != "" {
+				r.Host = s.User
+				break
+			}
+		}
+	}
+	snap := r.snapshot()
+	l.mu.Unlock()
+	l.emit(Event{Type: typ, User: user, Room: snap})
+	return nil
+}
+
+// IsMember reports whether user holds a seat in the room.
+func (r Room) IsMember(user string) bool { return user != "" && r.seatOf(user) >= 0 }
+
+// Kick frees target's seat on the host's behalf and bars them from rejoining.
+// Once the game started the seat belongs to it, so kicks are refused.
+func (l *Lobby) Kick(id, host, target string) (Room, error) {
+	l.mu.Lock()
+	r, ok := l.rooms[id]
+	if !ok {
+		l.mu.Unlock()
+		return Room{}, ErrNotFound
+	}
+	if r.Host != host {
+		l.mu.Unlock()
+		return Room{}, ErrNotHost
+	}
+	i := r.seatOf(target)
+	if i < 0 || target == host {
+		l.mu.Unlock()
+		return Room{}, ErrNotMember
+	}
+	if r.Status != Waiting {
+		l.mu.Unlock()
+		return Room{}, ErrStarted
+	}
+	r.Seats[i].User, r.Seats[i].Ready = "", false
+	if r.banned == nil {
+		r.banned = map[string]bool{}
+	}
+	r.banned[target] = true
+	snap := r.snapshot()
+	l.mu.Unlock()
+	log.Printf("[LOBBY] %s kicked %s from room %s", host, target, id)
+	l.emit(Event{Type: MemberKicked, User: target, Room: snap})
+	return snap, nil
+}
+
+// SetReady toggles user's ready flag. When every seat is taken and ready the
+// underlying game is created and the room switches to playing.
+func (l *Lobby) SetReady(id, user string, ready bool) (Room, error) {
+	l.mu.Lock()
+	r, ok := l.rooms[id]
+	if !ok {
+		l.mu.Unlock()
+		return Room{}, ErrNotFound
+	}
+	if r.Status != Waiting {
+		l.mu.Unlock()
+		return Room{}, ErrStarted
+	}
+	i := r.seatOf(user)
+	if i < 0 {
+		l.mu.Unlock()
+		return Room{}, ErrNotMember
+	}
+	r.Seats[i].Ready = ready
+	evs := []Event{{Type: ReadyChanged, User: user, Room: r.snapshot()}}
+	if allReady(r.Seats) {
+		seats := map[string]string{}
+		for _, s := range r.Seats {
+			seats[s.Name] = s.User
+		}
+		gameID, err := l.start(r.Game, seats)
+		if err != nil {
+			r.Seats[i].Ready = false
+			l.mu.Unlock()
+			return Room{}, err
+		}
+		r.Status, r.GameID = Playing, gameID
+		log.Printf("[LOBBY] room %s started %s game %s", r.ID, r.Game, gameID)
+		evs = append(evs, Event{Type: GameStarted, Room: r.snapshot()})
+	}
+	snap := r.snapshot()
+	l.mu.Unlock()
+	for _, e := range evs {
+		l.emit(e)
+	}
+	return snap, nil
+}
+
+func allReady(seats []Seat) bool {
+	for _, s := range seats {
+		if s.User == "" || !s.Ready {
+			return false
+		}
+	}
+	return true
+}
+
+func (l *Lobby) emit(e Event) {
+	if l.notify != nil {
+		l.notify(e)
+	}
+}
@@ -0,0 +1,108 @@
+package lobby
+
+import "testing"
+
+func newTestLobby(events *[]Event) (*Lobby, *map[string]string) {
+	started := map[string]string{}
+	l := New(map[string][]string{"tictactoe": {"X", "O"}},
+		func(game string, seats map[string]string) (string, error) {
+			for k, v := range seats {
+				started[k] = v
+			}
+			return "g1", nil
+		},
+		func(e Event) { *events = append(*events, e) })
+	return l, &started
+}
+
+func TestRoomLifecycle(t *testing.T) {
+	var evs []Event
+	l, started := newTestLobby(&evs)
+	r, err := l.Create("ann", "ann's room", "tictactoe", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := l.Join(r.ID, "bob", "", ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := l.Join(r.ID, "cid", "", ""); err != ErrFull {
+		t.Fatalf("expected full room, got %v", err)
+	}
+	if r, _ = l.SetReady(r.ID, "ann", true); r.Status != Waiting {
+		t.Fatal("should wait for every seat")
+	}
+	r, _ = l.SetReady(r.ID, "bob", true)
+	if r.Status != Playing || r.GameID != "g1" {
+		t.Fatalf("expected game start, got %+v", r)
+	}
+	if (*started)["X"] != "ann" || (*started)["O"] != "bob" {
+		t.Fatalf("unexpected seats %v", *started)
+	}
+	if len(l.List()) != 0 {
+		t.Fatal("playing rooms should not be listed as open")
+	}
+	if last := evs[len(evs)-1]; last.Type != GameStarted {
+		t.Fatalf("expected game-started event last, got %s", last.Type)
+	}
+}
+
+func TestPasscodeAndHostHandover(t *testing.T) {
+	var evs []Event
+	l, _ := newTestLobby(&evs)
+	r, _ := l.Create("ann", "secret", "tictactoe", "1234")
+	if !r.Locked {
+		t.Fatal("room with passcode should be locked")
+	}
+	if _, err := l.Join(r.ID, "bob", "nope", ""); err != ErrBadPasscode {
+		t.Fatalf("expected bad passcode, got %v", err)
+	}
+	if _, err := l.Join(r.ID, "bob", "1234", "O"); err != nil {
+		t.Fatal(err)
+	}
+	l.Leave(r.ID, "ann")
+	if r, _ = l.Get(r.ID); r.Host != "bob" {
+		t.Fatalf("host should pass to bob, got %q", r.Host)
+	}
+	l.Leave(r.ID, "bob")
+	if _, err := l.Get(r.ID); err != ErrNotFound {
+		t.Fatal("empty room should be closed")
+	}
+	if evs[len(evs)-1].Type != RoomClosed {
+		t.Fatalf("expected room-closed, got %s", evs[len(evs)-1].Type)
+	}
+}
+
+func TestUnknownGameRejected(t *testing.T) {
+	var evs []Event
+	l, _ := newTestLobby(&evs)
+	if _, err := l.Create("ann", "x", "hangman", ""); err != ErrUnknownGame {
+		t.Fatalf("expected unknown game, got %v", err)
+	}
+}
+
+func TestKick(t *testing.T) {
+	var evs []Event
+	l, _ := newTestLobby(&evs)
+	r, _ := l.Create("ann", "ann's room", "tictactoe", "")
+	l.Join(r.ID, "bob", "", "")
+	if _, err := l.Kick(r.ID, "bob", "ann"); err != ErrNotHost {
+		t.Fatalf("only the host kicks, got %v", err)
+	}
+	if r, err := l.Kick(r.ID, "ann", "bob"); err != nil || r.IsMember("bob") {
+		t.Fatalf("kick failed: %v %+v", err, r)
+	}
+	if _, err := l.Join(r.ID, "bob", "", ""); err != ErrBanned {
+		t.Fatalf("a kicked user cannot rejoin, got %v", err)
+	}
+
+	// the seats of a started game stay with their players
+	l.Join(r.ID, "cid", "", "")
+	l.SetReady(r.ID, "ann", true)
+	l.SetReady(r.ID, "cid", true)
+	if _, err := l.Kick(r.ID, "ann", "cid"); err != ErrStarted {
+		t.Fatalf("kicks during play should be refused, got %v", err)
+	}
+	if r, _ := l.Get(r.ID); !r.IsMember("cid") {
+		t.Fatal("cid lost the seat")
+	}
+}
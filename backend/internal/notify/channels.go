@@ -0,0 +1,104 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Webhook POSTs the notification as JSON to the contact's webhook URL.
+// Users choose the URL, so unless AllowPrivate is set the default client
+// only connects to public addresses (checked after DNS resolution, so names
+// pointing at loopback, private, link-local or metadata addresses are
+// refused too). Redirects are never followed.
+type Webhook struct {
+	Client       *http.Client // nil uses a guarded client with a 10s timeout
+	AllowPrivate bool         // let the default client reach non-public addresses
+}
+
+// ErrPrivateAddress is returned for webhooks resolving to a non-public IP.
+var ErrPrivateAddress = errors.New("webhook address is not public")
+
+func (w Webhook) Notify(c Contact, n Notification) error {
+	if c.Webhook == "" {
+		return nil
+	}
+	client := w.Client
+	if client == nil {
+		client = w.defaultClient()
+	}
+	guarded := *client
+	guarded.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+	body, _ := json.Marshal(n)
+	resp, err := guarded.Post(c.Webhook, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook answered %s", resp.Status)
+	}
+	return nil
+}
+
+func (w Webhook) defaultClient() *http.Client {
+	dialer := &net.Dialer{Timeout: 5 * time.Second}
+	if !w.AllowPrivate {
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !publicIP(ip) {
+				return fmt.Errorf("%w: %s", ErrPrivateAddress, host)
+			}
+			return nil
+		}
+	}
+	// no proxy: the dialer must see the webhook's own address
+	return &http.Client{Timeout: 10 * time.Second, Transport: &http.Transport{DialContext: dialer.DialContext}}
+}
+
+// cgnat is the shared address space of RFC 6598, not routable on the internet.
+var cgnat = &net.IPNet{IP: net.IP{100, 64, 0, 0}, Mask: net.CIDRMask(10, 32)}
+
+// publicIP reports whether ip is a globally routable unicast address.
+func publicIP(ip net.IP) bool {
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+		if ip4[0] == 0 || cgnat.Contains(ip4) {
+			return false
+		}
+	}
+	return ip.IsGlobalUnicast() && !ip.IsPrivate() && !ip.IsLoopback() && !ip.IsLinkLocalUnicast()
+}
+
+// SMTP mails the notification to the contact's email address.
+type SMTP struct {
+	Addr string    // host:port of the mail server
+	From string    // envelope and header sender
+	Auth smtp.Auth // optional
+}
+
+func (s SMTP) Notify(c Contact, n Notification) error {
+	if c.Email == "" {
+		return nil
+	}
+	return smtp.SendMail(s.Addr, s.Auth, s.From, []string{c.Email}, message(s.From, c.Email, n))
+}
+
+func message(from, to string, n Notification) []byte {
+	subject := "gaMerZ: " + n.Text
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\nTo: %s\r\nSubject: %s\r\nDate: %s\r\n", from, to, subject, n.At.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\nContent-Type: text/plain; charset=utf-8\r\n\r\n")
+	fmt.Fprintf(&b, "%s\r\n\r\nGame: %s %s\r\n", n.Text, n.GameType, n.GameID)
+	return []byte(b.String())
+}
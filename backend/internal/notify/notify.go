@@ -0,0 +1,126 @@
+// Package notify tells users about things that happen while they are away,
+// such as their turn coming up in a correspondence game. Delivery channels
+// are pluggable Notifiers; users choose theirs by registering a Contact.
+package notify
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/mail"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// Notification kinds.
+const (
+	YourTurn = "your-turn"
+	GameOver = "game-over"
+)
+
+// Notification is one message for one user.
+type Notification struct {
+	User     string    `json:"user"`
+	Kind     string    `json:"kind"`
+	GameType string    `json:"gameType"`
+	GameID   string    `json:"gameId"`
+	Text     string    `json:"text"`
+	At       time.Time `json:"at"`
+}
+
+// Contact is where a user wants to be notified; empty fields are unused.
+type Contact struct {
+	Webhook string `json:"webhook,omitempty"`
+	Email   string `json:"email,omitempty"`
+}
+
+var (
+	ErrBadWebhook = errors.New("webhook must be an absolute http or https URL")
+	ErrBadEmail   = errors.New("invalid email address")
+)
+
+// Validate checks the addresses of c.
+func (c Contact) Validate() error {
+	if c.Webhook != "" {
+		u, err := url.Parse(c.Webhook)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return ErrBadWebhook
+		}
+	}
+	if c.Email != "" {
+		if a, err := mail.ParseAddress(c.Email); err != nil || a.Address != c.Email {
+			return ErrBadEmail
+		}
+	}
+	return nil
+}
+
+// Notifier delivers notifications over one channel. Implementations ignore
+// contacts that have no address for their channel.
+type Notifier interface {
+	Notify(c Contact, n Notification) error
+}
+
+// Service keeps user contacts and fans notifications out to every notifier.
+type Service struct {
+	mu        sync.Mutex
+	contacts  map[string]Contact
+	notifiers []Notifier
+}
+
+func NewService(notifiers ...Notifier) *Service {
+	return &Service{contacts: map[string]Contact{}, notifiers: notifiers}
+}
+
+// SetContact stores (or with a zero Contact removes) the addresses of user.
+func (s *Service) SetContact(user string, c Contact) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if c == (Contact{}) {
+		delete(s.contacts, user)
+	} else {
+		s.contacts[user] = c
+	}
+	return nil
+}
+
+func (s *Service) Contact(user string) Contact {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.contacts[user]
+}
+
+// Send delivers n in the background; callers may hold locks. Users without
+// a contact are skipped.
+func (s *Service) Send(n Notification) {
+	if n.User == "" {
+		return
+	}
+	go func() {
+		if err := s.Deliver(n); err != nil {
+			log.Printf("[NOTIFY] %s for %s: %v", n.Kind, n.User, err)
+		}
+	}()
+}
+
+// Deliver sends n through every notifier and returns the first error.
+func (s *Service) Deliver(n Notification) error {
+	c := s.Contact(n.User)
+	if c == (Contact{}) {
+		return nil
+	}
+	if n.At.IsZero() {
+		n.At = time.Now()
+	}
+	var first error
+	for _, nt := range s.notifiers {
+		if err := nt.Notify(c, n); err != nil && first == nil {
+			first = fmt.Errorf("%T: %w", nt, err)
+		}
+	}
+	return first
+}
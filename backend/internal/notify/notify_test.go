@@ -0,0 +1,146 @@
+package notify
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// fakeSMTP is a minimal local mail server accepting one message; the DATA
+// section is sent on the returned channel.
+func fakeSMTP(t *testing.T) (string, <-chan string) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	got := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		reply := func(s string) { conn.Write([]byte(s + "\r\n")) }
+		reply("220 localhost ready")
+		var data strings.Builder
+		inData := false
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			if inData {
+				if line == ".\r\n" {
+					inData = false
+					got <- data.String()
+					reply("250 queued")
+					continue
+				}
+				data.WriteString(line)
+				continue
+			}
+			switch cmd := strings.ToUpper(strings.TrimSpace(line)); {
+			case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+				reply("250 localhost")
+			case cmd == "DATA":
+				inData = true
+				reply("354 go ahead")
+			case cmd == "QUIT":
+				reply("221 bye")
+				return
+			default: // MAIL FROM, RCPT TO
+				reply("250 ok")
+			}
+		}
+	}()
+	return ln.Addr().String(), got
+}
+
+func TestSMTPDeliversYourTurnMail(t *testing.T) {
+	addr, got := fakeSMTP(t)
+	s := NewService(SMTP{Addr: addr, From: "games@example.com"}, Webhook{})
+	if err := s.SetContact("alice", Contact{Email: "alice@example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Deliver(Notification{User: "alice", Kind: YourTurn, GameType: "tictactoe", GameID: "g1", Text: "Your move against bob"}); err != nil {
+		t.Fatal(err)
+	}
+	msg := <-got
+	if !strings.Contains(msg, "To: alice@example.com") || !strings.Contains(msg, "Subject: gaMerZ: Your move against bob") || !strings.Contains(msg, "tictactoe g1") {
+		t.Fatalf("unexpected mail:\n%s", msg)
+	}
+}
+
+func TestWebhookPostsJSON(t *testing.T) {
+	var got Notification
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&got)
+	}))
+	defer srv.Close()
+	s := NewService(Webhook{AllowPrivate: true}, SMTP{Addr: "127.0.0.1:1"}) // no email set, SMTP is skipped
+	s.SetContact("bob", Contact{Webhook: srv.URL})
+	if err := s.Deliver(Notification{User: "bob", Kind: GameOver, GameID: "g2"}); err != nil {
+		t.Fatal(err)
+	}
+	if got.User != "bob" || got.Kind != GameOver || got.At.IsZero() {
+		t.Fatalf("unexpected payload %+v", got)
+	}
+	if err := s.Deliver(Notification{User: "carol", Kind: YourTurn}); err != nil {
+		t.Fatalf("users without contact are skipped, got %v", err)
+	}
+}
+
+func TestWebhookRefusesPrivateAddresses(t *testing.T) {
+	hit := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { hit = true }))
+	defer srv.Close()
+	s := NewService(Webhook{})
+	s.SetContact("mallory", Contact{Webhook: srv.URL})
+	if err := s.Deliver(Notification{User: "mallory", Kind: YourTurn}); !errors.Is(err, ErrPrivateAddress) || hit {
+		t.Fatalf("a loopback webhook must be refused, got %v (reached: %v)", err, hit)
+	}
+	for ip, public := range map[string]bool{
+		"127.0.0.1": false, "10.1.2.3": false, "192.168.0.1": false, "169.254.169.254": false, "100.64.0.1": false,
+		"0.0.0.0": false, "::1": false, "fe80::1": false, "fd00::1": false, "::ffff:127.0.0.1": false,
+		"8.8.8.8": true, "2606:4700::1111": true,
+	} {
+		if publicIP(net.ParseIP(ip)) != public {
+			t.Fatalf("publicIP(%s) should be %v", ip, public)
+		}
+	}
+}
+
+func TestWebhookDoesNotFollowRedirects(t *testing.T) {
+	followed := false
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { followed = true }))
+	defer target.Close()
+	srv := httptest.NewServer(http.RedirectHandler(target.URL, http.StatusTemporaryRedirect))
+	defer srv.Close()
+	s := NewService(Webhook{AllowPrivate: true})
+	s.SetContact("bob", Contact{Webhook: srv.URL})
+	if err := s.Deliver(Notification{User: "bob", Kind: YourTurn}); err == nil || followed {
+		t.Fatalf("redirects must not be followed, got %v (followed: %v)", err, followed)
+	}
+}
+
+func TestContactValidation(t *testing.T) {
+	s := NewService()
+	for _, c := range []Contact{{Webhook: "ftp://x"}, {Webhook: "/relative"}, {Email: "nope"}, {Email: "Bob <b@x.io>"}} {
+		if err := s.SetContact("u", c); err == nil {
+			t.Fatalf("expected %+v to be rejected", c)
+		}
+	}
+	s.SetContact("u", Contact{Email: "u@x.io"})
+	s.SetContact("u", Contact{})
+	if s.Contact("u") != (Contact{}) {
+		t.Fatal("empty contact should remove the user")
+	}
+}
@@ -0,0 +1,111 @@
+package rating
+
+import "math"
+
+// Glicko-2 implementation following Glickman's "Example of the Glicko-2 system".
+// Ratings are stored on the familiar Glicko scale (1500 / 350) and converted
+// to the internal Glicko-2 scale only while updating.
+
+const (
+	DefaultRating     = 1500.0
+	DefaultDeviation  = 350.0
+	DefaultVolatility = 0.06
+
+	// Tau constrains how fast volatility may change; 0.3..1.2 is sensible.
+	Tau = 0.5
+
+	glickoScale = 173.7178
+	convergence = 0.000001
+)
+
+// Rating is a player's Glicko-2 skill estimate.
+type Rating struct {
+	Rating     float64 `json:"rating"`
+	Deviation  float64 `json:"deviation"`
+	Volatility float64 `json:"volatility"`
+}
+
+// Outcome is one game played during a rating period. Score is 1 for a win,
+// 0.5 for a draw and 0 for a loss.
+type Outcome struct {
+	Opponent Rating
+	Score    float64
+}
+
+// NewRating returns the starting estimate for an unknown player.
+func NewRating() Rating {
+	return Rating{Rating: DefaultRating, Deviation: DefaultDeviation, Volatility: DefaultVolatility}
+}
+
+func g(phi float64) float64 { return 1 / math.Sqrt(1+3*phi*phi/(math.Pi*math.Pi)) }
+
+func expected(mu, muJ, phiJ float64) float64 { return 1 / (1 + math.Exp(-g(phiJ)*(mu-muJ))) }
+
+// Update returns the rating after one rating period containing results.
+// An empty period only inflates the deviation.
+func (r Rating) Update(results []Outcome) Rating {
+	mu := (r.Rating - DefaultRating) / glickoScale
+	phi := r.Deviation / glickoScale
+	sigma := r.Volatility
+
+	if len(results) == 0 {
+		phiStar := math.Sqrt(phi*phi + sigma*sigma)
+		return Rating{Rating: r.Rating, Deviation: math.Min(phiStar*glickoScale, DefaultDeviation), Volatility: sigma}
+	}
+
+	var vInv, deltaSum float64
+	for _, o := range results {
+		muJ := (o.Opponent.Rating - DefaultRating) / glickoScale
+		phiJ := o.Opponent.Deviation / glickoScale
+		gJ := g(phiJ)
+		e := expected(mu, muJ, phiJ)
+		vInv += gJ * gJ * e * (1 - e)
+		deltaSum += gJ * (o.Score - e)
+	}
+	v := 1 / vInv
+	delta := v * deltaSum
+
+	sigmaPrime := newVolatility(phi, sigma, v, delta)
+	phiStar := math.Sqrt(phi*phi + sigmaPrime*sigmaPrime)
+	phiPrime := 1 / math.Sqrt(1/(phiStar*phiStar)+1/v)
+	muPrime := mu + phiPrime*phiPrime*deltaSum
+
+	return Rating{
+		Rating:     muPrime*glickoScale + DefaultRating,
+		Deviation:  phiPrime * glickoScale,
+		Volatility: sigmaPrime,
+	}
+}
+
+// newVolatility solves for sigma' with the Illinois variant of regula falsi (step 5).
+func newVolatility(phi, sigma, v, delta float64) float64 {
+	a := math.Log(sigma * sigma)
+	f := func(x float64) float64 {
+		ex := math.Exp(x)
+		d := phi*phi + v + ex
+		return ex*(delta*delta-phi*phi-v-ex)/(2*d*d) - (x-a)/(Tau*Tau)
+	}
+	A := a
+	var B float64
+	if delta*delta > phi*phi+v {
+		B = math.Log(delta*delta - phi*phi - v)
+	} else {
+		k := 1.0
+		for f(a-k*Tau) < 0 {
+			k++
+		}
+		B = a - k*Tau
+	}
+	fA, fB := f(A), f(B)
+	for math.Abs(B-A) > convergence {
+		C := A + (A-B)*fA/(fB-fA)
+		fC := f(C)
+		if fC*fB <= 0 {
+			A, fA = B, fB
+		} else {
+			fA /= 2
+		}
+		B, fB = C, fC
+	}
+	return math.Exp(A / 2)
+}
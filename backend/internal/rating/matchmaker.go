@@ -0,0 +1,126 @@
+package rating
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Window controls how far apart two queued players may be rated. The
+// acceptable gap starts at Base and grows by PerSecond for every second a
+// player has waited, up to Max.
+type Window struct {
+	Base      float64
+	PerSecond float64
+	Max       float64
+}
+
+// DefaultWindow pairs close ratings first and accepts anyone after ~1 minute.
+var DefaultWindow = Window{Base: 100, PerSecond: 10, Max: 700}
+
+// Width returns the acceptable rating gap after waiting for d.
+func (w Window) Width(d time.Duration) float64 {
+	return math.Min(w.Base+w.PerSecond*d.Seconds(), w.Max)
+}
+
+// Ticket is a queued matchmaking request.
+type Ticket struct {
+	User     string    `json:"user"`
+	Rating   float64   `json:"rating"`
+	JoinedAt time.Time `json:"joinedAt"`
+}
+
+// Pair is two tickets matched together; A waited longest.
+type Pair struct {
+	A, B Ticket
+}
+
+// Matchmaker is a rating aware queue for one game type.
+type Matchmaker struct {
+	mu     sync.Mutex
+	window Window
+	queue  []Ticket
+}
+
+func NewMatchmaker(w Window) *Matchmaker { return &Matchmaker{window: w} }
+
+// Join queues user; joining twice refreshes the rating but keeps the wait time.
+func (m *Matchmaker) Join(user string, rating float64, now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := range m.queue {
+		if m.queue[i].User == user {
+			m.queue[i].Rating = rating
+			return
+		}
+	}
+	m.queue = append(m.queue, Ticket{User: user, Rating: rating, JoinedAt: now})
+}
+
+// Leave removes user from the queue and reports whether they were queued.
+func (m *Matchmaker) Leave(user string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, t := range m.queue {
+		if t.User == user {
+			m.queue = append(m.queue[:i], m.queue[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Waiting returns the ticket for user if still queued.
+func (m *Matchmaker) Waiting(user string) (Ticket, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, t := range m.queue {
+		if t.User == user {
+			return t, true
+		}
+	}
+	return Ticket{}, false
+}
+
+// Pair matches queued players whose rating gap fits inside both players'
+// current windows. Longest waiting players are served first and get the
+// closest acceptable opponent. Matched tickets leave the queue.
+func (m *Matchmaker) Pair(now time.Time) []Pair {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	sort.SliceStable(m.queue, func(i, j int) bool { return m.queue[i].JoinedAt.Before(m.queue[j].JoinedAt) })
+	used := make([]bool, len(m.queue))
+	var pairs []Pair
+	for i, a := range m.queue {
+		if used[i] {
+			continue
+		}
+		best, bestGap := -1, math.Inf(1)
+		for j := i + 1; j < len(m.queue); j++ {
+			if used[j] {
+				continue
+			}
+			b := m.queue[j]
+			gap := math.Abs(a.Rating - b.Rating)
+			if gap > m.window.Width(now.Sub(a.JoinedAt)) || gap > m.window.Width(now.Sub(b.JoinedAt)) {
+				continue
+			}
+			if gap < bestGap {
+				best, bestGap = j, gap
+			}
+		}
+		if best >= 0 {
+			used[i], used[best] = true, true
+			pairs = append(pairs, Pair{A: a, B: m.queue[best]})
+		}
+	}
+	rest := m.queue[:0]
+	for i, t := range m.queue {
+		if !used[i] {
+			rest = append(rest, t)
+		}
+	}
+	m.queue = rest
+	return pairs
+}
@@ -0,0 +1,64 @@
+package rating
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func near(a, b, eps float64) bool { return math.Abs(a-b) <= eps }
+
+// Worked example from Glickman's Glicko-2 paper.
+func TestGlicko2PaperExample(t *testing.T) {
+	r := Rating{Rating: 1500, Deviation: 200, Volatility: 0.06}
+	got := r.Update([]Outcome{
+		{Opponent: Rating{Rating: 1400, Deviation: 30}, Score: 1},
+		{Opponent: Rating{Rating: 1550, Deviation: 100}, Score: 0},
+		{Opponent: Rating{Rating: 1700, Deviation: 300}, Score: 0},
+	})
+	if !near(got.Rating, 1464.06, 0.01) || !near(got.Deviation, 151.52, 0.01) || !near(got.Volatility, 0.05999, 0.00001) {
+		t.Fatalf("unexpected update %+v", got)
+	}
+}
+
+func TestStoreRecordMatchAndProvisional(t *testing.T) {
+	s := NewStore()
+	a, b := s.RecordMatch("tictactoe", "g1", "alice", "bob", 1)
+	if a.Rating.Rating <= DefaultRating || b.Rating.Rating >= DefaultRating {
+		t.Fatalf("winner should gain, loser should drop: %v %v", a.Rating, b.Rating)
+	}
+	if !a.Provisional {
+		t.Fatal("player with one game should be provisional")
+	}
+	for i := 0; i < 30; i++ {
+		s.RecordMatch("tictactoe", "g", "alice", "bob", 0.5)
+	}
+	if s.Get("tictactoe", "alice").Provisional {
+		t.Fatalf("expected established rating, got %+v", s.Get("tictactoe", "alice"))
+	}
+	if h := s.History("tictactoe", "bob"); len(h) != 31 || h[0].Opponent != "alice" {
+		t.Fatalf("unexpected history len=%d", len(h))
+	}
+}
+
+func TestMatchmakerWideningWindow(t *testing.T) {
+	m := NewMatchmaker(Window{Base: 100, PerSecond: 10, Max: 500})
+	t0 := time.Now()
+	m.Join("low", 1200, t0)
+	m.Join("high", 1500, t0)
+	if p := m.Pair(t0); len(p) != 0 {
+		t.Fatalf("300 gap should not pair immediately: %+v", p)
+	}
+	m.Join("mid", 1520, t0)
+	p := m.Pair(t0.Add(time.Second))
+	if len(p) != 1 || p[0].A.User != "high" || p[0].B.User != "mid" {
+		t.Fatalf("expected high vs mid, got %+v", p)
+	}
+	m.Join("far", 1450, t0.Add(time.Second))
+	if p := m.Pair(t0.Add(10 * time.Second)); len(p) != 0 {
+		t.Fatalf("newcomer's window is still narrow: %+v", p)
+	}
+	if p := m.Pair(t0.Add(30 * time.Second)); len(p) != 1 {
+		t.Fatalf("windows should have widened enough to pair: %+v", p)
+	}
+}
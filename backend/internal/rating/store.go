@@ -0,0 +1,109 @@
+package rating
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Players stay provisional until their deviation drops below ProvisionalDeviation
+// and they have completed ProvisionalGames rated games.
+const (
+	ProvisionalDeviation = 110.0
+	ProvisionalGames     = 5
+)
+
+// HistoryEntry records a rating change caused by one rated match.
+type HistoryEntry struct {
+	GameID   string    `json:"gameId"`
+	Opponent string    `json:"opponent"`
+	Score    float64   `json:"score"`
+	Before   Rating    `json:"before"`
+	After    Rating    `json:"after"`
+	At       time.Time `json:"at"`
+}
+
+// Player is a user's rating for one game type.
+type Player struct {
+	User     string `json:"user"`
+	GameType string `json:"gameType"`
+	Rating
+	Games       int            `json:"games"`
+	Provisional bool           `json:"provisional"`
+	History     []HistoryEntry `json:"-"`
+}
+
+type key struct{ game, user string }
+
+// Store keeps ratings in memory, one per (game type, user).
+type Store struct {
+	mu      sync.Mutex
+	players map[key]*Player
+	now     func() time.Time
+}
+
+func NewStore() *Store { return &Store{players: map[key]*Player{}, now: time.Now} }
+
+func (s *Store) get(game, user string) *Player {
+	k := key{game, user}
+	p, ok := s.players[k]
+	if !ok {
+		p = &Player{User: user, GameType: game, Rating: NewRating(), Provisional: true}
+		s.players[k] = p
+	}
+	return p
+}
+
+// Get returns the rating of user for game, creating a default entry if needed.
+func (s *Store) Get(game, user string) Player {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return *s.get(game, user)
+}
+
+// All returns every rating held by user, sorted by game type.
+func (s *Store) All(user string) []Player {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := []Player{}
+	for k, p := range s.players {
+		if k.user == user {
+			out = append(out, *p)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].GameType < out[j].GameType })
+	return out
+}
+
+// History returns the rating changes of user for game, oldest first.
+func (s *Store) History(game, user string) []HistoryEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, ok := s.players[key{game, user}]
+	if !ok {
+		return []HistoryEntry{}
+	}
+	return append([]HistoryEntry{}, p.History...)
+}
+
+// RecordMatch applies a finished rated match between a and b. scoreA is a's
+// score (1 win, 0.5 draw, 0 loss). Each match is treated as its own rating
+// period so both sides are updated from their pre-match ratings.
+func (s *Store) RecordMatch(game, gameID, a, b string, scoreA float64) (Player, Player) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	pa, pb := s.get(game, a), s.get(game, b)
+	ra, rb := pa.Rating, pb.Rating
+	now := s.now()
+	s.apply(pa, gameID, b, scoreA, ra, rb, now)
+	s.apply(pb, gameID, a, 1-scoreA, rb, ra, now)
+	return *pa, *pb
+}
+
+func (s *Store) apply(p *Player, gameID, opponent string, score float64, before, opp Rating, at time.Time) {
+	after := before.Update([]Outcome{{Opponent: opp, Score: score}})
+	p.Rating = after
+	p.Games++
+	p.Provisional = p.Games < ProvisionalGames || p.Deviation > ProvisionalDeviation
+	p.History = append(p.History, HistoryEntry{GameID: gameID, Opponent: opponent, Score: score, Before: before, After: after, At: at})
+}
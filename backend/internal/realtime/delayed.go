@@ -0,0 +1,111 @@
+package realtime
+
+import (
+	"sync"
+	"time"
+)
+
+type stamped struct {
+	at  time.Time
+	msg Message
+}
+
+// Delayed publishes on a Hub but hands messages to its own subscribers only
+// after a fixed delay. Each message is treated as a full snapshot: a new
+// subscriber starts from the latest one that is at least delay old, followed
+// by the newer ones as they come due.
+type Delayed struct {
+	hub   *Hub
+	delay time.Duration
+
+	mu      sync.Mutex
+	history map[string][]stamped
+}
+
+func NewDelayed(hub *Hub, delay time.Duration) *Delayed {
+	return &Delayed{hub: hub, delay: delay, history: map[string][]stamped{}}
+}
+
+// Delay returns the configured delay.
+func (d *Delayed) Delay() time.Duration { return d.delay }
+
+// Publish records m and sends it to the subscribers of topic.
+func (d *Delayed) Publish(topic string, m Message) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	now := time.Now()
+	h := append(d.history[topic], stamped{at: now, msg: m})
+	// keep one snapshot older than the delay window as a starting point
+	cutoff := now.Add(-d.delay)
+	for len(h) > 1 && !h[1].at.After(cutoff) {
+		h = h[1:]
+	}
+	d.history[topic] = h
+	d.hub.Publish(topic, m)
+}
+
+// Latest returns the newest message of topic that is at least delay old,
+// which is what a subscriber joining now starts from.
+func (d *Delayed) Latest(topic string) (Message, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	cutoff := time.Now().Add(-d.delay)
+	h := d.history[topic]
+	for i := len(h) - 1; i >= 0; i-- {
+		if !h[i].at.After(cutoff) {
+			return h[i].msg, true
+		}
+	}
+	return Message{}, false
+}
+
+// Forget drops the history of a topic that will not be published again.
+// Current subscribers keep what they have queued.
+func (d *Delayed) Forget(topic string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.history, topic)
+}
+
+// Subscribe returns a channel receiving the messages of topic delay late and a
+// cancel func that must be called once the subscriber goes away.
+func (d *Delayed) Subscribe(topic string) (<-chan Message, func()) {
+	d.mu.Lock()
+	in, cancel := d.hub.Subscribe(topic)
+	var queue []stamped
+	cutoff := time.Now().Add(-d.delay)
+	for i, s := range d.history[topic] {
+		if !s.at.After(cutoff) && i+1 < len(d.history[topic]) && !d.history[topic][i+1].at.After(cutoff) {
+			continue // superseded before the window
+		}
+		queue = append(queue, s)
+	}
+	d.mu.Unlock()
+	out := make(chan Message, subscriberBuffer)
+	go func() {
+		defer close(out)
+		for {
+			var due <-chan time.Time
+			if len(queue) > 0 {
+				due = time.After(time.Until(queue[0].at.Add(d.delay)))
+			}
+			select {
+			case m, ok := <-in:
+				if !ok {
+					return
+				}
+				queue = append(queue, stamped{at: time.Now(), msg: m})
+			case <-due:
+				select {
+				case out <- queue[0].msg:
+				default: // slow subscriber, drop
+				}
+				queue = queue[1:]
+			}
+		}
+	}()
+	return out, cancel
+}
+
+// Subscribers returns how many clients currently listen on topic.
+func (d *Delayed) Subscribers(topic string) int { return d.hub.Subscribers(topic) }
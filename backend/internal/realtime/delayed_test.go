@@ -0,0 +1,85 @@
+package realtime
+
+import (
+	"testing"
+	"time"
+)
+
+func recv(t *testing.T, ch <-chan Message, within time.Duration) (Message, bool) {
+	t.Helper()
+	select {
+	case m := <-ch:
+		return m, true
+	case <-time.After(within):
+		return Message{}, false
+	}
+}
+
+func TestDelayedHoldsMessagesBack(t *testing.T) {
+	d := NewDelayed(NewHub(), 80*time.Millisecond)
+	ch, cancel := d.Subscribe("t")
+	defer cancel()
+	d.Publish("t", Message{Event: "state", Data: 1})
+	if _, ok := recv(t, ch, 40*time.Millisecond); ok {
+		t.Fatal("message delivered before the delay")
+	}
+	if m, ok := recv(t, ch, 200*time.Millisecond); !ok || m.Data != 1 {
+		t.Fatalf("expected delayed message, got %+v %v", m, ok)
+	}
+}
+
+func TestDelayedNewSubscriberStartsFromDelayedSnapshot(t *testing.T) {
+	d := NewDelayed(NewHub(), 60*time.Millisecond)
+	d.Publish("t", Message{Data: 1})
+	d.Publish("t", Message{Data: 2})
+	time.Sleep(90 * time.Millisecond)
+	d.Publish("t", Message{Data: 3}) // still inside the window
+	ch, cancel := d.Subscribe("t")
+	defer cancel()
+	if m, ok := recv(t, ch, 20*time.Millisecond); !ok || m.Data != 2 {
+		t.Fatalf("expected the last snapshot older than the delay, got %+v %v", m, ok)
+	}
+	if _, ok := recv(t, ch, 20*time.Millisecond); ok {
+		t.Fatal("recent snapshot leaked before its delay")
+	}
+	if m, ok := recv(t, ch, 200*time.Millisecond); !ok || m.Data != 3 {
+		t.Fatalf("expected the recent snapshot once due, got %+v %v", m, ok)
+	}
+	if d.Subscribers("t") != 1 {
+		t.Fatalf("expected one subscriber, got %d", d.Subscribers("t"))
+	}
+}
+
+func TestDelayedWithoutDelayReplaysLatest(t *testing.T) {
+	d := NewDelayed(NewHub(), 0)
+	d.Publish("t", Message{Data: 1})
+	d.Publish("t", Message{Data: 2})
+	ch, cancel := d.Subscribe("t")
+	if m, ok := recv(t, ch, 50*time.Millisecond); !ok || m.Data != 2 {
+		t.Fatalf("expected latest snapshot, got %+v %v", m, ok)
+	}
+	cancel()
+	if _, ok := <-ch; ok {
+		t.Fatal("channel should close after cancel")
+	}
+}
+
+func TestDelayedLatestAndForget(t *testing.T) {
+	d := NewDelayed(NewHub(), 60*time.Millisecond)
+	if _, ok := d.Latest("t"); ok {
+		t.Fatal("nothing published yet")
+	}
+	d.Publish("t", Message{Data: 1})
+	if _, ok := d.Latest("t"); ok {
+		t.Fatal("a fresh snapshot must not be handed out before its delay")
+	}
+	time.Sleep(90 * time.Millisecond)
+	d.Publish("t", Message{Data: 2})
+	if m, ok := d.Latest("t"); !ok || m.Data != 1 {
+		t.Fatalf("expected the snapshot older than the delay, got %+v %v", m, ok)
+	}
+	d.Forget("t")
+	if _, ok := d.Latest("t"); ok || len(d.history) != 0 {
+		t.Fatalf("history kept after Forget: %v", d.history)
+	}
+}
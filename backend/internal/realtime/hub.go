@@ -0,0 +1,117 @@
+package realtime
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Message is one server push. Event becomes the SSE event name.
+type Message struct {
+	Event string `json:"event"`
+	Data  any    `json:"data"`
+}
+
+// subscriberBuffer is how many messages a slow client may lag behind before
+// further messages to it are dropped.
+const subscriberBuffer = 32
+
+// Hub fans messages out to subscribers of named topics ("lobby", "room:ab12"...).
+type Hub struct {
+	mu   sync.Mutex
+	subs map[string]map[chan Message]struct{}
+}
+
+func NewHub() *Hub { return &Hub{subs: map[string]map[chan Message]struct{}{}} }
+
+// Subscribe returns a channel receiving messages for topic and a cancel func
+// that must be called once the subscriber goes away.
+func (h *Hub) Subscribe(topic string) (<-chan Message, func()) {
+	ch := make(chan Message, subscriberBuffer)
+	h.mu.Lock()
+	if h.subs[topic] == nil {
+		h.subs[topic] = map[chan Message]struct{}{}
+	}
+	h.subs[topic][ch] = struct{}{}
+	h.mu.Unlock()
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			h.mu.Lock()
+			delete(h.subs[topic], ch)
+			if len(h.subs[topic]) == 0 {
+				delete(h.subs, topic)
+			}
+			h.mu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+// Publish sends m to every current subscriber of topic without blocking.
+func (h *Hub) Publish(topic string, m Message) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.subs[topic] {
+		select {
+		case ch <- m:
+		default: // slow subscriber, drop
+		}
+	}
+}
+
+// Subscribers returns how many clients currently listen on topic.
+func (h *Hub) Subscribers(topic string) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.subs[topic])
+}
+
+// keepAlive is how often an SSE comment is written so proxies keep the
+// connection open.
+const keepAlive = 25 * time.Second
+
+// ServeSSE streams messages from ch to the client as Server-Sent Events until
+// the request is cancelled or ch is closed. initial messages are sent first.
+func ServeSSE(w http.ResponseWriter, r *http.Request, ch <-chan Message, initial ...Message) {
+	fl, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	for _, m := range initial {
+		writeEvent(w, m)
+	}
+	fl.Flush()
+	tick := time.NewTicker(keepAlive)
+	defer tick.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-tick.C:
+			fmt.Fprint(w, ": ping\n\n")
+			fl.Flush()
+		case m, ok := <-ch:
+			if !ok {
+				return
+			}
+			writeEvent(w, m)
+			fl.Flush()
+		}
+	}
+}
+
+func writeEvent(w http.ResponseWriter, m Message) {
+	data, err := json.Marshal(m.Data)
+	if err != nil {
+		data = []byte("null")
+	}
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", m.Event, data)
+}
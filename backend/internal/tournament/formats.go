@@ -0,0 +1,307 @@
+package tournament
+
+import (
+	"math"
+	"sort"
+)
+
+// seedOrder returns bracket positions for seeds 1..size so that the top
+// seeds meet as late as possible and byes (seeds > players) go to them.
+func seedOrder(size int) []int {
+	order := []int{1}
+	for n := 1; n < size; n *= 2 {
+		next := make([]int, 0, n*2)
+		for _, s := range order {
+			next = append(next, s, 2*n+1-s)
+		}
+		order = next
+	}
+	return order
+}
+
+func bracketSize(players int) int {
+	size := 1
+	for size < players {
+		size *= 2
+	}
+	return size
+}
+
+// buildWinners lays out a full elimination bracket on bracket and returns
+// the match ids of each round (index 0 = first round).
+func (t *Tournament) buildWinners(bracket string) [][]int {
+	size := bracketSize(len(t.Players))
+	seat := func(seed int) string {
+		if seed > len(t.Players) {
+			return ""
+		}
+		return t.Players[seed-1]
+	}
+	order := seedOrder(size)
+	var rounds [][]int
+	var first []int
+	for i := 0; i < size; i += 2 {
+		first = append(first, t.addMatch(bracket, 1, seat(order[i]), seat(order[i+1])).ID)
+	}
+	rounds = append(rounds, first)
+	for r := 2; len(rounds[len(rounds)-1]) > 1; r++ {
+		prev := rounds[len(rounds)-1]
+		var cur []int
+		for i := 0; i < len(prev); i += 2 {
+			cur = append(cur, t.addFed(bracket, r, &source{match: prev[i]}, &source{match: prev[i+1]}).ID)
+		}
+		rounds = append(rounds, cur)
+	}
+	return rounds
+}
+
+func (t *Tournament) buildSingle() { t.buildWinners(MainBracket) }
+
+// buildDouble adds a losers bracket where every winners round drops its
+// losers in against the losers bracket survivors, followed by a grand final.
+// A grand final reset is added later if the losers bracket champion wins.
+func (t *Tournament) buildDouble() {
+	wb := t.buildWinners(WinnersBracket)
+	wbFinal := wb[len(wb)-1][0]
+	var survivors []*source
+	lr := 0
+	if len(wb) == 1 { // two players: the loser goes straight to the grand final
+		survivors = []*source{{match: wbFinal, loser: true}}
+	} else {
+		lr++
+		for i := 0; i < len(wb[0]); i += 2 {
+			m := t.addFed(LosersBracket, lr, &source{match: wb[0][i], loser: true}, &source{match: wb[0][i+1], loser: true})
+			survivors = append(survivors, &source{match: m.ID})
+		}
+		for r := 1; r < len(wb); r++ {
+			lr++
+			drops := wb[r]
+			var next []*source
+			for i, s := range survivors {
+				// alternate the drop order to postpone rematches from the winners bracket
+				d := drops[i]
+				if r%2 == 1 {
+					d = drops[len(drops)-1-i]
+				}
+				m := t.addFed(LosersBracket, lr, s, &source{match: d, loser: true})
+				next = append(next, &source{match: m.ID})
+			}
+			survivors = next
+			if len(survivors) > 1 {
+				lr++
+				next = nil
+				for i := 0; i < len(survivors); i += 2 {
+					m := t.addFed(LosersBracket, lr, survivors[i], survivors[i+1])
+					next = append(next, &source{match: m.ID})
+				}
+				survivors = next
+			}
+		}
+	}
+	t.addFed(FinalBracket, 1, &source{match: wbFinal}, survivors[0])
+}
+
+// afterFinal adds the grand final reset when the losers bracket champion
+// (slot B) wins the first grand final, since that is their opponent's
+// first loss.
+func (t *Tournament) afterFinal(m *Match) {
+	if t.Format == DoubleElimination && m.Bracket == FinalBracket && m.Round == 1 && m.Winner == m.B {
+		t.addMatch(FinalBracket, 2, m.A, m.B)
+	}
+}
+
+// buildRoundRobin schedules every pairing with the circle method.
+func (t *Tournament) buildRoundRobin() {
+	ps := append([]string{}, t.Players...)
+	if len(ps)%2 == 1 {
+		ps = append(ps, "") // sitting out
+	}
+	n := len(ps)
+	t.schedule = nil
+	for r := 0; r < n-1; r++ {
+		var round [][2]string
+		for i := 0; i < n/2; i++ {
+			a, b := ps[i], ps[n-1-i]
+			if r%2 == 1 && i == 0 {
+				a, b = b, a // alternate who takes the first seat
+			}
+			if a != "" && b != "" {
+				round = append(round, [2]string{a, b})
+			}
+		}
+		t.schedule = append(t.schedule, round)
+		// rotate all but the first player
+		ps = append([]string{ps[0], ps[n-1]}, ps[1:n-1]...)
+	}
+	t.Rounds = len(t.schedule)
+}
+
+func swissRounds(players int) int {
+	return int(math.Ceil(math.Log2(float64(players))))
+}
+
+// pairSwiss pairs the next round: players ordered by standing, the lowest
+// ranked player without a bye sits out if the count is odd, and a
+// backtracking search avoids rematches whenever possible.
+func (t *Tournament) pairSwiss() [][2]string {
+	table := t.standings()
+	var order []string
+	for _, s := range table {
+		order = append(order, s.User)
+	}
+	met := map[[2]string]bool{}
+	hadBye := map[string]bool{}
+	for _, m := range t.Matches {
+		if m.Bye {
+			hadBye[m.Winner] = true
+			continue
+		}
+		met[[2]string{m.A, m.B}], met[[2]string{m.B, m.A}] = true, true
+	}
+	var pairs [][2]string
+	if len(order)%2 == 1 {
+		bye := len(order) - 1
+		for i := len(order) - 1; i >= 0; i-- {
+			if !hadBye[order[i]] {
+				bye = i
+				break
+			}
+		}
+		pairs = append(pairs, [2]string{order[bye], ""})
+		order = append(order[:bye:bye], order[bye+1:]...)
+	}
+	if found := pairUp(order, met); found != nil {
+		return append(pairs, found...)
+	}
+	for i := 0; i < len(order); i += 2 { // everyone already met: fall back to plain order
+		pairs = append(pairs, [2]string{order[i], order[i+1]})
+	}
+	return pairs
+}
+
+func pairUp(order []string, met map[[2]string]bool) [][2]string {
+	if len(order) == 0 {
+		return [][2]string{}
+	}
+	a := order[0]
+	for i := 1; i < len(order); i++ {
+		b := order[i]
+		if met[[2]string{a, b}] {
+			continue
+		}
+		rest := make([]string, 0, len(order)-2)
+		rest = append(rest, order[1:i]...)
+		rest = append(rest, order[i+1:]...)
+		if tail := pairUp(rest, met); tail != nil {
+			return append([][2]string{{a, b}}, tail...)
+		}
+	}
+	return nil
+}
+
+// startRound creates the matches of round r for round robin and swiss.
+func (t *Tournament) startRound(r int) {
+	t.Round = r
+	var pairs [][2]string
+	if t.Format == RoundRobin {
+		pairs = t.schedule[r-1]
+	} else {
+		pairs = t.pairSwiss()
+	}
+	for _, p := range pairs {
+		t.addMatch(MainBracket, r, p[0], p[1])
+	}
+}
+
+// Standing is one line of the standings table. Points: win 1, draw 0.5,
+// swiss bye 1. Buchholz is the sum of opponents' points.
+type Standing struct {
+	Rank       int     `json:"rank"`
+	User       string  `json:"user"`
+	Played     int     `json:"played"`
+	Wins       int     `json:"wins"`
+	Draws      int     `json:"draws"`
+	Losses     int     `json:"losses"`
+	Byes       int     `json:"byes"`
+	Points     float64 `json:"points"`
+	Buchholz   float64 `json:"buchholz"`
+	Eliminated bool    `json:"eliminated,omitempty"`
+}
+
+func (t *Tournament) standings() []Standing {
+	idx := map[string]*Standing{}
+	seed := map[string]int{}
+	for i, p := range t.Players {
+		idx[p] = &Standing{User: p}
+		seed[p] = i
+	}
+	opponents := map[string][]string{}
+	for _, m := range t.Matches {
+		if m.Status != Done {
+			continue
+		}
+		if m.Bye {
+			if s := idx[m.Winner]; s != nil && t.Format == Swiss {
+				s.Byes++
+				s.Points++
+			}
+			continue
+		}
+		a, b := idx[m.A], idx[m.B]
+		a.Played++
+		b.Played++
+		opponents[m.A] = append(opponents[m.A], m.B)
+		opponents[m.B] = append(opponents[m.B], m.A)
+		switch {
+		case m.Draw:
+			a.Draws++
+			b.Draws++
+			a.Points += 0.5
+			b.Points += 0.5
+		default:
+			w, l := idx[m.Winner], idx[m.Loser]
+			w.Wins++
+			w.Points++
+			l.Losses++
+		}
+	}
+	lives := 0
+	switch t.Format {
+	case SingleElimination:
+		lives = 1
+	case DoubleElimination:
+		lives = 2
+	}
+	out := make([]Standing, 0, len(idx))
+	for _, p := range t.Players {
+		s := idx[p]
+		for _, o := range opponents[p] {
+			s.Buchholz += idx[o].Points
+		}
+		s.Eliminated = lives > 0 && s.Losses >= lives && p != t.Winner
+		out = append(out, *s)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if (a.User == t.Winner) != (b.User == t.Winner) {
+			return a.User == t.Winner
+		}
+		if a.Eliminated != b.Eliminated {
+			return !a.Eliminated
+		}
+		if a.Points != b.Points {
+			return a.Points > b.Points
+		}
+		if a.Losses != b.Losses {
+			return a.Losses < b.Losses
+		}
+		if a.Buchholz != b.Buchholz {
+			return a.Buchholz > b.Buchholz
+		}
+		return seed[a.User] < seed[b.User]
+	})
+	for i := range out {
+		out[i].Rank = i + 1
+	}
+	return out
+}
@@ -0,0 +1,268 @@
+package tournament
+
+import (
+	"log"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Starter creates the game for a match between a (first seat) and b and
+// returns its id.
+type Starter func(game, a, b string, rated bool) (string, error)
+
+type matchRef struct {
+	tournament string
+	match      int
+}
+
+// Manager holds tournaments in memory and drives them as results arrive.
+type Manager struct {
+	mu     sync.Mutex
+	tours  map[string]*Tournament
+	byGame map[string]matchRef
+	games  map[string]bool // game types that can be played in tournaments
+	start  Starter
+	notify func(Tournament)
+}
+
+// NewManager creates a manager for the given two player game types. notify
+// (optional) is called with a snapshot after every change.
+func NewManager(games []string, start Starter, notify func(Tournament)) *Manager {
+	m := &Manager{tours: map[string]*Tournament{}, byGame: map[string]matchRef{}, games: map[string]bool{}, start: start, notify: notify}
+	for _, g := range games {
+		m.games[g] = true
+	}
+	return m
+}
+
+// View is a tournament together with its current standings.
+type View struct {
+	Tournament
+	Standings []Standing `json:"standings"`
+}
+
+func view(t *Tournament) View { return View{Tournament: t.snapshot(), Standings: t.standings()} }
+
+// Create opens a tournament for registration. rounds only applies to swiss
+// (0 picks log2 of the field).
+func (mg *Manager) Create(organizer, name, game string, format Format, rated bool, rounds int) (View, error) {
+	if organizer == "" {
+		return View{}, ErrMissingUser
+	}
+	if !mg.games[game] {
+		return View{}, ErrUnknownGame
+	}
+	if !validFormat(format) {
+		return View{}, ErrBadFormat
+	}
+	t := &Tournament{ID: newID(), Name: name, Game: game, Format: format, Organizer: organizer, Rated: rated, Players: []string{}, Status: Registering, CreatedAt: time.Now()}
+	if format == Swiss {
+		t.Rounds = rounds
+	}
+	mg.mu.Lock()
+	mg.tours[t.ID] = t
+	v := view(t)
+	mg.mu.Unlock()
+	mg.emit(v.Tournament)
+	return v, nil
+}
+
+// List returns every tournament, newest first.
+func (mg *Manager) List() []View {
+	mg.mu.Lock()
+	defer mg.mu.Unlock()
+	out := []View{}
+	for _, t := range mg.tours {
+		out = append(out, view(t))
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.After(out[j].CreatedAt) })
+	return out
+}
+
+// Get returns a tournament with its bracket and standings.
+func (mg *Manager) Get(id string) (View, error) {
+	mg.mu.Lock()
+	defer mg.mu.Unlock()
+	t, ok := mg.tours[id]
+	if !ok {
+		return View{}, ErrNotFound
+	}
+	return view(t), nil
+}
+
+// Join registers user; registration order is the seeding.
+func (mg *Manager) Join(id, user string) (View, error) {
+	return mg.update(id, func(t *Tournament) error {
+		if user == "" {
+			return ErrMissingUser
+		}
+		if t.Status != Registering {
+			return ErrNotRegistering
+		}
+		for _, p := range t.Players {
+			if p == user {
+				return ErrAlreadyJoined
+			}
+		}
+		t.Players = append(t.Players, user)
+		return nil
+	})
+}
+
+// Leave withdraws user before the tournament starts.
+func (mg *Manager) Leave(id, user string) (View, error) {
+	return mg.update(id, func(t *Tournament) error {
+		if t.Status != Registering {
+			return ErrNotRegistering
+		}
+		for i, p := range t.Players {
+			if p == user {
+				t.Players = append(t.Players[:i], t.Players[i+1:]...)
+				return nil
+			}
+		}
+		return ErrNotJoined
+	})
+}
+
+// Start builds the bracket or first round and creates the first games.
+func (mg *Manager) Start(id, user string) (View, error) {
+	return mg.update(id, func(t *Tournament) error {
+		if t.Organizer != user {
+			return ErrNotOrganizer
+		}
+		if t.Status != Registering {
+			return ErrNotRegistering
+		}
+		if len(t.Players) < 2 {
+			return ErrTooFewPlayers
+		}
+		t.Status = Running
+		switch t.Format {
+		case SingleElimination:
+			t.buildSingle()
+		case DoubleElimination:
+			t.buildDouble()
+		case RoundRobin:
+			t.buildRoundRobin()
+			t.startRound(1)
+		case Swiss:
+			if t.Rounds < 1 {
+				t.Rounds = swissRounds(len(t.Players))
+			}
+			t.startRound(1)
+		}
+		log.Printf("[TOURNEY] %s started (%s, %d players)", t.ID, t.Format, len(t.Players))
+		mg.progress(t)
+		return nil
+	})
+}
+
+// Report ingests a finished game. winner is the winning user, empty for a
+// draw. Games that are not tournament matches are ignored.
+func (mg *Manager) Report(gameID, winner string) {
+	mg.mu.Lock()
+	ref, ok := mg.byGame[gameID]
+	if !ok {
+		mg.mu.Unlock()
+		return
+	}
+	delete(mg.byGame, gameID)
+	t := mg.tours[ref.tournament]
+	m := t.match(ref.match)
+	if m.Status != Playing || m.GameID != gameID {
+		mg.mu.Unlock()
+		return
+	}
+	switch {
+	case winner == "" && t.elimination():
+		log.Printf("[TOURNEY] %s match %d drawn, replaying", t.ID, m.ID)
+		mg.createGame(t, m) // a knockout match needs a winner
+	case winner == "":
+		m.Status, m.Draw = Done, true
+	default:
+		m.finish(winner)
+		t.afterFinal(m)
+	}
+	mg.progress(t)
+	v := t.snapshot()
+	mg.mu.Unlock()
+	mg.emit(v)
+}
+
+// progress creates games for newly playable matches, moves round based
+// formats to their next round and detects the end of the tournament.
+func (mg *Manager) progress(t *Tournament) {
+	for {
+		for _, m := range t.settle() {
+			mg.createGame(t, m)
+		}
+		if !t.elimination() {
+			if !roundDone(t, t.Round) {
+				return
+			}
+			if t.Round < t.Rounds {
+				t.startRound(t.Round + 1)
+				continue
+			}
+			t.Status = Finished
+			t.Winner = t.standings()[0].User
+		} else {
+			last := t.Matches[len(t.Matches)-1]
+			if last.Status != Done {
+				return
+			}
+			t.Status, t.Winner = Finished, last.Winner
+		}
+		log.Printf("[TOURNEY] %s finished, winner %s", t.ID, t.Winner)
+		return
+	}
+}
+
+func roundDone(t *Tournament, r int) bool {
+	for _, m := range t.Matches {
+		if m.Round == r && m.Status != Done {
+			return false
+		}
+	}
+	return true
+}
+
+func (mg *Manager) createGame(t *Tournament, m *Match) {
+	first, second := m.A, m.B
+	if len(m.Games)%2 == 1 {
+		first, second = m.B, m.A // replays of a drawn match alternate who opens
+	}
+	id, err := mg.start(t.Game, first, second, t.Rated)
+	if err != nil {
+		log.Printf("[TOURNEY] %s match %d: cannot create game: %v", t.ID, m.ID, err)
+		return
+	}
+	m.GameID = id
+	m.Games = append(m.Games, id)
+	mg.byGame[id] = matchRef{tournament: t.ID, match: m.ID}
+}
+
+func (mg *Manager) update(id string, fn func(*Tournament) error) (View, error) {
+	mg.mu.Lock()
+	t, ok := mg.tours[id]
+	if !ok {
+		mg.mu.Unlock()
+		return View{}, ErrNotFound
+	}
+	if err := fn(t); err != nil {
+		mg.mu.Unlock()
+		return View{}, err
+	}
+	v := view(t)
+	mg.mu.Unlock()
+	mg.emit(v.Tournament)
+	return v, nil
+}
+
+func (mg *Manager) emit(t Tournament) {
+	if mg.notify != nil {
+		mg.notify(t)
+	}
+}
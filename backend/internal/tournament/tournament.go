@@ -0,0 +1,209 @@
+package tournament
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"time"
+)
+
+// Format is the competition structure of a tournament.
+type Format string
+
+const (
+	SingleElimination Format = "single-elimination"
+	DoubleElimination Format = "double-elimination"
+	RoundRobin        Format = "round-robin"
+	Swiss             Format = "swiss"
+)
+
+// Tournament statuses.
+const (
+	Registering = "registering"
+	Running     = "running"
+	Finished    = "finished"
+)
+
+// Match statuses.
+const (
+	Pending = "pending" // waiting for a feeder match to decide a slot
+	Playing = "playing"
+	Done    = "done"
+)
+
+// Bracket names used by Match.Bracket.
+const (
+	MainBracket    = "main" // single elimination, round robin and swiss
+	WinnersBracket = "winners"
+	LosersBracket  = "losers"
+	FinalBracket   = "final"
+)
+
+var (
+	ErrNotFound       = errors.New("tournament not found")
+	ErrBadFormat      = errors.New("unknown tournament format")
+	ErrNotRegistering = errors.New("tournament is not open for registration")
+	ErrAlreadyJoined  = errors.New("already registered")
+	ErrNotJoined      = errors.New("not registered")
+	ErrTooFewPlayers  = errors.New("at least two players are needed")
+	ErrNotOrganizer   = errors.New("only the organizer can do that")
+	ErrMissingUser    = errors.New("missing user")
+	ErrUnknownGame    = errors.New("game type cannot be played in tournaments")
+)
+
+// source says which earlier match decides a slot: its winner, or its loser.
+type source struct {
+	match int
+	loser bool
+}
+
+// Match is one pairing. A or B is empty for a bye. In elimination formats a
+// drawn game is replayed, so Games lists every game played for the match.
+type Match struct {
+	ID      int      `json:"id"`
+	Bracket string   `json:"bracket"`
+	Round   int      `json:"round"`
+	A       string   `json:"a"`
+	B       string   `json:"b"`
+	Status  string   `json:"status"`
+	GameID  string   `json:"gameId,omitempty"`
+	Games   []string `json:"games,omitempty"`
+	Winner  string   `json:"winner,omitempty"`
+	Loser   string   `json:"loser,omitempty"`
+	Draw    bool     `json:"draw,omitempty"`
+	Bye     bool     `json:"bye,omitempty"`
+
+	from  [2]*source
+	ready [2]bool
+}
+
+// Tournament is a competition between registered users on one game type.
+type Tournament struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Game      string    `json:"game"`
+	Format    Format    `json:"format"`
+	Organizer string    `json:"organizer"`
+	Rated     bool      `json:"rated"`
+	Players   []string  `json:"players"`
+	Status    string    `json:"status"`
+	Rounds    int       `json:"rounds,omitempty"` // round robin and swiss
+	Round     int       `json:"round,omitempty"`  // current round (round robin and swiss)
+	Matches   []*Match  `json:"matches"`
+	Winner    string    `json:"winner,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+
+	schedule [][][2]string // round robin pairings per round
+}
+
+func newID() string {
+	b := make([]byte, 4)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func validFormat(f Format) bool {
+	switch f {
+	case SingleElimination, DoubleElimination, RoundRobin, Swiss:
+		return true
+	}
+	return false
+}
+
+func (t *Tournament) elimination() bool {
+	return t.Format == SingleElimination || t.Format == DoubleElimination
+}
+
+func (t *Tournament) addMatch(bracket string, round int, a, b string) *Match {
+	m := &Match{ID: len(t.Matches) + 1, Bracket: bracket, Round: round, A: a, B: b, Status: Pending, ready: [2]bool{true, true}}
+	t.Matches = append(t.Matches, m)
+	return m
+}
+
+func (t *Tournament) addFed(bracket string, round int, a, b *source) *Match {
+	m := &Match{ID: len(t.Matches) + 1, Bracket: bracket, Round: round, Status: Pending, from: [2]*source{a, b}}
+	t.Matches = append(t.Matches, m)
+	return m
+}
+
+func (t *Tournament) match(id int) *Match { return t.Matches[id-1] }
+
+// snapshot deep copies t so it can be handed out without the manager lock.
+func (t *Tournament) snapshot() Tournament {
+	c := *t
+	c.Players = append([]string{}, t.Players...)
+	c.Matches = make([]*Match, len(t.Matches))
+	for i, m := range t.Matches {
+		mc := *m
+		mc.Games = append([]string{}, m.Games...)
+		c.Matches[i] = &mc
+	}
+	c.schedule = nil
+	return c
+}
+
+// resolveBye marks m done without a game; the present player (if any) advances.
+func (m *Match) resolveBye() {
+	m.Status, m.Bye = Done, true
+	if m.A != "" {
+		m.Winner = m.A
+	} else {
+		m.Winner = m.B
+	}
+}
+
+// finish records a decided result for m.
+func (m *Match) finish(winner string) {
+	m.Status, m.Winner = Done, winner
+	if winner == m.A {
+		m.Loser = m.B
+	} else {
+		m.Loser = m.A
+	}
+}
+
+// settle fills slots whose feeder matches are done, auto-advances byes and
+// returns matches that are now ready for a game to be created.
+func (t *Tournament) settle() []*Match {
+	var playable []*Match
+	for changed := true; changed; {
+		changed = false
+		for _, m := range t.Matches {
+			if m.Status != Pending {
+				continue
+			}
+			for i, src := range m.from {
+				if m.ready[i] || src == nil {
+					continue
+				}
+				f := t.match(src.match)
+				if f.Status != Done {
+					continue
+				}
+				p := f.Winner
+				if src.loser {
+					p = f.Loser
+				}
+				if i == 0 {
+					m.A = p
+				} else {
+					m.B = p
+				}
+				m.ready[i] = true
+				changed = true
+			}
+			if !m.ready[0] || !m.ready[1] {
+				continue
+			}
+			if m.A == "" || m.B == "" {
+				m.resolveBye()
+				changed = true
+				continue
+			}
+			m.Status = Playing
+			playable = append(playable, m)
+			changed = true
+		}
+	}
+	return playable
+}
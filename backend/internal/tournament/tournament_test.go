@@ -0,0 +1,240 @@
+package tournament
+
+import (
+	"fmt"
+	"testing"
+)
+
+// harness runs tournaments with fake games; decide picks the winner of a
+// game between a and b ("" for a draw).
+type harness struct {
+	mg    *Manager
+	games map[string][2]string
+	n     int
+}
+
+func newHarness() *harness {
+	h := &harness{games: map[string][2]string{}}
+	h.mg = NewManager([]string{"tictactoe"}, func(game, a, b string, rated bool) (string, error) {
+		h.n++
+		id := fmt.Sprintf("g%d", h.n)
+		h.games[id] = [2]string{a, b}
+		return id, nil
+	}, nil)
+	return h
+}
+
+func (h *harness) run(t *testing.T, format Format, players []string, decide func(a, b string) string) View {
+	v, err := h.mg.Create("org", "cup", "tictactoe", format, false, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range players {
+		if _, err := h.mg.Join(v.ID, p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := h.mg.Start(v.ID, "org"); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 1000; i++ {
+		v, _ = h.mg.Get(v.ID)
+		if v.Status == Finished {
+			return v
+		}
+		played := false
+		for _, m := range v.Matches {
+			if m.Status == Playing {
+				g := h.games[m.GameID]
+				h.mg.Report(m.GameID, decide(g[0], g[1]))
+				played = true
+				break
+			}
+		}
+		if !played {
+			t.Fatalf("tournament stalled: %+v", v)
+		}
+	}
+	t.Fatal("tournament did not finish")
+	return v
+}
+
+// lowerWins makes the alphabetically smaller name win every game.
+func lowerWins(a, b string) string {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func TestSingleEliminationWithByes(t *testing.T) {
+	h := newHarness()
+	v := h.run(t, SingleElimination, []string{"a", "b", "c", "d", "e"}, lowerWins)
+	if v.Winner != "a" {
+		t.Fatalf("expected a to win, got %q", v.Winner)
+	}
+	byes := 0
+	for _, m := range v.Matches {
+		if m.Bye {
+			byes++
+		}
+	}
+	if byes != 3 || len(h.games) != 4 {
+		t.Fatalf("5 players need 3 byes and 4 games, got %d byes %d games", byes, len(h.games))
+	}
+	if v.Standings[0].User != "a" || !v.Standings[len(v.Standings)-1].Eliminated {
+		t.Fatalf("unexpected standings %+v", v.Standings)
+	}
+}
+
+func TestDoubleEliminationGrandFinalReset(t *testing.T) {
+	h := newHarness()
+	// "d" loses its first match, then wins everything including both grand finals.
+	first := true
+	v := h.run(t, DoubleElimination, []string{"a", "b", "c", "d"}, func(a, b string) string {
+		if a == "d" || b == "d" {
+			if first {
+				first = false
+				return lowerWins(a, b)
+			}
+			return "d"
+		}
+		return lowerWins(a, b)
+	})
+	if v.Winner != "d" {
+		t.Fatalf("expected losers bracket champion d, got %q", v.Winner)
+	}
+	finals := 0
+	for _, m := range v.Matches {
+		if m.Bracket == FinalBracket {
+			finals++
+		}
+	}
+	if finals != 2 {
+		t.Fatalf("expected a grand final reset, got %d finals", finals)
+	}
+	for _, s := range v.Standings {
+		if s.User != "d" && s.Losses < 2 && s.Eliminated {
+			t.Fatalf("%s eliminated with %d losses", s.User, s.Losses)
+		}
+	}
+}
+
+func TestDrawsReplayInKnockout(t *testing.T) {
+	h := newHarness()
+	draws := 2
+	v := h.run(t, SingleElimination, []string{"a", "b"}, func(a, b string) string {
+		if draws > 0 {
+			draws--
+			return ""
+		}
+		return "b"
+	})
+	if v.Winner != "b" || len(v.Matches[0].Games) != 3 {
+		t.Fatalf("expected two replays then b, got %+v", v.Matches[0])
+	}
+	// each replay swaps the seats so neither player always opens
+	for i, id := range v.Matches[0].Games {
+		want := [2]string{"a", "b"}
+		if i%2 == 1 {
+			want = [2]string{"b", "a"}
+		}
+		if h.games[id] != want {
+			t.Fatalf("game %d seated %v, want %v", i, h.games[id], want)
+		}
+	}
+}
+
+func TestRoundRobinPlaysEveryPairing(t *testing.T) {
+	h := newHarness()
+	v := h.run(t, RoundRobin, []string{"a", "b", "c", "d", "e"}, lowerWins)
+	seen := map[string]bool{}
+	for _, g := range h.games {
+		a, b := g[0], g[1]
+		if a > b {
+			a, b = b, a
+		}
+		seen[a+b] = true
+	}
+	if len(h.games) != 10 || len(seen) != 10 || v.Rounds != 5 {
+		t.Fatalf("expected 10 distinct games over 5 rounds, got %d/%d/%d", len(h.games), len(seen), v.Rounds)
+	}
+	if v.Winner != "a" || v.Standings[0].Points != 4 || v.Standings[4].Points != 0 {
+		t.Fatalf("unexpected standings %+v", v.Standings)
+	}
+}
+
+func TestSwissAvoidsRematchesAndGivesOneByeEach(t *testing.T) {
+	h := newHarness()
+	v := h.run(t, Swiss, []string{"a", "b", "c", "d", "e"}, lowerWins)
+	if v.Rounds != 3 {
+		t.Fatalf("expected 3 rounds for 5 players, got %d", v.Rounds)
+	}
+	met := map[string]bool{}
+	for _, g := range h.games {
+		a, b := g[0], g[1]
+		if a > b {
+			a, b = b, a
+		}
+		if met[a+b] {
+			t.Fatalf("rematch %s-%s", a, b)
+		}
+		met[a+b] = true
+	}
+	byes := map[string]int{}
+	for _, m := range v.Matches {
+		if m.Bye {
+			byes[m.Winner]++
+		}
+	}
+	if len(byes) != 3 {
+		t.Fatalf("expected 3 different bye recipients, got %v", byes)
+	}
+	if v.Winner != "a" {
+		t.Fatalf("expected a to top the swiss, got %q", v.Winner)
+	}
+}
+
+func TestRegistrationRules(t *testing.T) {
+	h := newHarness()
+	if _, err := h.mg.Create("org", "x", "hangman", RoundRobin, false, 0); err != ErrUnknownGame {
+		t.Fatalf("expected unknown game, got %v", err)
+	}
+	v, _ := h.mg.Create("org", "x", "tictactoe", Swiss, false, 0)
+	h.mg.Join(v.ID, "a")
+	if _, err := h.mg.Join(v.ID, "a"); err != ErrAlreadyJoined {
+		t.Fatalf("expected duplicate join error, got %v", err)
+	}
+	if _, err := h.mg.Start(v.ID, "org"); err != ErrTooFewPlayers {
+		t.Fatalf("expected too few players, got %v", err)
+	}
+	h.mg.Join(v.ID, "b")
+	if _, err := h.mg.Start(v.ID, "a"); err != ErrNotOrganizer {
+		t.Fatalf("expected organizer check, got %v", err)
+	}
+}
+
+func TestDoubleEliminationEveryLoserLosesTwice(t *testing.T) {
+	players := []string{"a", "b", "c", "d", "e", "f", "g", "h", "i"}
+	upsets := func(a, b string) string { // the later seed wins
+		if a > b {
+			return a
+		}
+		return b
+	}
+	for n := 2; n <= len(players); n++ {
+		for _, decide := range []func(a, b string) string{lowerWins, upsets} {
+			h := newHarness()
+			v := h.run(t, DoubleElimination, players[:n], decide)
+			for _, s := range v.Standings {
+				if s.User == v.Winner {
+					if s.Losses > 1 {
+						t.Fatalf("n=%d: champion %s has %d losses", n, s.User, s.Losses)
+					}
+				} else if s.Losses != 2 || !s.Eliminated {
+					t.Fatalf("n=%d: %s finished with %d losses", n, s.User, s.Losses)
+				}
+			}
+		}
+	}
+}